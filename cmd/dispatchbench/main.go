@@ -0,0 +1,177 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/internal/flexletpb"
+	"github.com/nya3jp/flex/internal/grpcutil"
+)
+
+// run measures the latency of dispatching jobs to flexlets waiting for tasks.
+// It simulates flexlets that finish tasks immediately so that only dispatch
+// overhead is measured.
+func run(ctx context.Context, cl flex.FlexServiceClient, fcl flexletpb.FlexletServiceClient, flexlets, jobs int, interval time.Duration) error {
+	ctx, cancel := context.WithCancel(ctx)
+
+	var mu sync.Mutex
+	submitted := make(map[int64]time.Time)
+	var latencies []time.Duration
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer cancel()
+
+	for i := 0; i < flexlets; i++ {
+		name := fmt.Sprintf("dispatchbench-%d", i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				res, err := fcl.TakeTask(ctx, &flexletpb.TakeTaskRequest{FlexletName: name, Wait: true})
+				if err != nil {
+					if code := status.Code(err); ctx.Err() == nil && code != codes.NotFound && code != codes.DeadlineExceeded {
+						log.Printf("WARNING: TakeTask failed: %v", err)
+						time.Sleep(time.Second)
+					}
+					continue
+				}
+				taken := time.Now()
+				ref := res.GetTask().GetRef()
+				if _, err := fcl.FinishTask(ctx, &flexletpb.FinishTaskRequest{Ref: ref, Result: &flex.TaskResult{}}); err != nil && ctx.Err() == nil {
+					log.Printf("WARNING: FinishTask failed: %v", err)
+				}
+
+				mu.Lock()
+				if start, ok := submitted[ref.GetJobId()]; ok {
+					latencies = append(latencies, taken.Sub(start))
+					if len(latencies) == jobs {
+						close(done)
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	// Give flexlets time to start waiting.
+	time.Sleep(time.Second)
+
+	log.Printf("Submitting %d jobs to %d flexlets", jobs, flexlets)
+	start := time.Now()
+	for i := 0; i < jobs; i++ {
+		mu.Lock()
+		sent := time.Now()
+		res, err := cl.SubmitJob(ctx, &flex.SubmitJobRequest{
+			Spec: &flex.JobSpec{
+				Command: &flex.JobCommand{Args: []string{"true"}},
+			},
+		})
+		if err != nil {
+			mu.Unlock()
+			return fmt.Errorf("failed to submit a job: %w", err)
+		}
+		submitted[res.GetId()] = sent
+		mu.Unlock()
+
+		if interval > 0 {
+			time.Sleep(interval)
+		}
+	}
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	elapsed := time.Since(start)
+
+	mu.Lock()
+	defer mu.Unlock()
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	var sum time.Duration
+	for _, l := range latencies {
+		sum += l
+	}
+	percentile := func(p int) time.Duration {
+		return latencies[(len(latencies)-1)*p/100]
+	}
+
+	fmt.Printf("Jobs:        %d\n", jobs)
+	fmt.Printf("Flexlets:    %d\n", flexlets)
+	fmt.Printf("Elapsed:     %v\n", elapsed.Round(time.Millisecond))
+	fmt.Printf("Throughput:  %.1f jobs/s\n", float64(jobs)/elapsed.Seconds())
+	fmt.Printf("Latency avg: %v\n", (sum / time.Duration(len(latencies))).Round(time.Millisecond))
+	fmt.Printf("Latency p50: %v\n", percentile(50).Round(time.Millisecond))
+	fmt.Printf("Latency p99: %v\n", percentile(99).Round(time.Millisecond))
+	fmt.Printf("Latency max: %v\n", latencies[len(latencies)-1].Round(time.Millisecond))
+	return nil
+}
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), unix.SIGINT, unix.SIGTERM)
+	defer cancel()
+
+	if err := func() error {
+		app := &cli.App{
+			Name:  "dispatchbench",
+			Usage: "Measures job dispatch latency with simulated flexlets",
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "hub", Required: true, Usage: "Flexhub URL"},
+				&cli.StringFlag{Name: "password", Usage: "Sets a Flex service password"},
+				&cli.IntFlag{Name: "flexlets", Value: 100, Usage: "Number of simulated flexlets"},
+				&cli.IntFlag{Name: "jobs", Value: 100, Usage: "Number of jobs to submit"},
+				&cli.DurationFlag{Name: "interval", Usage: "Interval between job submissions"},
+			},
+			Action: func(c *cli.Context) error {
+				hubURL := c.String("hub")
+				password := c.String("password")
+				flexlets := c.Int("flexlets")
+				jobs := c.Int("jobs")
+				interval := c.Duration("interval")
+				if flexlets <= 0 || jobs <= 0 {
+					return fmt.Errorf("--flexlets and --jobs must be positive")
+				}
+
+				cc, err := grpcutil.DialContext(ctx, hubURL, password)
+				if err != nil {
+					return err
+				}
+				cl := flex.NewFlexServiceClient(cc)
+				fcl := flexletpb.NewFlexletServiceClient(cc)
+
+				return run(ctx, cl, fcl, flexlets, jobs, interval)
+			},
+		}
+		return app.RunContext(ctx, os.Args)
+	}(); err != nil {
+		log.Fatalf("ERROR: %v", err)
+	}
+}
@@ -145,9 +145,14 @@ func ensurePackage(ctx context.Context, cl flex.FlexServiceClient, names []strin
 type outputFormatter interface {
 	JobStatus(jobStatus *flex.JobStatus)
 	JobStatuses(jobStatuses []*flex.JobStatus)
+	JobArrayStatus(arrayStatus *flex.JobArrayStatus)
+	BulkJobResults(results []*flex.BulkJobResult)
 	Package(pkg *flex.Package)
 	Tag(tag *flex.Tag)
 	Tags(tags []*flex.Tag)
+	WebhookDeliveries(deliveries []*flex.WebhookDelivery)
+	FlexletStatuses(flexlets []*flex.FlexletStatus)
+	FlexletInfo(info *flex.GetFlexletResponse)
 }
 
 func newOutputFormatter(c *cli.Context) outputFormatter {
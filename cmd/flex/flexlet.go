@@ -0,0 +1,187 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/nya3jp/flex"
+)
+
+var cmdFlexlet = &cli.Command{
+	Name:            "flexlet",
+	Usage:           "Flexlet-related subcommands.",
+	HideHelpCommand: true,
+	Subcommands: []*cli.Command{
+		cmdFlexletList,
+		cmdFlexletInfo,
+		cmdFlexletCordon,
+		cmdFlexletUncordon,
+		cmdFlexletDrain,
+		cmdFlexletRemove,
+	},
+}
+
+var cmdFlexletList = &cli.Command{
+	Name:  "list",
+	Usage: "Lists flexlets.",
+	Description: `Lists flexlets.
+
+Each line shows the flexlet name, the state, the number of running jobs over
+the number of cores, the time of the last heartbeat, and the number of tasks
+lost on the flexlet. A task is lost when the flexlet stops sending heartbeats
+for it, e.g. on crashes or network partitions.
+`,
+	Flags: []cli.Flag{
+		flagJSON,
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() > 0 {
+			cli.ShowSubcommandHelpAndExit(c, exitCodeHelp)
+		}
+
+		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
+			res, err := cl.ListFlexlets(ctx, &flex.ListFlexletsRequest{})
+			if err != nil {
+				return err
+			}
+			newOutputFormatter(c).FlexletStatuses(res.GetFlexlets())
+			return nil
+		})
+	},
+}
+
+var cmdFlexletInfo = &cli.Command{
+	Name:      "info",
+	Usage:     "Shows information of a flexlet.",
+	ArgsUsage: "name",
+	Description: `Shows information of a flexlet.
+
+The failure rate is the ratio of tasks that exited with non-zero codes among
+recently finished tasks.
+`,
+	Flags: []cli.Flag{
+		flagJSON,
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			cli.ShowSubcommandHelpAndExit(c, exitCodeHelp)
+		}
+		name := c.Args().Get(0)
+
+		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
+			res, err := cl.GetFlexlet(ctx, &flex.GetFlexletRequest{Name: name})
+			if err != nil {
+				return err
+			}
+			newOutputFormatter(c).FlexletInfo(res)
+			return nil
+		})
+	},
+}
+
+var cmdFlexletCordon = &cli.Command{
+	Name:      "cordon",
+	Usage:     "Cordons a flexlet.",
+	ArgsUsage: "name",
+	Description: `Cordons a flexlet.
+
+A cordoned flexlet is assigned no new task until uncordoned. Running tasks are
+not affected. Unlike draining, the flexlet keeps running.
+`,
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			cli.ShowSubcommandHelpAndExit(c, exitCodeHelp)
+		}
+		name := c.Args().Get(0)
+
+		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
+			if _, err := cl.CordonFlexlet(ctx, &flex.CordonFlexletRequest{Name: name}); err != nil {
+				return err
+			}
+			return nil
+		})
+	},
+}
+
+var cmdFlexletUncordon = &cli.Command{
+	Name:      "uncordon",
+	Usage:     "Uncordons a flexlet.",
+	ArgsUsage: "name",
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			cli.ShowSubcommandHelpAndExit(c, exitCodeHelp)
+		}
+		name := c.Args().Get(0)
+
+		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
+			if _, err := cl.UncordonFlexlet(ctx, &flex.UncordonFlexletRequest{Name: name}); err != nil {
+				return err
+			}
+			return nil
+		})
+	},
+}
+
+var cmdFlexletDrain = &cli.Command{
+	Name:      "drain",
+	Usage:     "Drains a flexlet.",
+	ArgsUsage: "name",
+	Description: `Drains a flexlet.
+
+A draining flexlet stops taking new tasks and waits for running tasks to
+finish up to its drain timeout. Tasks that do not finish in time are returned
+to the queue to be retried on other flexlets. The flexlet exits after draining.
+`,
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			cli.ShowSubcommandHelpAndExit(c, exitCodeHelp)
+		}
+		name := c.Args().Get(0)
+
+		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
+			if _, err := cl.DrainFlexlet(ctx, &flex.DrainFlexletRequest{Name: name}); err != nil {
+				return err
+			}
+			return nil
+		})
+	},
+}
+
+var cmdFlexletRemove = &cli.Command{
+	Name:      "rm",
+	Usage:     "Removes an offline flexlet.",
+	ArgsUsage: "name",
+	Description: `Removes an offline flexlet.
+
+Only offline flexlets can be removed. A removed flexlet appears again when it
+comes back online.
+`,
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			cli.ShowSubcommandHelpAndExit(c, exitCodeHelp)
+		}
+		name := c.Args().Get(0)
+
+		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
+			if _, err := cl.DeleteFlexlet(ctx, &flex.DeleteFlexletRequest{Name: name}); err != nil {
+				return err
+			}
+			return nil
+		})
+	},
+}
@@ -40,6 +40,17 @@ func (f *JSON) JobStatuses(jobStatuses []*flex.JobStatus) {
 	f.encodeJSON(jobStatuses)
 }
 
+func (f *JSON) JobArrayStatus(arrayStatus *flex.JobArrayStatus) {
+	f.encodeJSON(arrayStatus)
+}
+
+func (f *JSON) BulkJobResults(results []*flex.BulkJobResult) {
+	if results == nil {
+		results = make([]*flex.BulkJobResult, 0)
+	}
+	f.encodeJSON(results)
+}
+
 func (f *JSON) Package(pkg *flex.Package) {
 	f.encodeJSON(pkg)
 }
@@ -55,6 +66,24 @@ func (f *JSON) Tags(tags []*flex.Tag) {
 	f.encodeJSON(tags)
 }
 
+func (f *JSON) WebhookDeliveries(deliveries []*flex.WebhookDelivery) {
+	if deliveries == nil {
+		deliveries = make([]*flex.WebhookDelivery, 0)
+	}
+	f.encodeJSON(deliveries)
+}
+
+func (f *JSON) FlexletStatuses(flexlets []*flex.FlexletStatus) {
+	if flexlets == nil {
+		flexlets = make([]*flex.FlexletStatus, 0)
+	}
+	f.encodeJSON(flexlets)
+}
+
+func (f *JSON) FlexletInfo(info *flex.GetFlexletResponse) {
+	f.encodeJSON(info)
+}
+
 func (f *JSON) encodeJSON(val interface{}) {
 	enc := json.NewEncoder(f.w)
 	enc.SetIndent("", "  ")
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/alessio/shellescape"
 
@@ -47,6 +48,9 @@ func (f *Text) JobStatus(jobStatus *flex.JobStatus) {
 	}
 	fmt.Fprintf(f.w, "Priority: %d\n", spec.GetConstraints().GetPriority())
 	fmt.Fprintf(f.w, "Time Limit: %s\n", spec.GetLimits().GetTime().AsDuration().String())
+	if max := spec.GetLimits().GetMaxOutputBytes(); max > 0 {
+		fmt.Fprintf(f.w, "Output Limit: %d bytes\n", max)
+	}
 	fmt.Fprintf(f.w, "Labels: %s\n", strings.Join(spec.GetAnnotations().GetLabels(), ", "))
 	fmt.Fprintf(f.w, "State: %s\n", jobStatus.GetState().String())
 	fmt.Fprintf(f.w, "Task ID: %s\n", jobStatus.GetTaskId())
@@ -55,10 +59,20 @@ func (f *Text) JobStatus(jobStatus *flex.JobStatus) {
 		fmt.Fprintf(f.w, "Execution Result: %s\n", res.GetMessage())
 		fmt.Fprintf(f.w, "Execution Time: %d\n", res.GetTime().AsDuration())
 		fmt.Fprintf(f.w, "Exit Code: %d\n", res.GetExitCode())
+		fmt.Fprintf(f.w, "Output Size: %d bytes (stdout), %d bytes (stderr)\n", res.GetStdoutBytes(), res.GetStderrBytes())
+		if res.GetOutputTruncated() {
+			fmt.Fprintf(f.w, "Output Truncated: yes\n")
+		}
 	}
 	fmt.Fprintf(f.w, "Created Time: %s\n", jobStatus.GetCreated().AsTime().String())
 	fmt.Fprintf(f.w, "Started Time: %s\n", jobStatus.GetStarted().AsTime().String())
 	fmt.Fprintf(f.w, "Finished Time: %s\n", jobStatus.GetFinished().AsTime().String())
+	if id := jobStatus.GetClonedFrom(); id != 0 {
+		fmt.Fprintf(f.w, "Cloned From: %d\n", id)
+	}
+	if id := jobStatus.GetArrayId(); id != 0 {
+		fmt.Fprintf(f.w, "Array ID: %d\n", id)
+	}
 }
 
 func (f *Text) JobStatuses(jobStatuses []*flex.JobStatus) {
@@ -71,6 +85,34 @@ func (f *Text) JobStatuses(jobStatuses []*flex.JobStatus) {
 	}
 }
 
+func (f *Text) JobArrayStatus(arrayStatus *flex.JobArrayStatus) {
+	spec := arrayStatus.GetSpec()
+	array := arrayStatus.GetArray()
+	stats := arrayStatus.GetStats()
+	fmt.Fprintf(f.w, "Array ID: %d\n", arrayStatus.GetId())
+	fmt.Fprintf(f.w, "Command: %s\n", shellescape.QuoteCommand(spec.GetCommand().GetArgs()))
+	if params := array.GetParams(); len(params) > 0 {
+		fmt.Fprintf(f.w, "Params: %s\n", strings.Join(params, ", "))
+	} else {
+		fmt.Fprintf(f.w, "Range: %d-%d\n", array.GetStart(), array.GetEnd())
+	}
+	fmt.Fprintf(f.w, "Labels: %s\n", strings.Join(spec.GetAnnotations().GetLabels(), ", "))
+	fmt.Fprintf(f.w, "Pending Jobs: %d\n", stats.GetPendingJobs())
+	fmt.Fprintf(f.w, "Running Jobs: %d\n", stats.GetRunningJobs())
+	fmt.Fprintf(f.w, "Finished Jobs: %d\n", stats.GetFinishedJobs())
+	fmt.Fprintf(f.w, "Created Time: %s\n", arrayStatus.GetCreated().AsTime().String())
+}
+
+func (f *Text) BulkJobResults(results []*flex.BulkJobResult) {
+	for _, result := range results {
+		if result.GetOk() {
+			fmt.Fprintf(f.w, "%d\tok\n", result.GetId())
+		} else {
+			fmt.Fprintf(f.w, "%d\terror\t%s\n", result.GetId(), result.GetError())
+		}
+	}
+}
+
 func (f *Text) Package(pkg *flex.Package) {
 	fmt.Fprintf(f.w, "%s\n", pkg.GetHash())
 }
@@ -84,3 +126,93 @@ func (f *Text) Tags(tags []*flex.Tag) {
 		f.Tag(tag)
 	}
 }
+
+func (f *Text) WebhookDeliveries(deliveries []*flex.WebhookDelivery) {
+	for _, d := range deliveries {
+		state := strings.TrimPrefix(d.GetState().String(), "DELIVERY_")
+		fmt.Fprintf(f.w, "%d\t%d\t%s\t%s(%d)\t%d\t%s\t%s\n", d.GetId(), d.GetJobId(), d.GetEvent().String(), state, d.GetStatusCode(), d.GetAttempts(), d.GetUrl(), d.GetError())
+	}
+}
+
+func (f *Text) FlexletStatuses(flexlets []*flex.FlexletStatus) {
+	for _, flexlet := range flexlets {
+		fmt.Fprintf(f.w, "%s\t%s\t%d/%d\t%s\t%d\n", flexlet.GetFlexlet().GetName(), flexletState(flexlet), len(flexlet.GetCurrentJobs()), flexlet.GetFlexlet().GetSpec().GetCores(), flexlet.GetLastUpdate().AsTime().String(), flexlet.GetLostTasks())
+	}
+}
+
+func (f *Text) FlexletInfo(info *flex.GetFlexletResponse) {
+	flexlet := info.GetFlexlet()
+	stats := info.GetTaskStats()
+	fmt.Fprintf(f.w, "Name: %s\n", flexlet.GetFlexlet().GetName())
+	fmt.Fprintf(f.w, "State: %s\n", flexletState(flexlet))
+	fmt.Fprintf(f.w, "Cores: %d\n", flexlet.GetFlexlet().GetSpec().GetCores())
+	fmt.Fprintf(f.w, "Last Heartbeat: %s\n", flexlet.GetLastUpdate().AsTime().String())
+	if flexlet.GetUnhealthy() {
+		fmt.Fprintf(f.w, "Health: UNHEALTHY (%s)\n", flexlet.GetHealthError())
+	} else {
+		fmt.Fprintf(f.w, "Health: OK\n")
+	}
+	host := flexlet.GetHost()
+	fmt.Fprintf(f.w, "Version: %s\n", host.GetVersion())
+	fmt.Fprintf(f.w, "OS: %s (kernel %s)\n", host.GetOs(), host.GetKernel())
+	fmt.Fprintf(f.w, "Memory: %s free of %s\n", formatBytes(host.GetFreeMemoryBytes()), formatBytes(host.GetTotalMemoryBytes()))
+	fmt.Fprintf(f.w, "Disk Free: %s\n", formatBytes(host.GetDiskFreeBytes()))
+	cache := host.GetCache()
+	if max := cache.GetMaxBytes(); max > 0 {
+		fmt.Fprintf(f.w, "Cache Size: %s of %s (%d entries)\n", formatBytes(host.GetCacheBytes()), formatBytes(max), cache.GetEntries())
+	} else {
+		fmt.Fprintf(f.w, "Cache Size: %s (%d entries)\n", formatBytes(host.GetCacheBytes()), cache.GetEntries())
+	}
+	fmt.Fprintf(f.w, "Cache Hits: %d/%d (%d evictions)\n", cache.GetHits(), cache.GetHits()+cache.GetMisses(), cache.GetEvictions())
+	var loads []string
+	for _, load := range host.GetLoadAverage() {
+		loads = append(loads, fmt.Sprintf("%.2f", load))
+	}
+	fmt.Fprintf(f.w, "Load Average: %s\n", strings.Join(loads, ", "))
+	fmt.Fprintf(f.w, "Uptime: %s\n", host.GetUptime().AsDuration().Round(time.Second).String())
+	var jobIDs []string
+	for _, job := range flexlet.GetCurrentJobs() {
+		jobIDs = append(jobIDs, fmt.Sprint(job.GetId()))
+	}
+	fmt.Fprintf(f.w, "Running Jobs: %s\n", strings.Join(jobIDs, ", "))
+	if n := stats.GetFinishedTasks(); n > 0 {
+		fmt.Fprintf(f.w, "Failure Rate: %d/%d (%.1f%%)\n", stats.GetFailedTasks(), n, float64(stats.GetFailedTasks())*100/float64(n))
+	} else {
+		fmt.Fprintf(f.w, "Failure Rate: -\n")
+	}
+	fmt.Fprintf(f.w, "Lost Tasks: %d recently, %d in total\n", stats.GetLostTasks(), flexlet.GetLostTasks())
+	fmt.Fprintf(f.w, "Recent Tasks:\n")
+	for _, task := range info.GetRecentTasks() {
+		state := "RUNNING"
+		if task.GetLost() {
+			state = "LOST"
+		} else if res := task.GetResult(); res != nil {
+			state = fmt.Sprintf("FINISHED(%d)", res.GetExitCode())
+		}
+		fmt.Fprintf(f.w, "  %d\t%s\t%s\t%s\n", task.GetJobId(), task.GetTaskId(), state, task.GetStarted().AsTime().String())
+	}
+}
+
+func flexletState(flexlet *flex.FlexletStatus) string {
+	state := flexlet.GetState().String()
+	if flexlet.GetCordoned() {
+		state += ",CORDONED"
+	}
+	if flexlet.GetUnhealthy() {
+		state += ",UNHEALTHY"
+	}
+	return state
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
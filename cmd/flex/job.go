@@ -15,7 +15,10 @@
 package main
 
 import (
+	"bufio"
+	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -27,11 +30,14 @@ import (
 	"time"
 
 	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/nya3jp/flex"
 	"github.com/nya3jp/flex/internal/ctxutil"
 	"github.com/nya3jp/flex/internal/hashutil"
+	"github.com/nya3jp/flex/internal/joblog"
 )
 
 var flagPriority = &cli.IntFlag{
@@ -71,6 +77,16 @@ var flagTimeLimit = &cli.DurationFlag{
 	Usage:   "Sets the time limit of the job.",
 }
 
+var flagMaxOutputBytes = &cli.Int64Flag{
+	Name:  "max-output-bytes",
+	Usage: "Limits the size of each of stdout and stderr. Outputs exceeding it keep only their first and last halves. Zero means no limit.",
+}
+
+var flagKillOnOutputOverflow = &cli.BoolFlag{
+	Name:  "kill-on-output-overflow",
+	Usage: "Kills the job when an output exceeds --max-output-bytes.",
+}
+
 var flagLimit = &cli.Int64Flag{
 	Name:    "limit",
 	Aliases: []string{"n"},
@@ -109,13 +125,51 @@ var flagDelete = &cli.StringSliceFlag{
 	Usage:   "Deletes a label.",
 }
 
+var flagResolveTags = &cli.BoolFlag{
+	Name:  "resolve-tags",
+	Usage: "Resolves package tags again instead of using the package hashes of the original job.",
+}
+
+var flagWebhook = &cli.StringSliceFlag{
+	Name:  "webhook",
+	Usage: "Adds a webhook URL notified when the job starts running and finishes. Can be repeated.",
+}
+
+var flagArray = &cli.StringFlag{
+	Name:  "array",
+	Usage: "Creates a job array with child jobs for each index in the range START-END (inclusive).",
+}
+
+var flagArrayParam = &cli.StringSliceFlag{
+	Name:  "array-param",
+	Usage: "Creates a job array with a child job for each parameter. Can be repeated.",
+}
+
+var flagArrayID = &cli.Int64Flag{
+	Name:  "array",
+	Usage: "Filters jobs by job array ID.",
+}
+
+var flagProxy = &cli.BoolFlag{
+	Name:  "proxy",
+	Usage: "Downloads outputs through the hub instead of directly from the file storage.",
+}
+
+var flagTimestamps = &cli.BoolFlag{
+	Name:  "timestamps",
+	Usage: "Prefixes each line with the time it was written.",
+}
+
 var jobCreateFlags = []cli.Flag{
 	flagFile,
 	flagPackage,
 	flagShell,
 	flagTimeLimit,
+	flagMaxOutputBytes,
+	flagKillOnOutputOverflow,
 	flagPriority,
 	flagAddLabel,
+	flagWebhook,
 }
 
 var cmdJob = &cli.Command{
@@ -124,11 +178,16 @@ var cmdJob = &cli.Command{
 	HideHelpCommand: true,
 	Subcommands: []*cli.Command{
 		cmdJobCreate,
+		cmdJobRerun,
 		cmdJobWait,
 		cmdJobOutputs,
+		cmdJobLogs,
 		cmdJobInfo,
 		cmdJobList,
 		cmdJobLabel,
+		cmdJobCancel,
+		cmdJobBulk,
+		cmdJobArray,
 	},
 }
 
@@ -173,7 +232,7 @@ of three commands: "flex job create", "flex job wait" and "flex job outputs".
 			if err := waitJob(ctx, cl, id); err != nil {
 				return err
 			}
-			if err := printJobOutputs(ctx, cl, id); err != nil {
+			if err := printJobOutputs(ctx, cl, id, false); err != nil {
 				return err
 			}
 			return nil
@@ -205,18 +264,41 @@ You can add package references to a job in one of two ways, or both:
   - Use --package option (can be repeated) to attach an existing package to
     the job.
 
+To submit many similar jobs at once, use --array or --array-param to create
+a job array. A job array expands to child jobs sharing the same command line,
+each of which has the following environment variables set:
+
+  - FLEX_ARRAY_ID: the job array ID
+  - FLEX_ARRAY_INDEX: the index in --array range, or the 0-based position of
+    the parameter in --array-param
+  - FLEX_ARRAY_PARAM: the parameter (only with --array-param)
+
 This command finishes as soon as it successfully submits a job. It does not
 wait for the completion of the job.
 
-This command prints a job ID to the standard output on success.
+This command prints a job ID to the standard output on success. If a job array
+is created, its array ID is printed instead.
 `,
-	Flags: jobCreateFlags,
+	Flags: append([]cli.Flag{flagArray, flagArrayParam}, jobCreateFlags...),
 	Action: func(c *cli.Context) error {
 		args, err := makeArgs(c)
 		if err != nil {
 			return err
 		}
+		array, err := makeJobArray(c)
+		if err != nil {
+			return err
+		}
 		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
+			if array != nil {
+				id, err := submitJobArray(ctx, cl, c, args, array)
+				if err != nil {
+					return err
+				}
+				fmt.Println(id)
+				return nil
+			}
+
 			id, err := submitJob(ctx, cl, c, args)
 			if err != nil {
 				return err
@@ -227,6 +309,58 @@ This command prints a job ID to the standard output on success.
 	},
 }
 
+var cmdJobRerun = &cli.Command{
+	Name:      "rerun",
+	Aliases:   []string{"clone"},
+	Usage:     "Creates a new job from an existing job.",
+	ArgsUsage: "job-id",
+	Description: `Creates a new job from an existing job.
+
+The new job has the same command line, packages, time limit, priority and
+labels as the original job, except for those overridden by flags. Packages
+specified by tags in the original job are pinned to the same hashes unless
+--resolve-tags is set.
+
+This command prints a job ID to the standard output on success.
+`,
+	Flags: []cli.Flag{
+		flagTimeLimit,
+		flagPriority,
+		flagResolveTags,
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			cli.ShowSubcommandHelpAndExit(c, exitCodeHelp)
+		}
+		id, err := strconv.ParseInt(c.Args().Get(0), 10, 64)
+		if err != nil {
+			return err
+		}
+
+		overrides := &flex.JobSpecOverrides{}
+		if c.IsSet(flagTimeLimit.Name) {
+			overrides.Limits = &flex.JobLimits{Time: durationpb.New(c.Duration(flagTimeLimit.Name))}
+		}
+		if c.IsSet(flagPriority.Name) {
+			overrides.Constraints = &flex.JobConstraints{Priority: int32(c.Int(flagPriority.Name))}
+		}
+
+		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
+			res, err := cl.CloneJob(ctx, &flex.CloneJobRequest{
+				Id:          id,
+				Overrides:   overrides,
+				ResolveTags: c.Bool(flagResolveTags.Name),
+			})
+			if err != nil {
+				return err
+			}
+			log.Printf("Submitted job %d", res.GetId())
+			fmt.Println(res.GetId())
+			return nil
+		})
+	},
+}
+
 var cmdJobWait = &cli.Command{
 	Name:      "wait",
 	Usage:     "Waits a job.",
@@ -264,7 +398,14 @@ var cmdJobOutputs = &cli.Command{
 	Description: `Prints out job outputs.
 
 Prints the job output to stdout/stderr. The job should have already finished.
+
+Outputs are downloaded directly from the file storage by default. Set --proxy
+to download them through the hub, e.g. when the file storage is not reachable
+from your network.
 `,
+	Flags: []cli.Flag{
+		flagProxy,
+	},
 	Action: func(c *cli.Context) error {
 		if c.NArg() != 1 {
 			cli.ShowSubcommandHelpAndExit(c, exitCodeHelp)
@@ -275,8 +416,10 @@ Prints the job output to stdout/stderr. The job should have already finished.
 			return err
 		}
 
+		proxy := c.Bool(flagProxy.Name)
+
 		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
-			if err := printJobOutputs(ctx, cl, id); err != nil {
+			if err := printJobOutputs(ctx, cl, id, proxy); err != nil {
 				return err
 			}
 			return nil
@@ -284,6 +427,42 @@ Prints the job output to stdout/stderr. The job should have already finished.
 	},
 }
 
+var cmdJobLogs = &cli.Command{
+	Name:      "logs",
+	Usage:     "Prints out the combined log of job outputs.",
+	ArgsUsage: "job-id",
+	Description: `Prints out the combined log of job outputs.
+
+Prints lines written to stdout and stderr by the job in the order they were
+written, to stdout and stderr respectively. Set --timestamps to prefix each
+line with the time it was written. The job should have already finished.
+`,
+	Flags: []cli.Flag{
+		flagTimestamps,
+		flagProxy,
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			cli.ShowSubcommandHelpAndExit(c, exitCodeHelp)
+		}
+
+		id, err := strconv.ParseInt(c.Args().Get(0), 10, 64)
+		if err != nil {
+			return err
+		}
+
+		timestamps := c.Bool(flagTimestamps.Name)
+		proxy := c.Bool(flagProxy.Name)
+
+		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
+			if err := printJobLog(ctx, cl, id, timestamps, proxy); err != nil {
+				return fmt.Errorf("failed to retrieve log: %v", err)
+			}
+			return nil
+		})
+	},
+}
+
 var cmdJobInfo = &cli.Command{
 	Name:      "info",
 	Aliases:   []string{"get"},
@@ -327,6 +506,7 @@ Jobs are sorted in the decreasing order of their IDs.
 		flagBefore,
 		flagState,
 		flagLabel,
+		flagArrayID,
 		flagJSON,
 	},
 	Action: func(c *cli.Context) error {
@@ -334,22 +514,14 @@ Jobs are sorted in the decreasing order of their IDs.
 		beforeID := c.Int64(flagBefore.Name)
 		stateStr := c.String(flagState.Name)
 		label := c.String(flagLabel.Name)
+		arrayID := c.Int64(flagArrayID.Name)
 		if c.NArg() > 0 {
 			cli.ShowSubcommandHelpAndExit(c, exitCodeHelp)
 		}
 
-		var state flex.JobState
-		switch strings.ToLower(stateStr) {
-		case "":
-			state = flex.JobState_UNSPECIFIED
-		case "pending":
-			state = flex.JobState_PENDING
-		case "running":
-			state = flex.JobState_RUNNING
-		case "finished":
-			state = flex.JobState_FINISHED
-		default:
-			return fmt.Errorf("unknown job state: %s", stateStr)
+		state, err := parseJobState(stateStr)
+		if err != nil {
+			return err
 		}
 
 		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
@@ -358,6 +530,7 @@ Jobs are sorted in the decreasing order of their IDs.
 				BeforeId: beforeID,
 				State:    state,
 				Label:    label,
+				ArrayId:  arrayID,
 			})
 			if err != nil {
 				return err
@@ -406,6 +579,295 @@ This command allows updating job labels after submission.
 	},
 }
 
+var cmdJobCancel = &cli.Command{
+	Name:      "cancel",
+	Usage:     "Cancels a job.",
+	ArgsUsage: "job-id",
+	Description: `Cancels a job.
+
+A pending job is finished without being run. A running job is marked finished
+and its result from the flexlet is discarded.
+`,
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			cli.ShowSubcommandHelpAndExit(c, exitCodeHelp)
+		}
+		id, err := strconv.ParseInt(c.Args().Get(0), 10, 64)
+		if err != nil {
+			return err
+		}
+
+		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
+			if _, err := cl.CancelJob(ctx, &flex.CancelJobRequest{Id: id}); err != nil {
+				return err
+			}
+			return nil
+		})
+	},
+}
+
+var jobFilterFlags = []cli.Flag{
+	flagState,
+	flagLabel,
+	flagArrayID,
+	flagJSON,
+}
+
+var cmdJobBulk = &cli.Command{
+	Name:            "bulk",
+	Usage:           "Operates on all jobs matching a filter.",
+	HideHelpCommand: true,
+	Description: `Operates on all jobs matching a filter.
+
+Jobs are selected by --state, --label and/or --array. At least one of them
+must be specified. The result of the operation is reported for each job.
+`,
+	Subcommands: []*cli.Command{
+		cmdJobBulkLabel,
+		cmdJobBulkCancel,
+		cmdJobBulkRetry,
+	},
+}
+
+var cmdJobBulkLabel = &cli.Command{
+	Name:  "label",
+	Usage: "Updates labels of matching jobs.",
+	Flags: append([]cli.Flag{flagAdd, flagDelete}, jobFilterFlags...),
+	Action: func(c *cli.Context) error {
+		adds := c.StringSlice(flagAdd.Name)
+		dels := c.StringSlice(flagDelete.Name)
+		filter, err := makeJobFilter(c)
+		if err != nil {
+			return err
+		}
+
+		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
+			res, err := cl.BulkUpdateJobLabels(ctx, &flex.BulkUpdateJobLabelsRequest{
+				Filter: filter,
+				Adds:   adds,
+				Dels:   dels,
+			})
+			if err != nil {
+				return err
+			}
+			return reportBulkJobResults(c, res.GetResults())
+		})
+	},
+}
+
+var cmdJobBulkCancel = &cli.Command{
+	Name:  "cancel",
+	Usage: "Cancels matching jobs.",
+	Flags: jobFilterFlags,
+	Action: func(c *cli.Context) error {
+		filter, err := makeJobFilter(c)
+		if err != nil {
+			return err
+		}
+
+		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
+			res, err := cl.BulkCancelJobs(ctx, &flex.BulkCancelJobsRequest{Filter: filter})
+			if err != nil {
+				return err
+			}
+			return reportBulkJobResults(c, res.GetResults())
+		})
+	},
+}
+
+var cmdJobBulkRetry = &cli.Command{
+	Name:  "retry",
+	Usage: "Puts matching finished jobs back to the queue.",
+	Flags: jobFilterFlags,
+	Action: func(c *cli.Context) error {
+		filter, err := makeJobFilter(c)
+		if err != nil {
+			return err
+		}
+
+		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
+			res, err := cl.BulkRetryJobs(ctx, &flex.BulkRetryJobsRequest{Filter: filter})
+			if err != nil {
+				return err
+			}
+			return reportBulkJobResults(c, res.GetResults())
+		})
+	},
+}
+
+var cmdJobArray = &cli.Command{
+	Name:            "array",
+	Usage:           "Job array subcommands.",
+	HideHelpCommand: true,
+	Description: `Job array subcommands.
+
+A job array is a group of child jobs created by "flex job create" with --array
+or --array-param. Child jobs can be listed with "flex job list --array".
+`,
+	Subcommands: []*cli.Command{
+		cmdJobArrayInfo,
+		cmdJobArrayWait,
+		cmdJobArrayCancel,
+	},
+}
+
+var cmdJobArrayInfo = &cli.Command{
+	Name:      "info",
+	Aliases:   []string{"get"},
+	Usage:     "Shows job array info.",
+	ArgsUsage: "array-id",
+	Flags: []cli.Flag{
+		flagJSON,
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			cli.ShowSubcommandHelpAndExit(c, exitCodeHelp)
+		}
+		id, err := strconv.ParseInt(c.Args().Get(0), 10, 64)
+		if err != nil {
+			return err
+		}
+
+		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
+			res, err := cl.GetJobArray(ctx, &flex.GetJobArrayRequest{Id: id})
+			if err != nil {
+				return err
+			}
+			newOutputFormatter(c).JobArrayStatus(res.GetArray())
+			return nil
+		})
+	},
+}
+
+var cmdJobArrayWait = &cli.Command{
+	Name:      "wait",
+	Usage:     "Waits all jobs in a job array.",
+	ArgsUsage: "array-id",
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			cli.ShowSubcommandHelpAndExit(c, exitCodeHelp)
+		}
+		id, err := strconv.ParseInt(c.Args().Get(0), 10, 64)
+		if err != nil {
+			return err
+		}
+
+		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
+			log.Printf("Waiting for job array %d", id)
+			return waitJobArray(ctx, cl, id)
+		})
+	},
+}
+
+var cmdJobArrayCancel = &cli.Command{
+	Name:      "cancel",
+	Usage:     "Cancels unfinished jobs in a job array.",
+	ArgsUsage: "array-id",
+	Flags: []cli.Flag{
+		flagJSON,
+	},
+	Action: func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			cli.ShowSubcommandHelpAndExit(c, exitCodeHelp)
+		}
+		id, err := strconv.ParseInt(c.Args().Get(0), 10, 64)
+		if err != nil {
+			return err
+		}
+
+		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
+			var results []*flex.BulkJobResult
+			for _, state := range []flex.JobState{flex.JobState_PENDING, flex.JobState_RUNNING} {
+				res, err := cl.BulkCancelJobs(ctx, &flex.BulkCancelJobsRequest{
+					Filter: &flex.JobFilter{State: state, ArrayId: id},
+				})
+				if err != nil {
+					return err
+				}
+				results = append(results, res.GetResults()...)
+			}
+			return reportBulkJobResults(c, results)
+		})
+	},
+}
+
+func parseJobState(s string) (flex.JobState, error) {
+	switch strings.ToLower(s) {
+	case "":
+		return flex.JobState_UNSPECIFIED, nil
+	case "pending":
+		return flex.JobState_PENDING, nil
+	case "running":
+		return flex.JobState_RUNNING, nil
+	case "finished":
+		return flex.JobState_FINISHED, nil
+	default:
+		return flex.JobState_UNSPECIFIED, fmt.Errorf("unknown job state: %s", s)
+	}
+}
+
+func makeJobFilter(c *cli.Context) (*flex.JobFilter, error) {
+	if c.NArg() > 0 {
+		cli.ShowSubcommandHelpAndExit(c, exitCodeHelp)
+	}
+	state, err := parseJobState(c.String(flagState.Name))
+	if err != nil {
+		return nil, err
+	}
+	label := c.String(flagLabel.Name)
+	arrayID := c.Int64(flagArrayID.Name)
+	if state == flex.JobState_UNSPECIFIED && label == "" && arrayID == 0 {
+		return nil, errors.New("one of --state, --label or --array must be specified")
+	}
+	return &flex.JobFilter{State: state, Label: label, ArrayId: arrayID}, nil
+}
+
+func makeJobArray(c *cli.Context) (*flex.JobArray, error) {
+	rangeStr := c.String(flagArray.Name)
+	params := c.StringSlice(flagArrayParam.Name)
+	if rangeStr != "" && len(params) > 0 {
+		return nil, errors.New("--array and --array-param are exclusive")
+	}
+	if len(params) > 0 {
+		return &flex.JobArray{Params: params}, nil
+	}
+	if rangeStr == "" {
+		return nil, nil
+	}
+
+	i := strings.Index(rangeStr, "-")
+	if i < 0 {
+		return nil, fmt.Errorf("invalid --array range: %s", rangeStr)
+	}
+	start, err := strconv.ParseInt(rangeStr[:i], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --array range: %s", rangeStr)
+	}
+	end, err := strconv.ParseInt(rangeStr[i+1:], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --array range: %s", rangeStr)
+	}
+	if start > end {
+		return nil, fmt.Errorf("invalid --array range: %s", rangeStr)
+	}
+	return &flex.JobArray{Start: int32(start), End: int32(end)}, nil
+}
+
+func reportBulkJobResults(c *cli.Context, results []*flex.BulkJobResult) error {
+	newOutputFormatter(c).BulkJobResults(results)
+	failed := 0
+	for _, result := range results {
+		if !result.GetOk() {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("operation failed for %d of %d jobs", failed, len(results))
+	}
+	log.Printf("Operation succeeded for %d jobs", len(results))
+	return nil
+}
+
 func makeArgs(c *cli.Context) ([]string, error) {
 	if c.Bool(flagShell.Name) {
 		if c.NArg() != 1 {
@@ -420,16 +882,56 @@ func makeArgs(c *cli.Context) ([]string, error) {
 }
 
 func submitJob(ctx context.Context, cl flex.FlexServiceClient, c *cli.Context, args []string) (int64, error) {
+	spec, err := makeJobSpec(ctx, cl, c, args)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := cl.SubmitJob(ctx, &flex.SubmitJobRequest{Spec: spec})
+	if err != nil {
+		return 0, err
+	}
+
+	log.Printf("Submitted job %d", res.GetId())
+	return res.GetId(), nil
+}
+
+func submitJobArray(ctx context.Context, cl flex.FlexServiceClient, c *cli.Context, args []string, array *flex.JobArray) (int64, error) {
+	spec, err := makeJobSpec(ctx, cl, c, args)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := cl.SubmitJob(ctx, &flex.SubmitJobRequest{Spec: spec, Array: array})
+	if err != nil {
+		return 0, err
+	}
+
+	log.Printf("Submitted job array %d", res.GetArrayId())
+	return res.GetArrayId(), nil
+}
+
+func makeJobSpec(ctx context.Context, cl flex.FlexServiceClient, c *cli.Context, args []string) (*flex.JobSpec, error) {
 	priority := c.Int(flagPriority.Name)
 	files := c.StringSlice(flagFile.Name)
 	packages := c.StringSlice(flagPackage.Name)
 	timeLimit := c.Duration(flagTimeLimit.Name)
+	maxOutputBytes := c.Int64(flagMaxOutputBytes.Name)
+	killOnOutputOverflow := c.Bool(flagKillOnOutputOverflow.Name)
 	labels := c.StringSlice(flagAddLabel.Name)
+	webhooks := c.StringSlice(flagWebhook.Name)
+
+	if maxOutputBytes < 0 {
+		return nil, errors.New("--max-output-bytes must not be negative")
+	}
+	if killOnOutputOverflow && maxOutputBytes == 0 {
+		return nil, errors.New("--kill-on-output-overflow requires --max-output-bytes")
+	}
 
 	if len(files) > 0 {
 		hash, err := ensurePackage(ctx, cl, files)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
 		packages = append(packages, hash)
 	}
@@ -445,7 +947,7 @@ func submitJob(ctx context.Context, cl flex.FlexServiceClient, c *cli.Context, a
 		pkgs = append(pkgs, pkg)
 	}
 
-	spec := &flex.JobSpec{
+	return &flex.JobSpec{
 		Command: &flex.JobCommand{
 			Args: args,
 		},
@@ -453,7 +955,9 @@ func submitJob(ctx context.Context, cl flex.FlexServiceClient, c *cli.Context, a
 			Packages: pkgs,
 		},
 		Limits: &flex.JobLimits{
-			Time: durationpb.New(timeLimit),
+			Time:                 durationpb.New(timeLimit),
+			MaxOutputBytes:       maxOutputBytes,
+			KillOnOutputOverflow: killOnOutputOverflow,
 		},
 		Constraints: &flex.JobConstraints{
 			Priority: int32(priority),
@@ -461,85 +965,227 @@ func submitJob(ctx context.Context, cl flex.FlexServiceClient, c *cli.Context, a
 		Annotations: &flex.JobAnnotations{
 			Labels: labels,
 		},
-	}
-	res, err := cl.SubmitJob(ctx, &flex.SubmitJobRequest{Spec: spec})
+		Notifications: &flex.JobNotifications{
+			Webhooks: webhooks,
+		},
+	}, nil
+}
+
+func waitJob(ctx context.Context, cl flex.FlexServiceClient, id int64) error {
+	lastState := flex.JobState_PENDING
+	return watchJobs(ctx, cl, &flex.WatchJobsRequest{Ids: []int64{id}}, func(job *flex.JobStatus) bool {
+		state := job.GetState()
+		if state == lastState {
+			return false
+		}
+		lastState = state
+		switch state {
+		case flex.JobState_PENDING:
+			log.Printf("Job %d returned", id)
+		case flex.JobState_RUNNING:
+			log.Printf("Job %d running", id)
+		case flex.JobState_FINISHED:
+			result := job.GetResult()
+			log.Printf("Job %d finished: %s (%v)", id, result.GetMessage(), result.GetTime().AsDuration())
+			return true
+		}
+		return false
+	})
+}
+
+func waitJobArray(ctx context.Context, cl flex.FlexServiceClient, id int64) error {
+	res, err := cl.GetJobArray(ctx, &flex.GetJobArrayRequest{Id: id})
 	if err != nil {
-		return 0, err
+		return err
 	}
+	stats := res.GetArray().GetStats()
+	total := int(stats.GetPendingJobs() + stats.GetRunningJobs() + stats.GetFinishedJobs())
 
-	log.Printf("Submitted job %d", res.GetId())
-	return res.GetId(), nil
+	states := make(map[int64]flex.JobState)
+	lastFinished := -1
+	return watchJobs(ctx, cl, &flex.WatchJobsRequest{Filter: &flex.JobFilter{ArrayId: id}}, func(job *flex.JobStatus) bool {
+		states[job.GetJob().GetId()] = job.GetState()
+		if len(states) < total {
+			return false
+		}
+
+		finished := 0
+		for _, state := range states {
+			if state == flex.JobState_FINISHED {
+				finished++
+			}
+		}
+		if finished != lastFinished {
+			lastFinished = finished
+			log.Printf("Job array %d: %d/%d finished", id, finished, total)
+		}
+		return finished == total
+	})
 }
 
-func waitJob(ctx context.Context, cl flex.FlexServiceClient, id int64) error {
-	lastState := flex.JobState_PENDING
+// watchJobs calls f for each job status streamed by WatchJobs until f returns
+// true. It watches jobs again when the stream is interrupted, e.g. by a server
+// restart.
+func watchJobs(ctx context.Context, cl flex.FlexServiceClient, req *flex.WatchJobsRequest, f func(job *flex.JobStatus) bool) error {
 	for {
-		res, err := cl.GetJob(ctx, &flex.GetJobRequest{Id: id})
-		if err != nil {
-			return err
-		}
+		err := func() error {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
 
-		job := res.GetJob()
-		state := job.GetState()
-		if state != lastState {
-			lastState = state
-			switch state {
-			case flex.JobState_PENDING:
-				log.Printf("Job %d returned", id)
-			case flex.JobState_RUNNING:
-				log.Printf("Job %d running", id)
-			case flex.JobState_FINISHED:
-				result := job.GetResult()
-				log.Printf("Job %d finished: %s (%v)", id, result.GetMessage(), result.GetTime().AsDuration())
-				return nil
+			stream, err := cl.WatchJobs(ctx, req)
+			if err != nil {
+				return err
 			}
+			for {
+				res, err := stream.Recv()
+				if err != nil {
+					return err
+				}
+				if f(res.GetJob()) {
+					return nil
+				}
+			}
+		}()
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if code := status.Code(err); code != codes.Unavailable && code != codes.Canceled {
+			return err
 		}
 
+		log.Printf("WARNING: Watching jobs failed; retrying: %v", err)
 		if err := ctxutil.Sleep(ctx, time.Second); err != nil {
 			return err
 		}
 	}
 }
 
-func printJobOutputs(ctx context.Context, cl flex.FlexServiceClient, id int64) error {
-	if err := func() error {
-		jo, err := cl.GetJobOutput(ctx, &flex.GetJobOutputRequest{Id: id, Type: flex.GetJobOutputRequest_STDERR})
-		if err != nil {
-			return err
+func printJobOutputs(ctx context.Context, cl flex.FlexServiceClient, id int64, proxy bool) error {
+	if err := copyJobOutput(ctx, cl, id, flex.GetJobOutputRequest_STDERR, os.Stderr, proxy); err != nil {
+		return fmt.Errorf("failed to retrieve stderr: %v", err)
+	}
+	if err := copyJobOutput(ctx, cl, id, flex.GetJobOutputRequest_STDOUT, os.Stdout, proxy); err != nil {
+		return fmt.Errorf("failed to retrieve stdout: %v", err)
+	}
+	return nil
+}
+
+func printJobLog(ctx context.Context, cl flex.FlexServiceClient, id int64, timestamps, proxy bool) error {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(copyJobOutput(ctx, cl, id, flex.GetJobOutputRequest_LOG, pw, proxy))
+	}()
+	defer pr.Close()
+
+	r := bufio.NewReader(pr)
+	for {
+		line, err := r.ReadString('\n')
+		if line != "" {
+			printJobLogLine(line, timestamps)
 		}
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, jo.GetLocation().GetPresignedUrl(), nil)
-		if err != nil {
-			return err
+		if err == io.EOF {
+			return nil
 		}
-		res, err := http.DefaultClient.Do(req)
 		if err != nil {
 			return err
 		}
-		defer res.Body.Close()
-		_, err = io.Copy(os.Stderr, res.Body)
-		return err
-	}(); err != nil {
-		return fmt.Errorf("failed to retrieve stderr: %v", err)
 	}
+}
 
-	if err := func() error {
-		jo, err := cl.GetJobOutput(ctx, &flex.GetJobOutputRequest{Id: id, Type: flex.GetJobOutputRequest_STDOUT})
+func printJobLogLine(line string, timestamps bool) {
+	rec, err := joblog.Parse(line)
+	if err != nil {
+		// Print malformed lines, e.g. a truncation marker, as is.
+		fmt.Print(line)
+		return
+	}
+	w := os.Stdout
+	if rec.Stream == joblog.Stderr {
+		w = os.Stderr
+	}
+	if timestamps {
+		fmt.Fprintf(w, "%s %s\n", rec.Time.Format(time.RFC3339Nano), rec.Line)
+	} else {
+		fmt.Fprintln(w, rec.Line)
+	}
+}
+
+func copyJobOutput(ctx context.Context, cl flex.FlexServiceClient, id int64, outputType flex.GetJobOutputRequest_JobOutputType, w io.Writer, proxy bool) error {
+	if proxy {
+		stream, err := cl.ReadJobOutput(ctx, &flex.ReadJobOutputRequest{Id: id, Type: outputType, AcceptEncodings: []string{"gzip"}})
 		if err != nil {
 			return err
 		}
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, jo.GetLocation().GetPresignedUrl(), nil)
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
 		if err != nil {
 			return err
 		}
-		res, err := http.DefaultClient.Do(req)
+		r, err := decodeOutput(&jobOutputReader{stream: stream, buf: res.GetData()}, res.GetContentEncoding())
 		if err != nil {
 			return err
 		}
-		defer res.Body.Close()
-		_, err = io.Copy(os.Stdout, res.Body)
+		_, err = io.Copy(w, r)
 		return err
-	}(); err != nil {
-		return fmt.Errorf("failed to retrieve stdout: %v", err)
 	}
-	return nil
+
+	jo, err := cl.GetJobOutput(ctx, &flex.GetJobOutputRequest{Id: id, Type: outputType})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jo.GetLocation().GetPresignedUrl(), nil)
+	if err != nil {
+		return err
+	}
+	// Receive the output as stored, and decode it below. Otherwise outputs
+	// stored with Content-Encoding may be decoded by storages or by the HTTP
+	// client.
+	req.Header.Set("Accept-Encoding", "gzip")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	r, err := decodeOutput(res.Body, jo.GetContentEncoding())
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	return err
+}
+
+// decodeOutput returns a reader that decodes a job output in encoding.
+func decodeOutput(r io.Reader, encoding string) (io.Reader, error) {
+	switch encoding {
+	case "":
+		return r, nil
+	case "gzip":
+		return gzip.NewReader(r)
+	default:
+		return nil, fmt.Errorf("unknown output encoding: %s", encoding)
+	}
+}
+
+// jobOutputReader reads data streamed by ReadJobOutput.
+type jobOutputReader struct {
+	stream flex.FlexService_ReadJobOutputClient
+	buf    []byte
+}
+
+func (r *jobOutputReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		res, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = res.GetData()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
 }
@@ -36,6 +36,8 @@ var app = &cli.App{
 		cmdRun,
 		cmdJob,
 		cmdPackage,
+		cmdFlexlet,
+		cmdWebhook,
 	},
 }
 
@@ -0,0 +1,79 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/nya3jp/flex"
+)
+
+var flagJob = &cli.Int64Flag{
+	Name:  "job",
+	Usage: "Filters deliveries by job ID.",
+}
+
+var cmdWebhook = &cli.Command{
+	Name:            "webhook",
+	Usage:           "Webhook-related subcommands.",
+	HideHelpCommand: true,
+	Subcommands: []*cli.Command{
+		cmdWebhookDeliveries,
+	},
+}
+
+var cmdWebhookDeliveries = &cli.Command{
+	Name:  "deliveries",
+	Usage: "Lists webhook deliveries.",
+	Description: `Lists webhook deliveries.
+
+Webhooks are specified by --webhook on job creation. Flexhub sends a POST
+request with the job status in JSON to each webhook when the job starts running
+and when it finishes. A failed delivery is retried with exponential backoff.
+
+Deliveries are sorted in the decreasing order of their IDs. Each line shows
+the delivery ID, the job ID, the event, the delivery state with the last HTTP
+status code, the number of attempts, the webhook URL and the last error.
+`,
+	Flags: []cli.Flag{
+		flagJob,
+		flagLimit,
+		flagBefore,
+		flagJSON,
+	},
+	Action: func(c *cli.Context) error {
+		jobID := c.Int64(flagJob.Name)
+		limit := c.Int64(flagLimit.Name)
+		beforeID := c.Int64(flagBefore.Name)
+		if c.NArg() > 0 {
+			cli.ShowSubcommandHelpAndExit(c, exitCodeHelp)
+		}
+
+		return runCmd(c, func(ctx context.Context, cl flex.FlexServiceClient) error {
+			res, err := cl.ListWebhookDeliveries(ctx, &flex.ListWebhookDeliveriesRequest{
+				Limit:    limit,
+				BeforeId: beforeID,
+				JobId:    jobID,
+			})
+			if err != nil {
+				return err
+			}
+			newOutputFormatter(c).WebhookDeliveries(res.GetDeliveries())
+			return nil
+		})
+	},
+}
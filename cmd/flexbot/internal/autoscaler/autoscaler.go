@@ -0,0 +1,143 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoscaler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/cmd/flexbot/internal/provider"
+)
+
+type Options struct {
+	// MinWorkers and MaxWorkers bound the number of workers.
+	MinWorkers int
+	MaxWorkers int
+	// CoresPerWorker is the number of jobs a worker can run concurrently.
+	CoresPerWorker int
+	// Interval is the period of reconciliation.
+	Interval time.Duration
+	// ScaleDownCooldown is the duration to wait after the last demand before
+	// scaling down.
+	ScaleDownCooldown time.Duration
+}
+
+// Autoscaler periodically reads queue statistics from flexhub and scales
+// workers managed by a provider toward the target number.
+type Autoscaler struct {
+	cl       flex.FlexServiceClient
+	provider provider.Provider
+	opts     Options
+	trigger  chan struct{}
+
+	lastDemand time.Time
+}
+
+func New(cl flex.FlexServiceClient, provider provider.Provider, opts Options) *Autoscaler {
+	if opts.CoresPerWorker < 1 {
+		opts.CoresPerWorker = 1
+	}
+	return &Autoscaler{
+		cl:       cl,
+		provider: provider,
+		opts:     opts,
+		trigger:  make(chan struct{}, 1),
+	}
+}
+
+// Trigger requests an immediate reconciliation. It does not block.
+func (a *Autoscaler) Trigger() {
+	select {
+	case a.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (a *Autoscaler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(a.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := a.reconcile(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.Printf("WARNING: reconcile: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-a.trigger:
+		}
+	}
+}
+
+func (a *Autoscaler) reconcile(ctx context.Context) error {
+	res, err := a.cl.GetStats(ctx, &flex.GetStatsRequest{})
+	if err != nil {
+		return err
+	}
+	stats := res.GetStats()
+
+	current, err := a.provider.Count(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	running := int(stats.GetJob().GetRunningJobs())
+	pending := int(stats.GetJob().GetPendingJobs())
+	if pending > 0 {
+		a.lastDemand = now
+	}
+
+	target := Target(current, running, pending, a.opts)
+	switch {
+	case target > current:
+		log.Printf("Scaling up: %d -> %d workers (%d pending jobs)", current, target, pending)
+		return a.provider.ScaleUp(ctx, target-current)
+	case target < current:
+		if now.Sub(a.lastDemand) < a.opts.ScaleDownCooldown {
+			return nil
+		}
+		log.Printf("Scaling down: %d -> %d workers", current, target)
+		return a.provider.ScaleDown(ctx, current-target)
+	}
+	return nil
+}
+
+// Target computes the target number of workers.
+//
+// The target is the number of workers needed to run all running and pending
+// jobs at once. It does not depend on idle cores, which workers just started
+// do not report until they connect to flexhub, so that repeated
+// reconciliations do not add workers again for the same pending jobs. Workers
+// are not removed while jobs are pending.
+func Target(current, running, pending int, opts Options) int {
+	target := (running + pending + opts.CoresPerWorker - 1) / opts.CoresPerWorker
+	if pending > 0 && target < current {
+		target = current
+	}
+	if target > opts.MaxWorkers {
+		target = opts.MaxWorkers
+	}
+	if target < opts.MinWorkers {
+		target = opts.MinWorkers
+	}
+	return target
+}
@@ -0,0 +1,175 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoscaler_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/cmd/flexbot/internal/autoscaler"
+)
+
+func TestTarget(t *testing.T) {
+	opts := autoscaler.Options{MinWorkers: 1, MaxWorkers: 10, CoresPerWorker: 4}
+	for _, tc := range []struct {
+		name                      string
+		current, running, pending int
+		want                      int
+	}{
+		{"Steady", 2, 8, 0, 2},
+		{"ScaleUp", 2, 8, 9, 5},
+		{"ScaleUpPartial", 2, 5, 1, 2},
+		{"ScaleUpToMax", 2, 0, 100, 10},
+		{"StartingWorkers", 5, 8, 9, 5},
+		{"ScaleDown", 4, 5, 0, 2},
+		{"ScaleDownToMin", 4, 0, 0, 1},
+		{"NoScaleDownWhilePending", 4, 0, 1, 4},
+		{"StartFromZero", 0, 0, 0, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := autoscaler.Target(tc.current, tc.running, tc.pending, opts); got != tc.want {
+				t.Errorf("Target(%d, %d, %d) = %d; want %d", tc.current, tc.running, tc.pending, got, tc.want)
+			}
+		})
+	}
+}
+
+// fakeStatsClient returns fixed job stats, and cancels a context after
+// returning them a number of times.
+type fakeStatsClient struct {
+	flex.FlexServiceClient
+
+	running, pending int32
+	calls, maxCalls  int
+	cancel           func()
+}
+
+func (c *fakeStatsClient) GetStats(ctx context.Context, req *flex.GetStatsRequest, opts ...grpc.CallOption) (*flex.GetStatsResponse, error) {
+	c.calls++
+	if c.calls >= c.maxCalls {
+		c.cancel()
+	}
+	return &flex.GetStatsResponse{
+		Stats: &flex.Stats{
+			Job: &flex.JobStats{RunningJobs: c.running, PendingJobs: c.pending},
+		},
+	}, nil
+}
+
+// fakeProvider counts workers, which never connect to flexhub. Stopped
+// workers linger as if they were draining running tasks.
+type fakeProvider struct {
+	mu sync.Mutex
+	// stops is the number of times each worker was stopped.
+	stops []int
+}
+
+func newFakeProvider(workers int) *fakeProvider {
+	return &fakeProvider{stops: make([]int, workers)}
+}
+
+func (p *fakeProvider) Count(ctx context.Context) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := 0
+	for _, s := range p.stops {
+		if s == 0 {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (p *fakeProvider) ScaleUp(ctx context.Context, n int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stops = append(p.stops, make([]int, n)...)
+	return nil
+}
+
+func (p *fakeProvider) ScaleDown(ctx context.Context, n int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := len(p.stops) - 1; i >= 0 && n > 0; i-- {
+		if p.stops[i] == 0 {
+			p.stops[i]++
+			n--
+		}
+	}
+	if n > 0 {
+		return fmt.Errorf("%d more workers to stop than running", n)
+	}
+	return nil
+}
+
+// MaxStops returns the maximum number of times a worker was stopped.
+func (p *fakeProvider) MaxStops() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	max := 0
+	for _, s := range p.stops {
+		if s > max {
+			max = s
+		}
+	}
+	return max
+}
+
+func TestAutoscaler_Run(t *testing.T) {
+	opts := autoscaler.Options{MinWorkers: 1, MaxWorkers: 100, CoresPerWorker: 4, Interval: time.Millisecond}
+	for _, tc := range []struct {
+		name             string
+		workers          int
+		running, pending int32
+		want             int
+	}{
+		// Workers started on the first reconciliation do not take pending
+		// jobs before the test ends, which must not add more workers.
+		{"ScaleUp", 1, 0, 9, 3},
+		{"ScaleDown", 10, 5, 0, 2},
+		{"Steady", 2, 8, 0, 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cl := &fakeStatsClient{running: tc.running, pending: tc.pending, maxCalls: 20, cancel: cancel}
+			prov := newFakeProvider(tc.workers)
+			scaler := autoscaler.New(cl, prov, opts)
+			go func() {
+				for ctx.Err() == nil {
+					scaler.Trigger()
+					time.Sleep(100 * time.Microsecond)
+				}
+			}()
+
+			if err := scaler.Run(ctx); err != context.Canceled {
+				t.Fatalf("Run: %v", err)
+			}
+			if got, _ := prov.Count(ctx); got != tc.want {
+				t.Errorf("Workers = %d; want %d", got, tc.want)
+			}
+			if got := prov.MaxStops(); got > 1 {
+				t.Errorf("A worker was stopped %d times; want at most once", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Exec is a Provider that delegates to a user script. The script is called
+// with extra arguments:
+//
+//	count    prints the number of workers not being stopped to stdout
+//	up N     starts N workers
+//	down N   stops N workers
+type Exec struct {
+	args []string
+}
+
+var _ Provider = &Exec{}
+
+func NewExec(args []string) (*Exec, error) {
+	if len(args) == 0 {
+		return nil, errors.New("script command is empty")
+	}
+	return &Exec{args: args}, nil
+}
+
+func (p *Exec) Count(ctx context.Context) (int, error) {
+	out, err := p.run(ctx, "count")
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(out)))
+	if err != nil {
+		return 0, fmt.Errorf("script count: malformed output: %q", out)
+	}
+	return n, nil
+}
+
+func (p *Exec) ScaleUp(ctx context.Context, n int) error {
+	_, err := p.run(ctx, "up", strconv.Itoa(n))
+	return err
+}
+
+func (p *Exec) ScaleDown(ctx context.Context, n int) error {
+	_, err := p.run(ctx, "down", strconv.Itoa(n))
+	return err
+}
+
+func (p *Exec) run(ctx context.Context, args ...string) ([]byte, error) {
+	args = append(append([]string(nil), p.args...), args...)
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("script %s: %w", args[len(p.args)], err)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sync"
+)
+
+// HTTP is a Provider that runs workers by sending POST requests to a
+// push-mode flexlet. A worker is a request in flight, which runs at most one
+// task. Workers finish by themselves, so scaling down is no-op.
+type HTTP struct {
+	url string
+
+	mu       sync.Mutex
+	inflight int
+}
+
+var _ Provider = &HTTP{}
+
+func NewHTTP(url string) *HTTP {
+	return &HTTP{url: url}
+}
+
+func (p *HTTP) Count(ctx context.Context) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.inflight, nil
+}
+
+func (p *HTTP) ScaleUp(ctx context.Context, n int) error {
+	p.mu.Lock()
+	p.inflight += n
+	p.mu.Unlock()
+
+	for i := 0; i < n; i++ {
+		go func() {
+			defer func() {
+				p.mu.Lock()
+				p.inflight--
+				p.mu.Unlock()
+			}()
+			if err := p.post(ctx); err != nil {
+				log.Printf("ERROR: %v", err)
+			}
+		}()
+	}
+	return nil
+}
+
+func (p *HTTP) ScaleDown(ctx context.Context, n int) error {
+	return nil
+}
+
+func (p *HTTP) post(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, &bytes.Buffer{})
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(ioutil.Discard, res.Body)
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("http status %d", res.StatusCode)
+	}
+	return nil
+}
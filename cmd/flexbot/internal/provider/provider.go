@@ -0,0 +1,32 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+)
+
+// Provider manages flexlet workers on behalf of the autoscaler.
+type Provider interface {
+	// Count returns the number of workers currently running, excluding ones
+	// being stopped.
+	Count(ctx context.Context) (int, error)
+	// ScaleUp starts n more workers. Workers are stopped when ctx is canceled
+	// if the provider owns them.
+	ScaleUp(ctx context.Context, n int) error
+	// ScaleDown stops n workers. Workers being stopped already are not
+	// stopped again.
+	ScaleDown(ctx context.Context, n int) error
+}
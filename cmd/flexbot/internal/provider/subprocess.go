@@ -0,0 +1,153 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// WorkerPlaceholder is replaced with a unique worker ID in command line
+// arguments of Subprocess workers.
+const WorkerPlaceholder = "{worker}"
+
+// Subprocess is a Provider that runs workers as local subprocesses, e.g.
+// flexlets in pull mode. Workers are stopped with SIGTERM, newest first. A
+// worker is signaled only once since flexlets abort running tasks on the
+// second signal, and it is not counted while it drains.
+type Subprocess struct {
+	args []string
+
+	mu      sync.Mutex
+	nextID  int
+	workers []*worker
+}
+
+type worker struct {
+	id       string
+	cmd      *exec.Cmd
+	stopping bool
+}
+
+var _ Provider = &Subprocess{}
+
+func NewSubprocess(args []string) (*Subprocess, error) {
+	if len(args) == 0 {
+		return nil, errors.New("worker command is empty")
+	}
+	return &Subprocess{args: args}, nil
+}
+
+func (p *Subprocess) Count(ctx context.Context) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := 0
+	for _, w := range p.workers {
+		if !w.stopping {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (p *Subprocess) ScaleUp(ctx context.Context, n int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i := 0; i < n; i++ {
+		id := strconv.Itoa(p.nextID)
+		p.nextID++
+
+		var args []string
+		for _, arg := range p.args {
+			args = append(args, strings.ReplaceAll(arg, WorkerPlaceholder, id))
+		}
+
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		cmd.Env = append(os.Environ(), "FLEXBOT_WORKER_ID="+id)
+		if err := cmd.Start(); err != nil {
+			return err
+		}
+		log.Printf("Started worker %s (pid %d)", id, cmd.Process.Pid)
+		w := &worker{id: id, cmd: cmd}
+		p.workers = append(p.workers, w)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			err := cmd.Wait()
+			log.Printf("Worker %s exited: %v", id, err)
+
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			for i, other := range p.workers {
+				if other == w {
+					p.workers = append(p.workers[:i], p.workers[i+1:]...)
+					break
+				}
+			}
+		}()
+		go func() {
+			select {
+			case <-ctx.Done():
+				p.mu.Lock()
+				defer p.mu.Unlock()
+				p.stop(w)
+			case <-done:
+			}
+		}()
+	}
+	return nil
+}
+
+func (p *Subprocess) ScaleDown(ctx context.Context, n int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i := len(p.workers) - 1; i >= 0 && n > 0; i-- {
+		w := p.workers[i]
+		if w.stopping {
+			continue
+		}
+		if err := p.stop(w); err != nil {
+			return err
+		}
+		n--
+	}
+	return nil
+}
+
+// stop sends SIGTERM to w unless it has been sent already. p.mu must be held.
+func (p *Subprocess) stop(w *worker) error {
+	if w.stopping {
+		return nil
+	}
+	if err := w.cmd.Process.Signal(unix.SIGTERM); err != nil {
+		return err
+	}
+	w.stopping = true
+	log.Printf("Stopping worker %s", w.id)
+	return nil
+}
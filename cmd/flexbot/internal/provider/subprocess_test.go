@@ -0,0 +1,80 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nya3jp/flex/cmd/flexbot/internal/provider"
+)
+
+func TestSubprocess_ScaleDown(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Workers record signals and linger for a while as if they were
+	// draining running tasks.
+	script := `trap 'echo TERM >> ` + dir + `/{worker}; sleep 1; exit 0' TERM; touch ` + dir + `/{worker}.ready; while :; do sleep 0.05; done`
+	p, err := provider.NewSubprocess([]string{"sh", "-c", script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if err := p.ScaleUp(ctx, 3); err != nil {
+		t.Fatalf("ScaleUp: %v", err)
+	}
+	waitFile := func(name string) []byte {
+		for start := time.Now(); time.Since(start) < 10*time.Second; time.Sleep(10 * time.Millisecond) {
+			if b, err := ioutil.ReadFile(filepath.Join(dir, name)); err == nil {
+				return b
+			}
+		}
+		t.Fatalf("%s was not written", name)
+		return nil
+	}
+	// Wait for workers to trap signals.
+	for _, id := range []string{"0", "1", "2"} {
+		waitFile(id + ".ready")
+	}
+
+	// Repeated scale-downs must not signal draining workers again.
+	for _, want := range []int{2, 1, 0} {
+		if err := p.ScaleDown(ctx, 1); err != nil {
+			t.Fatalf("ScaleDown: %v", err)
+		}
+		if got, err := p.Count(ctx); err != nil || got != want {
+			t.Errorf("Count() = %d, %v; want %d", got, err, want)
+		}
+	}
+	if err := p.ScaleDown(ctx, 1); err != nil {
+		t.Fatalf("ScaleDown: %v", err)
+	}
+
+	for _, id := range []string{"0", "1", "2"} {
+		if got := strings.Count(string(waitFile(id)), "TERM"); got != 1 {
+			t.Errorf("Worker %s got %d signals; want 1", id, got)
+		}
+	}
+}
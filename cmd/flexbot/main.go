@@ -15,75 +15,116 @@
 package main
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"net/http"
 	"os"
 	"os/signal"
+	"time"
 
 	"github.com/urfave/cli/v2"
 	"golang.org/x/sys/unix"
 
-	"github.com/nya3jp/flex/internal/concurrent"
+	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/cmd/flexbot/internal/autoscaler"
+	"github.com/nya3jp/flex/cmd/flexbot/internal/provider"
+	"github.com/nya3jp/flex/internal/grpcutil"
 	"github.com/nya3jp/flex/internal/pubsub"
 )
 
+func newProvider(c *cli.Context) (provider.Provider, error) {
+	switch name := c.String("provider"); name {
+	case "http":
+		flexletURL := c.String("flexlet")
+		if flexletURL == "" {
+			return nil, errors.New("--flexlet is required for the http provider")
+		}
+		return provider.NewHTTP(flexletURL), nil
+	case "subprocess":
+		return provider.NewSubprocess(c.Args().Slice())
+	case "exec":
+		return provider.NewExec(c.Args().Slice())
+	default:
+		return nil, fmt.Errorf("unknown provider %q", name)
+	}
+}
+
 func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), unix.SIGINT, unix.SIGTERM)
 	defer cancel()
 
 	if err := func() error {
 		app := &cli.App{
-			Name:  "flexbot",
-			Usage: "Flexbot",
+			Name:      "flexbot",
+			Usage:     "Flexbot",
+			ArgsUsage: "[worker command...]",
 			Flags: []cli.Flag{
-				&cli.StringFlag{Name: "subscribe", Required: true, Usage: "PubSub subscription ID to pull job events from"},
-				&cli.StringFlag{Name: "flexlet", Required: true, Usage: "Flexlet URL"},
-				&cli.IntFlag{Name: "parallelism", Required: true, Usage: "Number of flexlets run in parallel"},
+				&cli.StringFlag{Name: "hub", Required: true, Usage: "Flexhub URL"},
+				&cli.StringFlag{Name: "password", Usage: "Flexhub password"},
+				&cli.StringFlag{Name: "provider", Value: "http", Usage: "Worker provider: http (POST to a push-mode flexlet), subprocess (run the worker command; " + provider.WorkerPlaceholder + " in arguments is replaced with a worker ID), exec (run the worker command with count, up N or down N)"},
+				&cli.StringFlag{Name: "flexlet", Usage: "Flexlet URL for the http provider"},
+				&cli.IntFlag{Name: "min", Value: 0, Usage: "Minimum number of workers"},
+				&cli.IntFlag{Name: "max", Aliases: []string{"parallelism"}, Required: true, Usage: "Maximum number of workers"},
+				&cli.IntFlag{Name: "cores-per-worker", Value: 1, Usage: "Number of jobs a worker runs concurrently"},
+				&cli.DurationFlag{Name: "interval", Value: 10 * time.Second, Usage: "Interval of reconciliation"},
+				&cli.DurationFlag{Name: "scale-down-cooldown", Value: 5 * time.Minute, Usage: "Duration to wait after pending jobs are gone before scaling down"},
+				&cli.StringFlag{Name: "subscribe", Usage: "URL to receive job events from to reconcile immediately (gcppubsub://PROJECT/SUBSCRIPTION, nats://HOST:PORT/SUBJECT, redis://HOST:PORT/KEY); a bare ID is a Cloud Pub/Sub subscription"},
 			},
 			Action: func(c *cli.Context) error {
 				ctx := c.Context
-				subscriptionID := c.String("subscribe")
-				flexletURL := c.String("flexlet")
-				parallelism := c.Int("parallelism")
+				hubURL := c.String("hub")
+				password := c.String("password")
+				subscribeURL := c.String("subscribe")
+				opts := autoscaler.Options{
+					MinWorkers:        c.Int("min"),
+					MaxWorkers:        c.Int("max"),
+					CoresPerWorker:    c.Int("cores-per-worker"),
+					Interval:          c.Duration("interval"),
+					ScaleDownCooldown: c.Duration("scale-down-cooldown"),
+				}
+				if opts.MinWorkers > opts.MaxWorkers {
+					return errors.New("--min must not be greater than --max")
+				}
 
-				subscriber, err := pubsub.NewSubscriber(ctx, subscriptionID, parallelism)
+				prov, err := newProvider(c)
 				if err != nil {
 					return err
 				}
-				defer subscriber.Close()
 
-				ctx, cancel := context.WithCancel(ctx)
-				defer cancel()
+				cc, err := grpcutil.DialContext(ctx, hubURL, password)
+				if err != nil {
+					return err
+				}
+				defer cc.Close()
 
-				limiter := concurrent.NewLimiter(parallelism)
+				scaler := autoscaler.New(flex.NewFlexServiceClient(cc), prov, opts)
 
-				return subscriber.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
-					if err := limiter.Take(ctx); err != nil {
-						log.Printf("ERROR: %v", err)
-						return
+				if subscribeURL != "" {
+					subscriber, err := pubsub.NewSubscriber(ctx, subscribeURL, 1)
+					if err != nil {
+						return err
 					}
-					defer limiter.Done()
-
-					// Ack immediately to run jobs beyond ack deadline.
-					msg.Ack()
+					defer subscriber.Close()
 
-					if err := func() error {
-						res, err := http.Post(flexletURL, "application/octet-stream", &bytes.Buffer{})
-						if err != nil {
-							return err
+					go func() {
+						if err := subscriber.Receive(ctx, func(ctx context.Context, event *flex.JobEvent) {
+							// Events from older flexhubs carry no state. Take
+							// them as job submissions.
+							if state := event.GetState(); state != flex.JobState_PENDING && state != flex.JobState_UNSPECIFIED {
+								return
+							}
+							scaler.Trigger()
+						}); err != nil && ctx.Err() == nil {
+							log.Printf("WARNING: Subscription stopped: %v", err)
 						}
-						res.Body.Close()
-						if res.StatusCode/100 != 2 {
-							return fmt.Errorf("http status %d", res.StatusCode)
-						}
-						return nil
-					}(); err != nil {
-						log.Printf("ERROR: %v", err)
-					}
-				})
+					}()
+				}
+
+				if err := scaler.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+					return err
+				}
+				return nil
 			},
 		}
 		return app.RunContext(ctx, os.Args)
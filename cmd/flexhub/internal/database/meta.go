@@ -17,17 +17,23 @@ package database
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	_ "embed"
 	"errors"
 	"fmt"
+	"log"
+	"math"
 	"strings"
 	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/cmd/flexhub/internal/eventbus"
+	"github.com/nya3jp/flex/internal/ctxutil"
 	"github.com/nya3jp/flex/internal/flexletpb"
 	"github.com/nya3jp/flex/internal/hashutil"
 )
@@ -37,12 +43,38 @@ var schemaQueries string
 
 var ErrNoPendingTask = errors.New("no pending task")
 
+// ErrFlexletUnavailable is returned by TakeTask when the flexlet may not take
+// tasks because it is draining, cordoned or unhealthy.
+var ErrFlexletUnavailable = errors.New("flexlet unavailable")
+
+const (
+	// jobEventRetention is how long job events are kept for other replicas
+	// to relay them.
+	jobEventRetention = 10 * time.Minute
+
+	// flexletRecentTasks is the number of recent tasks returned by GetFlexlet.
+	flexletRecentTasks = 20
+	// flexletTaskStatsWindow is the number of recent tasks GetFlexlet
+	// computes statistics over.
+	flexletTaskStatsWindow = 100
+
+	// bulkBatchSize is the number of job IDs bulk operations list at once.
+	bulkBatchSize = 100
+)
+
 type MetaStore struct {
-	db *sql.DB
+	db      *sql.DB
+	bus     *eventbus.Bus
+	replica string
 }
 
-func NewMetaStore(db *sql.DB) *MetaStore {
-	return &MetaStore{db: db}
+// NewMetaStore creates a MetaStore. If bus is non-nil, job state transitions
+// made via the MetaStore are published to it.
+//
+// Job state transitions are also recorded in the database so that other
+// flexhub replicas sharing it can relay them with RelayJobEvents.
+func NewMetaStore(db *sql.DB, bus *eventbus.Bus) *MetaStore {
+	return &MetaStore{db: db, bus: bus, replica: uuid.New().String()}
 }
 
 func (m *MetaStore) InitTables(ctx context.Context) (err error) {
@@ -68,7 +100,10 @@ func (m *MetaStore) InitTables(ctx context.Context) (err error) {
 	return tx.Commit()
 }
 
-func (m *MetaStore) Maintain(ctx context.Context) (err error) {
+// Maintain marks flexlets without heartbeats for flexletTimeout offline, and
+// marks tasks without heartbeats for taskTimeout lost so that their jobs are
+// retried.
+func (m *MetaStore) Maintain(ctx context.Context, flexletTimeout, taskTimeout time.Duration) (err error) {
 	defer func() {
 		if err != nil {
 			err = fmt.Errorf("maintaining: %w", err)
@@ -77,35 +112,301 @@ func (m *MetaStore) Maintain(ctx context.Context) (err error) {
 
 	// Mark stale flexlets down.
 	if _, err := m.db.ExecContext(ctx, `
-UPDATE flexlets SET state = 'OFFLINE'
-WHERE state = 'ONLINE' AND last_update < TIMESTAMPADD(MINUTE, -1, CURRENT_TIMESTAMP())
-`); err != nil {
+UPDATE flexlets SET state = 'OFFLINE', drain = FALSE
+WHERE state <> 'OFFLINE' AND last_update < TIMESTAMPADD(SECOND, -?, CURRENT_TIMESTAMP())
+`, int64(flexletTimeout.Seconds())); err != nil {
 		return err
 	}
 
-	// Release stale jobs.
+	// Release jobs of lost tasks.
+	ids, err := m.releaseLostTasks(ctx, taskTimeout)
+	if err != nil {
+		return err
+	}
+	m.notifyJobs(ctx, ids...)
+
+	// Prune job events that all replicas should have relayed.
 	if _, err := m.db.ExecContext(ctx, `
-UPDATE jobs j INNER JOIN tasks t ON (j.task_uuid = t.uuid)
-SET j.state = 'PENDING', j.task_uuid = NULL
-WHERE j.state = 'RUNNING' AND t.last_update < TIMESTAMPADD(MINUTE, -1, CURRENT_TIMESTAMP())
-`); err != nil {
+DELETE FROM job_events
+WHERE created < TIMESTAMPADD(SECOND, -?, CURRENT_TIMESTAMP())
+`, int64(jobEventRetention.Seconds())); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (m *MetaStore) InsertJob(ctx context.Context, spec *flex.JobSpec) (id int64, err error) {
+// AcquireLease acquires or renews the lease of the given name for ttl. It
+// returns true if this replica holds the lease. It is used to elect a single
+// replica to run periodic work.
+func (m *MetaStore) AcquireLease(ctx context.Context, name string, ttl time.Duration) (acquired bool, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("acquiring lease %s: %w", name, err)
+		}
+	}()
+
+	if _, err := m.db.ExecContext(ctx, `
+INSERT IGNORE INTO leases (name, holder, expires) VALUES (?, '', CURRENT_TIMESTAMP())
+`, name); err != nil {
+		return false, err
+	}
+
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var holder string
+	var valid bool
+	if err := tx.QueryRowContext(ctx, `
+SELECT holder, expires > CURRENT_TIMESTAMP() FROM leases WHERE name = ? FOR UPDATE
+`, name).Scan(&holder, &valid); err != nil {
+		return false, err
+	}
+	if holder != m.replica && valid {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+UPDATE leases
+SET
+    holder = ?,
+    expires = TIMESTAMPADD(SECOND, ?, CURRENT_TIMESTAMP())
+WHERE name = ?
+`, m.replica, int64(ttl.Seconds()), name); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RelayJobEvents publishes job state transitions made by other flexhub
+// replicas to the event bus as remote events. It polls the database every
+// interval until ctx is canceled.
+func (m *MetaStore) RelayJobEvents(ctx context.Context, interval time.Duration) error {
+	// Start from the latest job event so that old events are not relayed.
+	lastID := int64(-1)
+	for {
+		if lastID < 0 {
+			err := m.db.QueryRowContext(ctx, `SELECT id FROM job_events ORDER BY id DESC LIMIT 1`).Scan(&lastID)
+			if err == sql.ErrNoRows {
+				lastID = 0
+			} else if err != nil {
+				log.Printf("WARNING: Failed to relay job events: %v", err)
+				lastID = -1
+			}
+		} else {
+			ids, nextID, err := m.listRemoteJobEvents(ctx, lastID)
+			if err != nil {
+				log.Printf("WARNING: Failed to relay job events: %v", err)
+			}
+			lastID = nextID
+			m.publishRemoteJobs(ctx, ids)
+		}
+
+		if err := ctxutil.Sleep(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+func (m *MetaStore) publishRemoteJobs(ctx context.Context, ids []int64) {
+	if m.bus == nil {
+		return
+	}
+	for _, id := range ids {
+		status, err := m.GetJob(ctx, id)
+		if err != nil {
+			log.Printf("WARNING: Failed to relay a job event: %v", err)
+			continue
+		}
+		m.bus.PublishRemote(status)
+	}
+}
+
+// listRemoteJobEvents returns IDs of jobs updated by other replicas after the
+// job event lastID, and the ID of the last job event seen.
+func (m *MetaStore) listRemoteJobEvents(ctx context.Context, lastID int64) (ids []int64, nextID int64, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("listing job events: %w", err)
+		}
+	}()
+
+	rows, err := m.db.QueryContext(ctx, `
+SELECT id, job_id, origin FROM job_events WHERE id > ? ORDER BY id ASC
+`, lastID)
+	if err != nil {
+		return nil, lastID, err
+	}
+	defer rows.Close()
+
+	nextID = lastID
+	seen := make(map[int64]struct{})
+	for rows.Next() {
+		var id, jobID int64
+		var origin string
+		if err := rows.Scan(&id, &jobID, &origin); err != nil {
+			return nil, lastID, err
+		}
+		nextID = id
+		if origin == m.replica {
+			continue
+		}
+		if _, ok := seen[jobID]; ok {
+			continue
+		}
+		seen[jobID] = struct{}{}
+		ids = append(ids, jobID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, lastID, err
+	}
+	return ids, nextID, nil
+}
+
+type lostTask struct {
+	taskID      string
+	flexletName string
+	jobID       int64
+	reason      string
+}
+
+// releaseLostTasks marks stale running tasks lost and returns their jobs to
+// the queue. It returns IDs of released jobs.
+func (m *MetaStore) releaseLostTasks(ctx context.Context, taskTimeout time.Duration) ([]int64, error) {
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+SELECT t.uuid, t.flexlet, IFNULL(f.state, 'OFFLINE'), j.id
+FROM tasks t
+    LEFT JOIN flexlets f ON (t.flexlet = f.name)
+    LEFT JOIN jobs j ON (j.task_uuid = t.uuid AND j.state = 'RUNNING')
+WHERE t.state = 'RUNNING' AND t.last_update < TIMESTAMPADD(SECOND, -?, CURRENT_TIMESTAMP())
+FOR UPDATE
+`, int64(taskTimeout.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*lostTask
+	for rows.Next() {
+		var taskID, flexletName, flexletState string
+		var jobIDPtr *int64
+		if err := rows.Scan(&taskID, &flexletName, &flexletState, &jobIDPtr); err != nil {
+			return nil, err
+		}
+		reason := fmt.Sprintf("no heartbeat for %v", taskTimeout)
+		if flexletState == "OFFLINE" {
+			reason += " and flexlet is offline"
+		}
+		task := &lostTask{taskID: taskID, flexletName: flexletName, reason: reason}
+		if jobIDPtr != nil {
+			task.jobID = *jobIDPtr
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	var ids []int64
+	for _, task := range tasks {
+		response, err := proto.Marshal(&flex.TaskResult{
+			ExitCode: -1,
+			Message:  "lost: " + task.reason,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, `
+UPDATE tasks
+SET
+    state = 'LOST',
+    response = ?,
+    finished = CURRENT_TIMESTAMP()
+WHERE uuid = ?
+`, response, task.taskID); err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE flexlets SET lost_tasks = lost_tasks + 1 WHERE name = ?`, task.flexletName); err != nil {
+			return nil, err
+		}
+		if task.jobID != 0 {
+			if _, err := tx.ExecContext(ctx, `UPDATE jobs SET state = 'PENDING', task_uuid = NULL WHERE id = ?`, task.jobID); err != nil {
+				return nil, err
+			}
+			ids = append(ids, task.jobID)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	for _, task := range tasks {
+		if task.jobID != 0 {
+			log.Printf("WARNING: Task %s for job %d on flexlet %s lost (%s); job requeued", task.taskID, task.jobID, task.flexletName, task.reason)
+		} else {
+			log.Printf("WARNING: Task %s on flexlet %s lost (%s)", task.taskID, task.flexletName, task.reason)
+		}
+	}
+	return ids, nil
+}
+
+func (m *MetaStore) InsertJob(ctx context.Context, spec *flex.JobSpec, clonedFrom int64) (id int64, err error) {
 	defer func() {
 		if err != nil {
 			err = fmt.Errorf("inserting a job: %w", err)
 		}
 	}()
 
-	priority := spec.GetConstraints().GetPriority()
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	id, err = insertJob(ctx, tx, spec, clonedFrom, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	m.notifyJobs(ctx, id)
+	return id, nil
+}
+
+// InsertJobArray inserts a job array and its child jobs. Each child job is
+// a copy of spec whose command has FLEX_ARRAY_* environment variables set.
+func (m *MetaStore) InsertJobArray(ctx context.Context, spec *flex.JobSpec, array *flex.JobArray) (arrayID int64, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("inserting a job array: %w", err)
+		}
+	}()
+
 	req, err := proto.Marshal(spec)
 	if err != nil {
 		return 0, err
 	}
+	arr, err := proto.Marshal(array)
+	if err != nil {
+		return 0, err
+	}
 
 	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
@@ -113,19 +414,47 @@ func (m *MetaStore) InsertJob(ctx context.Context, spec *flex.JobSpec) (id int64
 	}
 	defer tx.Rollback()
 
-	result, err := tx.ExecContext(ctx, `INSERT INTO jobs (priority, request) VALUES (?, ?)`, priority, req)
+	result, err := tx.ExecContext(ctx, `INSERT INTO job_arrays (request, array) VALUES (?, ?)`, req, arr)
 	if err != nil {
 		return 0, err
 	}
 
-	id, err = result.LastInsertId()
+	arrayID, err = result.LastInsertId()
 	if err != nil {
 		return 0, err
 	}
 
-	for _, label := range spec.GetAnnotations().GetLabels() {
-		if _, err := tx.ExecContext(ctx, `INSERT INTO labels (job_id, label) VALUES (?, ?)`, id, label); err != nil {
-			return 0, err
+	var count int64
+	insertChild := func(index int32, env ...string) error {
+		child := proto.Clone(spec).(*flex.JobSpec)
+		if child.Command == nil {
+			child.Command = &flex.JobCommand{}
+		}
+		child.Command.Env = append(child.Command.Env,
+			fmt.Sprintf("FLEX_ARRAY_ID=%d", arrayID),
+			fmt.Sprintf("FLEX_ARRAY_INDEX=%d", index))
+		child.Command.Env = append(child.Command.Env, env...)
+
+		if _, err := insertJob(ctx, tx, child, 0, arrayID); err != nil {
+			return err
+		}
+		count++
+		return nil
+	}
+
+	if params := array.GetParams(); len(params) > 0 {
+		for i, param := range params {
+			if err := insertChild(int32(i), "FLEX_ARRAY_PARAM="+param); err != nil {
+				return 0, err
+			}
+		}
+	} else {
+		// Iterate in int64 so that the loop terminates when End is
+		// math.MaxInt32.
+		for i := int64(array.GetStart()); i <= int64(array.GetEnd()); i++ {
+			if err := insertChild(int32(i)); err != nil {
+				return 0, err
+			}
 		}
 	}
 
@@ -133,9 +462,107 @@ func (m *MetaStore) InsertJob(ctx context.Context, spec *flex.JobSpec) (id int64
 		return 0, err
 	}
 
+	statuses, err := m.ListJobs(ctx, &flex.JobFilter{ArrayId: arrayID}, count, math.MaxInt64)
+	if err != nil {
+		log.Printf("WARNING: Failed to publish job events: %v", err)
+	}
+	var ids []int64
+	for i := len(statuses) - 1; i >= 0; i-- {
+		ids = append(ids, statuses[i].GetJob().GetId())
+	}
+	m.recordJobEvents(ctx, ids...)
+	if m.bus != nil {
+		for i := len(statuses) - 1; i >= 0; i-- {
+			m.bus.Publish(statuses[i])
+		}
+	}
+	return arrayID, nil
+}
+
+func insertJob(ctx context.Context, tx *sql.Tx, spec *flex.JobSpec, clonedFrom int64, arrayID int64) (int64, error) {
+	priority := spec.GetConstraints().GetPriority()
+	req, err := proto.Marshal(spec)
+	if err != nil {
+		return 0, err
+	}
+
+	var clonedFromPtr, arrayIDPtr *int64
+	if clonedFrom != 0 {
+		clonedFromPtr = &clonedFrom
+	}
+	if arrayID != 0 {
+		arrayIDPtr = &arrayID
+	}
+
+	result, err := tx.ExecContext(ctx, `INSERT INTO jobs (priority, request, cloned_from, array_id) VALUES (?, ?, ?, ?)`, priority, req, clonedFromPtr, arrayIDPtr)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, label := range spec.GetAnnotations().GetLabels() {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO labels (job_id, label) VALUES (?, ?)`, id, label); err != nil {
+			return 0, err
+		}
+	}
 	return id, nil
 }
 
+func (m *MetaStore) GetJobArray(ctx context.Context, id int64) (status *flex.JobArrayStatus, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("reading a job array: %w", err)
+		}
+	}()
+
+	row := m.db.QueryRowContext(ctx, `SELECT created, request, array FROM job_arrays WHERE id = ?`, id)
+	var created time.Time
+	var req, arr []byte
+	if err := row.Scan(&created, &req, &arr); err == sql.ErrNoRows {
+		return nil, fmt.Errorf("job array %d not found", id)
+	} else if err != nil {
+		return nil, err
+	}
+
+	var spec flex.JobSpec
+	if err := proto.Unmarshal(req, &spec); err != nil {
+		return nil, err
+	}
+	var array flex.JobArray
+	if err := proto.Unmarshal(arr, &array); err != nil {
+		return nil, err
+	}
+
+	row = m.db.QueryRowContext(ctx, `
+SELECT
+    IFNULL(SUM(IF(state = 'PENDING', 1, 0)), 0),
+    IFNULL(SUM(IF(state = 'RUNNING', 1, 0)), 0),
+    IFNULL(SUM(IF(state = 'FINISHED', 1, 0)), 0)
+FROM jobs
+WHERE array_id = ?
+`, id)
+	var pendingJobs, runningJobs, finishedJobs int32
+	if err := row.Scan(&pendingJobs, &runningJobs, &finishedJobs); err != nil {
+		return nil, err
+	}
+
+	return &flex.JobArrayStatus{
+		Id:    id,
+		Spec:  &spec,
+		Array: &array,
+		Stats: &flex.JobStats{
+			PendingJobs:  pendingJobs,
+			RunningJobs:  runningJobs,
+			FinishedJobs: finishedJobs,
+		},
+		Created: timestamppb.New(created),
+	}, nil
+}
+
 func (m *MetaStore) GetJob(ctx context.Context, id int64) (status *flex.JobStatus, err error) {
 	defer func() {
 		if err != nil {
@@ -144,7 +571,7 @@ func (m *MetaStore) GetJob(ctx context.Context, id int64) (status *flex.JobStatu
 	}()
 
 	rows, err := m.db.QueryContext(ctx, `
-SELECT j.id, j.state, j.task_uuid, t.flexlet, j.created, t.started, t.finished, j.request, t.response
+SELECT j.id, j.state, j.task_uuid, t.flexlet, j.created, t.started, t.finished, j.request, t.response, j.cloned_from, j.array_id
 FROM jobs j
     LEFT OUTER JOIN tasks t ON (j.task_uuid = t.uuid)
 WHERE j.id = ?
@@ -165,20 +592,24 @@ WHERE j.id = ?
 	return statuses[0], nil
 }
 
-func (m *MetaStore) ListJobs(ctx context.Context, state flex.JobState, label string, limit int64, beforeID int64) (statuses []*flex.JobStatus, err error) {
+func (m *MetaStore) ListJobs(ctx context.Context, filter *flex.JobFilter, limit int64, beforeID int64) (statuses []*flex.JobStatus, err error) {
 	defer func() {
 		if err != nil {
 			err = fmt.Errorf("listing jobs: %w", err)
 		}
 	}()
 
+	state := filter.GetState()
+	label := filter.GetLabel()
+	arrayID := filter.GetArrayId()
+
 	query, args := func() (string, []interface{}) {
 		if label == "" {
 			const query = `
-SELECT j.id, j.state, j.task_uuid, t.flexlet, j.created, t.started, t.finished, j.request, t.response
+SELECT j.id, j.state, j.task_uuid, t.flexlet, j.created, t.started, t.finished, j.request, t.response, j.cloned_from, j.array_id
 FROM jobs j
     LEFT OUTER JOIN tasks t ON (j.task_uuid = t.uuid)
-WHERE j.id < ? AND (? OR j.state = ?)
+WHERE j.id < ? AND (? OR j.state = ?) AND (? OR j.array_id = ?)
 ORDER BY j.id DESC
 LIMIT ?
 `
@@ -186,17 +617,19 @@ LIMIT ?
 				beforeID,
 				state == flex.JobState_UNSPECIFIED,
 				formatJobState(state),
+				arrayID == 0,
+				arrayID,
 				limit,
 			}
 			return query, args
 		}
 
 		const query = `
-SELECT j.id, j.state, j.task_uuid, t.flexlet, j.created, t.started, t.finished, j.request, t.response
+SELECT j.id, j.state, j.task_uuid, t.flexlet, j.created, t.started, t.finished, j.request, t.response, j.cloned_from, j.array_id
 FROM labels l
 	INNER JOIN jobs j ON (l.job_id = j.id)
     LEFT OUTER JOIN tasks t ON (j.task_uuid = t.uuid)
-WHERE l.label = ? AND l.job_id < ? AND (? OR j.state = ?)
+WHERE l.label = ? AND l.job_id < ? AND (? OR j.state = ?) AND (? OR j.array_id = ?)
 ORDER BY l.job_id DESC
 LIMIT ?
 `
@@ -205,6 +638,8 @@ LIMIT ?
 			beforeID,
 			state == flex.JobState_UNSPECIFIED,
 			formatJobState(state),
+			arrayID == 0,
+			arrayID,
 			limit,
 		}
 		return query, args
@@ -232,6 +667,225 @@ func (m *MetaStore) UpdateJobLabels(ctx context.Context, id int64, adds, dels []
 	}
 	defer tx.Rollback()
 
+	if err := updateJobLabels(ctx, tx, id, adds, dels); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (m *MetaStore) CancelJob(ctx context.Context, id int64) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("cancelling a job: %w", err)
+		}
+	}()
+
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := cancelJob(ctx, tx, id); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	m.notifyJobs(ctx, id)
+	return nil
+}
+
+func (m *MetaStore) BulkUpdateJobLabels(ctx context.Context, filter *flex.JobFilter, adds, dels []string) (results []*flex.BulkJobResult, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("updating job labels in bulk: %w", err)
+		}
+	}()
+
+	return m.bulkApply(ctx, filter, func(tx *sql.Tx, id int64) error {
+		return updateJobLabels(ctx, tx, id, adds, dels)
+	})
+}
+
+func (m *MetaStore) BulkCancelJobs(ctx context.Context, filter *flex.JobFilter) (results []*flex.BulkJobResult, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("cancelling jobs in bulk: %w", err)
+		}
+	}()
+
+	results, err = m.bulkApply(ctx, filter, func(tx *sql.Tx, id int64) error {
+		return cancelJob(ctx, tx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	m.notifyBulkJobResults(ctx, results)
+	return results, nil
+}
+
+func (m *MetaStore) BulkRetryJobs(ctx context.Context, filter *flex.JobFilter) (results []*flex.BulkJobResult, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("retrying jobs in bulk: %w", err)
+		}
+	}()
+
+	results, err = m.bulkApply(ctx, filter, func(tx *sql.Tx, id int64) error {
+		return retryJob(ctx, tx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	m.notifyBulkJobResults(ctx, results)
+	return results, nil
+}
+
+// bulkApply calls f for every job matching filter. Job IDs are listed in
+// batches of bulkBatchSize, and each job is updated in its own short
+// transaction, so that a huge operation does not hold locks on the whole jobs
+// table and an error returned by f rolls back only the changes to the job.
+// Errors that break the transaction itself, e.g. deadlocks or lost
+// connections, abort the batch and are recorded for the rest of it.
+func (m *MetaStore) bulkApply(ctx context.Context, filter *flex.JobFilter, f func(tx *sql.Tx, id int64) error) ([]*flex.BulkJobResult, error) {
+	if filter.GetState() == flex.JobState_UNSPECIFIED && filter.GetLabel() == "" && filter.GetArrayId() == 0 {
+		return nil, errors.New("empty job filter")
+	}
+
+	var results []*flex.BulkJobResult
+	beforeID := int64(math.MaxInt64)
+	for {
+		ids, err := m.listJobIDs(ctx, filter, bulkBatchSize, beforeID)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			return results, nil
+		}
+		beforeID = ids[len(ids)-1]
+
+		for i, id := range ids {
+			jobErr, txErr := m.bulkApplyJob(ctx, id, f)
+			if txErr != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				for _, id := range ids[i:] {
+					results = append(results, &flex.BulkJobResult{Id: id, Error: txErr.Error()})
+				}
+				break
+			}
+			result := &flex.BulkJobResult{Id: id, Ok: true}
+			if jobErr != nil {
+				result.Ok = false
+				result.Error = jobErr.Error()
+			}
+			results = append(results, result)
+		}
+	}
+}
+
+// bulkApplyJob calls f for a job in a transaction. It returns an error
+// returned by f as jobErr, and an error of the transaction as txErr.
+func (m *MetaStore) bulkApplyJob(ctx context.Context, id int64, f func(tx *sql.Tx, id int64) error) (jobErr, txErr error) {
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := f(tx, id); err != nil {
+		if isTxError(err) {
+			return nil, err
+		}
+		return err, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// isTxError returns whether err means that a transaction can no longer be
+// used, rather than a failure specific to a job.
+func isTxError(err error) bool {
+	const (
+		errLockWaitTimeout = 1205
+		errLockDeadlock    = 1213
+	)
+	var merr *mysql.MySQLError
+	if errors.As(err, &merr) {
+		return merr.Number == errLockWaitTimeout || merr.Number == errLockDeadlock
+	}
+	return errors.Is(err, driver.ErrBadConn) || errors.Is(err, mysql.ErrInvalidConn) || errors.Is(err, sql.ErrTxDone) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func (m *MetaStore) listJobIDs(ctx context.Context, filter *flex.JobFilter, limit int64, beforeID int64) ([]int64, error) {
+	state := filter.GetState()
+	label := filter.GetLabel()
+	arrayID := filter.GetArrayId()
+
+	query, args := func() (string, []interface{}) {
+		if label == "" {
+			const query = `
+SELECT id
+FROM jobs
+WHERE id < ? AND (? OR state = ?) AND (? OR array_id = ?)
+ORDER BY id DESC
+LIMIT ?
+`
+			args := []interface{}{
+				beforeID,
+				state == flex.JobState_UNSPECIFIED,
+				formatJobState(state),
+				arrayID == 0,
+				arrayID,
+				limit,
+			}
+			return query, args
+		}
+
+		const query = `
+SELECT j.id
+FROM labels l
+	INNER JOIN jobs j ON (l.job_id = j.id)
+WHERE l.label = ? AND l.job_id < ? AND (? OR j.state = ?) AND (? OR j.array_id = ?)
+ORDER BY l.job_id DESC
+LIMIT ?
+`
+		args := []interface{}{
+			label,
+			beforeID,
+			state == flex.JobState_UNSPECIFIED,
+			formatJobState(state),
+			arrayID == 0,
+			arrayID,
+			limit,
+		}
+		return query, args
+	}()
+
+	rows, err := m.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func updateJobLabels(ctx context.Context, tx *sql.Tx, id int64, adds, dels []string) error {
 	// Read the current job spec.
 	row := tx.QueryRowContext(ctx, `SELECT request FROM jobs WHERE id = ? FOR UPDATE`, id)
 	var req []byte
@@ -285,7 +939,7 @@ func (m *MetaStore) UpdateJobLabels(ctx context.Context, id int64, adds, dels []
 	spec.Annotations.Labels = news
 
 	// Save the new spec.
-	req, err = proto.Marshal(&spec)
+	req, err := proto.Marshal(&spec)
 	if err != nil {
 		return err
 	}
@@ -305,8 +959,79 @@ func (m *MetaStore) UpdateJobLabels(ctx context.Context, id int64, adds, dels []
 			return err
 		}
 	}
+	return nil
+}
 
-	return tx.Commit()
+// cancelJob finishes a pending or running job with a cancellation result.
+// A running task is marked finished so that a later FinishTask call from the
+// flexlet is ignored. A pending job has no task yet, so a placeholder task is
+// recorded to hold the result.
+func cancelJob(ctx context.Context, tx *sql.Tx, id int64) error {
+	row := tx.QueryRowContext(ctx, `SELECT state, task_uuid FROM jobs WHERE id = ? FOR UPDATE`, id)
+	var stateStr string
+	var taskIDPtr *string
+	if err := row.Scan(&stateStr, &taskIDPtr); err != nil {
+		return err
+	}
+
+	response, err := proto.Marshal(&flex.TaskResult{
+		ExitCode: -1,
+		Message:  "cancelled",
+	})
+	if err != nil {
+		return err
+	}
+
+	switch stateStr {
+	case "PENDING":
+		taskID := uuid.New().String()
+		if _, err := tx.ExecContext(ctx, `
+INSERT INTO tasks (uuid, state, flexlet, finished, response) VALUES (?, 'FINISHED', '', CURRENT_TIMESTAMP(), ?)
+`, taskID, response); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE jobs SET state = 'FINISHED', task_uuid = ? WHERE id = ?`, taskID, id); err != nil {
+			return err
+		}
+	case "RUNNING":
+		if _, err := tx.ExecContext(ctx, `
+UPDATE tasks
+SET
+    state = 'FINISHED',
+    response = ?,
+    finished = CURRENT_TIMESTAMP(),
+    last_update = CURRENT_TIMESTAMP()
+WHERE uuid = ?
+`, response, *taskIDPtr); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE jobs SET state = 'FINISHED' WHERE id = ?`, id); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("job %d is already finished", id)
+	}
+	return nil
+}
+
+// retryJob puts a finished job back to the queue.
+func retryJob(ctx context.Context, tx *sql.Tx, id int64) error {
+	result, err := tx.ExecContext(ctx, `
+UPDATE jobs
+SET
+    state = 'PENDING',
+    task_uuid = NULL
+WHERE id = ? AND state = 'FINISHED'
+`, id)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return fmt.Errorf("job %d is not finished", id)
+	}
+	return nil
 }
 
 func (m *MetaStore) TakeTask(ctx context.Context, flexletName string) (ref *flexletpb.TaskRef, jobSpec *flex.JobSpec, err error) {
@@ -322,6 +1047,16 @@ func (m *MetaStore) TakeTask(ctx context.Context, flexletName string) (ref *flex
 	}
 	defer tx.Rollback()
 
+	// Do not assign tasks to draining, cordoned or unhealthy flexlets.
+	var flexletState string
+	var drain, cordoned, unhealthy bool
+	if err := tx.QueryRowContext(ctx, `SELECT state, drain, cordoned, unhealthy FROM flexlets WHERE name = ?`, flexletName).Scan(&flexletState, &drain, &cordoned, &unhealthy); err != nil && err != sql.ErrNoRows {
+		return nil, nil, err
+	}
+	if flexletState == "DRAINING" || drain || cordoned || unhealthy {
+		return nil, nil, ErrFlexletUnavailable
+	}
+
 	row := tx.QueryRowContext(ctx, `
 SELECT
   id, request
@@ -330,7 +1065,7 @@ WHERE
   state = 'PENDING'
 ORDER BY priority DESC, id ASC
 LIMIT 1
-FOR UPDATE
+FOR UPDATE SKIP LOCKED
 `)
 	var jobID int64
 	var req []byte
@@ -348,8 +1083,8 @@ FOR UPDATE
 	taskID := uuid.New().String()
 
 	if _, err := tx.ExecContext(ctx, `
-INSERT INTO tasks (uuid, flexlet) VALUES (?, ?)
-`, taskID, flexletName); err != nil {
+INSERT INTO tasks (uuid, flexlet, job_id) VALUES (?, ?, ?)
+`, taskID, flexletName, jobID); err != nil {
 		return nil, nil, err
 	}
 
@@ -367,6 +1102,8 @@ WHERE id = ?
 		return nil, nil, err
 	}
 
+	m.notifyJobs(ctx, jobID)
+
 	ref = &flexletpb.TaskRef{
 		TaskId: taskID,
 		JobId:  jobID,
@@ -374,7 +1111,10 @@ WHERE id = ?
 	return ref, &spec, nil
 }
 
-func (m *MetaStore) UpdateTask(ctx context.Context, ref *flexletpb.TaskRef) (err error) {
+// UpdateTask refreshes the heartbeat of a running task. It returns leaseLost
+// if the task no longer owns its job, in which case the flexlet should abort
+// the task.
+func (m *MetaStore) UpdateTask(ctx context.Context, ref *flexletpb.TaskRef) (leaseLost bool, err error) {
 	defer func() {
 		if err != nil {
 			err = fmt.Errorf("updating a running task: %w", err)
@@ -383,26 +1123,40 @@ func (m *MetaStore) UpdateTask(ctx context.Context, ref *flexletpb.TaskRef) (err
 
 	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
 	if err != nil {
-		return err
+		return false, err
 	}
 	defer tx.Rollback()
 
+	var owned int
+	if err := tx.QueryRowContext(ctx, `
+SELECT COUNT(*)
+FROM tasks t INNER JOIN jobs j ON (j.task_uuid = t.uuid)
+WHERE t.uuid = ? AND t.state = 'RUNNING' AND j.id = ? AND j.state = 'RUNNING'
+`, ref.GetTaskId(), ref.GetJobId()).Scan(&owned); err != nil {
+		return false, err
+	}
+	if owned == 0 {
+		return true, nil
+	}
+
 	if _, err := tx.ExecContext(ctx, `
 UPDATE tasks
 SET
     last_update = CURRENT_TIMESTAMP()
 WHERE uuid = ?
 `, ref.GetTaskId()); err != nil {
-		return err
+		return false, err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return err
+		return false, err
 	}
-	return nil
+	return false, nil
 }
 
-func (m *MetaStore) FinishTask(ctx context.Context, ref *flexletpb.TaskRef, result *flex.TaskResult, needRetry bool) (err error) {
+// FinishTask records the result of a task. It returns leaseLost if the task no
+// longer owns its job, in which case the result is discarded.
+func (m *MetaStore) FinishTask(ctx context.Context, ref *flexletpb.TaskRef, result *flex.TaskResult, needRetry bool) (leaseLost bool, err error) {
 	defer func() {
 		if err != nil {
 			err = fmt.Errorf("finishing a task: %w", err)
@@ -411,12 +1165,12 @@ func (m *MetaStore) FinishTask(ctx context.Context, ref *flexletpb.TaskRef, resu
 
 	response, err := proto.Marshal(result)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
 	if err != nil {
-		return err
+		return false, err
 	}
 	defer tx.Rollback()
 
@@ -425,13 +1179,18 @@ func (m *MetaStore) FinishTask(ctx context.Context, ref *flexletpb.TaskRef, resu
 		nextState = "PENDING"
 	}
 
-	if _, err := tx.ExecContext(ctx, `
+	jobResult, err := tx.ExecContext(ctx, `
 UPDATE jobs
 SET
     state = ?
 WHERE id = ? AND task_uuid = ? AND state = 'RUNNING'
-`, nextState, ref.GetJobId(), ref.GetTaskId()); err != nil {
-		return err
+`, nextState, ref.GetJobId(), ref.GetTaskId())
+	if err != nil {
+		return false, err
+	}
+	jobUpdated, err := jobResult.RowsAffected()
+	if err != nil {
+		return false, err
 	}
 
 	if _, err := tx.ExecContext(ctx, `
@@ -443,13 +1202,18 @@ SET
     last_update = CURRENT_TIMESTAMP()
 WHERE uuid = ? AND state = 'RUNNING'
 `, response, ref.GetTaskId()); err != nil {
-		return err
+		return false, err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return err
+		return false, err
 	}
-	return nil
+
+	if jobUpdated == 0 {
+		return true, nil
+	}
+	m.notifyJobs(ctx, ref.GetJobId())
+	return false, nil
 }
 
 func (m *MetaStore) UpdateTag(ctx context.Context, name, hash string) (err error) {
@@ -518,6 +1282,44 @@ func (m *MetaStore) ListTags(ctx context.Context) (tags []*flex.Tag, err error)
 	return tags, nil
 }
 
+// LookupObjectKey returns the wrapped data key of the stored file at path. It
+// returns nil if the file is not encrypted.
+func (m *MetaStore) LookupObjectKey(ctx context.Context, path string) (wrappedKey []byte, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("looking up an object key: %w", err)
+		}
+	}()
+
+	row := m.db.QueryRowContext(ctx, `SELECT wrapped_key FROM object_keys WHERE path = ?`, path)
+	if err := row.Scan(&wrappedKey); err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return wrappedKey, nil
+}
+
+// InsertObjectKey saves the wrapped data key of the stored file at path unless
+// one already exists. It returns the wrapped data key saved in the database,
+// which differs from wrappedKey if another one was saved earlier.
+func (m *MetaStore) InsertObjectKey(ctx context.Context, path string, wrappedKey []byte) (savedKey []byte, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("inserting an object key: %w", err)
+		}
+	}()
+
+	if _, err := m.db.ExecContext(ctx, `INSERT IGNORE INTO object_keys (path, wrapped_key) VALUES (?, ?)`, path, wrappedKey); err != nil {
+		return nil, err
+	}
+	row := m.db.QueryRowContext(ctx, `SELECT wrapped_key FROM object_keys WHERE path = ?`, path)
+	if err := row.Scan(&savedKey); err != nil {
+		return nil, err
+	}
+	return savedKey, nil
+}
+
 func (m *MetaStore) ListFlexlets(ctx context.Context) (statuses []*flex.FlexletStatus, err error) {
 	defer func() {
 		if err != nil {
@@ -525,7 +1327,17 @@ func (m *MetaStore) ListFlexlets(ctx context.Context) (statuses []*flex.FlexletS
 		}
 	}()
 
-	rows, err := m.db.QueryContext(ctx, `SELECT name, state, data FROM flexlets ORDER BY name ASC`)
+	return m.listFlexlets(ctx, "")
+}
+
+// listFlexlets returns flexlets of the name, or all flexlets if name is empty.
+func (m *MetaStore) listFlexlets(ctx context.Context, name string) ([]*flex.FlexletStatus, error) {
+	rows, err := m.db.QueryContext(ctx, `
+SELECT name, state, drain, cordoned, last_update, data, host, unhealthy, health_error, lost_tasks
+FROM flexlets
+WHERE ? = '' OR name = ?
+ORDER BY name ASC
+`, name, name)
 	if err != nil {
 		return nil, err
 	}
@@ -534,9 +1346,12 @@ func (m *MetaStore) ListFlexlets(ctx context.Context) (statuses []*flex.FlexletS
 	statusMap := make(map[string]*flex.FlexletStatus)
 	var names []string
 	for rows.Next() {
-		var name, stateStr string
-		var data []byte
-		if err := rows.Scan(&name, &stateStr, &data); err != nil {
+		var name, stateStr, healthError string
+		var drain, cordoned, unhealthy bool
+		var lostTasks int32
+		var lastUpdate time.Time
+		var data, hostData []byte
+		if err := rows.Scan(&name, &stateStr, &drain, &cordoned, &lastUpdate, &data, &hostData, &unhealthy, &healthError, &lostTasks); err != nil {
 			return nil, err
 		}
 
@@ -544,18 +1359,32 @@ func (m *MetaStore) ListFlexlets(ctx context.Context) (statuses []*flex.FlexletS
 		if err != nil {
 			return nil, err
 		}
+		// A flexlet is draining as soon as requested, even before it notices.
+		if state == flex.FlexletState_ONLINE && drain {
+			state = flex.FlexletState_DRAINING
+		}
 
 		var spec flex.FlexletSpec
 		if err := proto.Unmarshal(data, &spec); err != nil {
 			return nil, err
 		}
+		var host flex.FlexletHostInfo
+		if err := proto.Unmarshal(hostData, &host); err != nil {
+			return nil, err
+		}
 
 		statusMap[name] = &flex.FlexletStatus{
 			Flexlet: &flex.Flexlet{
 				Name: name,
 				Spec: &spec,
 			},
-			State: state,
+			State:       state,
+			Cordoned:    cordoned,
+			LastUpdate:  timestamppb.New(lastUpdate),
+			Host:        &host,
+			Unhealthy:   unhealthy,
+			HealthError: healthError,
+			LostTasks:   lostTasks,
 		}
 		names = append(names, name)
 	}
@@ -564,8 +1393,8 @@ func (m *MetaStore) ListFlexlets(ctx context.Context) (statuses []*flex.FlexletS
 SELECT j.id, t.flexlet, j.request
 FROM jobs j
     INNER JOIN tasks t ON (j.task_uuid = t.uuid)
-WHERE j.state = 'RUNNING'
-`)
+WHERE j.state = 'RUNNING' AND (? = '' OR t.flexlet = ?)
+`, name, name)
 	if err != nil {
 		return nil, err
 	}
@@ -595,14 +1424,148 @@ WHERE j.state = 'RUNNING'
 		})
 	}
 
-	statuses = make([]*flex.FlexletStatus, 0, len(statusMap))
+	statuses := make([]*flex.FlexletStatus, 0, len(statusMap))
 	for _, name := range names {
 		statuses = append(statuses, statusMap[name])
 	}
 	return statuses, nil
 }
 
-func (m *MetaStore) UpdateFlexlet(ctx context.Context, status *flex.FlexletStatus) (err error) {
+// GetFlexlet returns a flexlet with its recent tasks and statistics of
+// recently finished tasks.
+func (m *MetaStore) GetFlexlet(ctx context.Context, name string) (status *flex.FlexletStatus, tasks []*flex.FlexletTask, stats *flex.FlexletTaskStats, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("getting flexlet %s: %w", name, err)
+		}
+	}()
+
+	statuses, err := m.listFlexlets(ctx, name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(statuses) == 0 {
+		return nil, nil, nil, errors.New("flexlet not found")
+	}
+
+	rows, err := m.db.QueryContext(ctx, `
+SELECT uuid, job_id, state, started, finished, response
+FROM tasks
+WHERE flexlet = ?
+ORDER BY started DESC
+LIMIT ?
+`, name, flexletTaskStatsWindow)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer rows.Close()
+
+	stats = &flex.FlexletTaskStats{}
+	for rows.Next() {
+		var taskID, stateStr string
+		var jobIDPtr *int64
+		var started time.Time
+		var finished *time.Time
+		var res []byte
+		if err := rows.Scan(&taskID, &jobIDPtr, &stateStr, &started, &finished, &res); err != nil {
+			return nil, nil, nil, err
+		}
+
+		task := &flex.FlexletTask{
+			TaskId:  taskID,
+			Started: timestamppb.New(started),
+		}
+		if jobIDPtr != nil {
+			task.JobId = *jobIDPtr
+		}
+		if stateStr != "RUNNING" {
+			var result flex.TaskResult
+			if err := proto.Unmarshal(res, &result); err != nil {
+				return nil, nil, nil, err
+			}
+			task.Result = &result
+			if finished != nil {
+				task.Finished = timestamppb.New(*finished)
+			}
+		}
+		switch stateStr {
+		case "FINISHED":
+			stats.FinishedTasks++
+			if task.GetResult().GetExitCode() != 0 {
+				stats.FailedTasks++
+			}
+		case "LOST":
+			task.Lost = true
+			stats.LostTasks++
+		}
+		if len(tasks) < flexletRecentTasks {
+			tasks = append(tasks, task)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, nil, err
+	}
+	return statuses[0], tasks, stats, nil
+}
+
+func (m *MetaStore) CordonFlexlet(ctx context.Context, name string, cordoned bool) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("updating cordon of flexlet %s: %w", name, err)
+		}
+	}()
+
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var dummy int
+	if err := tx.QueryRowContext(ctx, `SELECT 1 FROM flexlets WHERE name = ? FOR UPDATE`, name).Scan(&dummy); err == sql.ErrNoRows {
+		return errors.New("flexlet not found")
+	} else if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE flexlets SET cordoned = ? WHERE name = ?`, cordoned, name); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (m *MetaStore) DeleteFlexlet(ctx context.Context, name string) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("deleting flexlet %s: %w", name, err)
+		}
+	}()
+
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var state string
+	if err := tx.QueryRowContext(ctx, `SELECT state FROM flexlets WHERE name = ? FOR UPDATE`, name).Scan(&state); err == sql.ErrNoRows {
+		return errors.New("flexlet not found")
+	} else if err != nil {
+		return err
+	}
+	if state != "OFFLINE" {
+		return errors.New("flexlet is not offline")
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM flexlets WHERE name = ?`, name); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateFlexlet records a heartbeat from a flexlet. It returns true if the
+// flexlet has been requested to drain.
+func (m *MetaStore) UpdateFlexlet(ctx context.Context, status *flex.FlexletStatus) (drain bool, err error) {
 	defer func() {
 		if err != nil {
 			err = fmt.Errorf("updating a flexlet: %w", err)
@@ -613,13 +1576,52 @@ func (m *MetaStore) UpdateFlexlet(ctx context.Context, status *flex.FlexletStatu
 	cores := status.GetFlexlet().GetSpec().GetCores()
 	data, err := proto.Marshal(status.GetFlexlet().GetSpec())
 	if err != nil {
-		return err
+		return false, err
+	}
+	host, err := proto.Marshal(status.GetHost())
+	if err != nil {
+		return false, err
+	}
+	unhealthy := status.GetUnhealthy()
+	healthError := status.GetHealthError()
+	const maxHealthErrorLen = 1024
+	if len(healthError) > maxHealthErrorLen {
+		healthError = healthError[:maxHealthErrorLen]
 	}
 
+	// A drain request is done once the flexlet goes offline.
 	if _, err := m.db.ExecContext(ctx, `
-INSERT INTO flexlets (name, state, cores, data) VALUES (?, ?, ?, ?)
-ON DUPLICATE KEY UPDATE state = ?, cores = ?, data = ?, last_update = CURRENT_TIMESTAMP()
-`, status.GetFlexlet().GetName(), stateStr, cores, data, stateStr, cores, data); err != nil {
+INSERT INTO flexlets (name, state, cores, data, host, unhealthy, health_error) VALUES (?, ?, ?, ?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE state = ?, cores = ?, data = ?, host = ?, unhealthy = ?, health_error = ?, drain = drain AND ? <> 'OFFLINE', last_update = CURRENT_TIMESTAMP()
+`, status.GetFlexlet().GetName(), stateStr, cores, data, host, unhealthy, healthError, stateStr, cores, data, host, unhealthy, healthError, stateStr); err != nil {
+		return false, err
+	}
+
+	row := m.db.QueryRowContext(ctx, `SELECT drain FROM flexlets WHERE name = ?`, status.GetFlexlet().GetName())
+	if err := row.Scan(&drain); err != nil {
+		return false, err
+	}
+	return drain, nil
+}
+
+func (m *MetaStore) DrainFlexlet(ctx context.Context, name string) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("draining flexlet %s: %w", name, err)
+		}
+	}()
+
+	var state string
+	if err := m.db.QueryRowContext(ctx, `SELECT state FROM flexlets WHERE name = ?`, name).Scan(&state); err == sql.ErrNoRows {
+		return errors.New("flexlet not found")
+	} else if err != nil {
+		return err
+	}
+	if state == "OFFLINE" {
+		return errors.New("flexlet is offline")
+	}
+
+	if _, err := m.db.ExecContext(ctx, `UPDATE flexlets SET drain = TRUE WHERE name = ?`, name); err != nil {
 		return err
 	}
 	return nil
@@ -635,11 +1637,12 @@ func (m *MetaStore) GetStats(ctx context.Context) (stats *flex.Stats, err error)
 	row := m.db.QueryRowContext(ctx, `
 SELECT
     IFNULL(SUM(IF(state = 'PENDING', 1, 0)), 0),
-    IFNULL(SUM(IF(state = 'RUNNING', 1, 0)), 0)
+    IFNULL(SUM(IF(state = 'RUNNING', 1, 0)), 0),
+    IFNULL(SUM(IF(state = 'FINISHED', 1, 0)), 0)
 FROM jobs
 `)
-	var pendingJobs, runningJobs int32
-	if err := row.Scan(&pendingJobs, &runningJobs); err != nil {
+	var pendingJobs, runningJobs, finishedJobs int32
+	if err := row.Scan(&pendingJobs, &runningJobs, &finishedJobs); err != nil {
 		return nil, err
 	}
 
@@ -647,7 +1650,7 @@ FROM jobs
 SELECT
     IFNULL(SUM(IF(state = 'ONLINE', 1, 0)), 0),
     IFNULL(SUM(IF(state = 'OFFLINE', 1, 0)), 0),
-    IFNULL(SUM(IF(state = 'ONLINE' AND cores >= 0, cores, 0)), 0)
+    IFNULL(SUM(IF(state = 'ONLINE' AND cores >= 0 AND NOT drain AND NOT cordoned AND NOT unhealthy, cores, 0)), 0)
 FROM flexlets
 `)
 	var onlineFlexlets, offlineFlexlets, totalFixedCores int32
@@ -663,7 +1666,11 @@ FROM jobs AS j
     INNER JOIN flexlets AS f ON (t.flexlet = f.name)
 WHERE
     j.state = 'RUNNING' AND
-		f.cores >= 0
+		f.state = 'ONLINE' AND
+		f.cores >= 0 AND
+		NOT f.drain AND
+		NOT f.cordoned AND
+		NOT f.unhealthy
 `)
 	var busyFixedCores int32
 	if err := row.Scan(&busyFixedCores); err != nil {
@@ -672,8 +1679,9 @@ WHERE
 
 	return &flex.Stats{
 		Job: &flex.JobStats{
-			PendingJobs: pendingJobs,
-			RunningJobs: runningJobs,
+			PendingJobs:  pendingJobs,
+			RunningJobs:  runningJobs,
+			FinishedJobs: finishedJobs,
 		},
 		Flexlet: &flex.FlexletStats{
 			OnlineFlexlets:  onlineFlexlets,
@@ -684,6 +1692,176 @@ WHERE
 	}, nil
 }
 
+// InsertWebhookDelivery records a new webhook delivery. The delivery is owned
+// by this replica for leaseTTL, during which other replicas do not claim it.
+func (m *MetaStore) InsertWebhookDelivery(ctx context.Context, jobID int64, url string, event flex.JobState, payload []byte, leaseTTL time.Duration) (id int64, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("inserting a webhook delivery: %w", err)
+		}
+	}()
+
+	result, err := m.db.ExecContext(ctx, `
+INSERT INTO webhook_deliveries (job_id, url, event, payload, owner, lease_expires)
+VALUES (?, ?, ?, ?, ?, TIMESTAMPADD(SECOND, ?, CURRENT_TIMESTAMP()))
+`, jobID, url, formatJobState(event), payload, m.replica, int64(leaseTTL/time.Second))
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// UpdateWebhookDelivery records an attempt of a webhook delivery owned by
+// this replica, and extends its lease by leaseTTL. It returns false if the
+// delivery is no longer owned by this replica, in which case it should not be
+// attempted any more.
+func (m *MetaStore) UpdateWebhookDelivery(ctx context.Context, id int64, state flex.WebhookDeliveryState, attempts, statusCode int32, errMsg string, leaseTTL time.Duration) (owned bool, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("updating a webhook delivery: %w", err)
+		}
+	}()
+
+	const maxErrorLen = 1024
+	if len(errMsg) > maxErrorLen {
+		errMsg = errMsg[:maxErrorLen]
+	}
+
+	result, err := m.db.ExecContext(ctx, `
+UPDATE webhook_deliveries
+SET
+    state = ?,
+    attempts = ?,
+    status_code = ?,
+    error = ?,
+    last_attempt = CURRENT_TIMESTAMP(),
+    lease_expires = TIMESTAMPADD(SECOND, ?, CURRENT_TIMESTAMP())
+WHERE id = ? AND owner = ?
+`, formatWebhookDeliveryState(state), attempts, statusCode, errMsg, int64(leaseTTL/time.Second), id, m.replica)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (m *MetaStore) ListWebhookDeliveries(ctx context.Context, jobID int64, limit int64, beforeID int64) (deliveries []*flex.WebhookDelivery, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("listing webhook deliveries: %w", err)
+		}
+	}()
+
+	rows, err := m.db.QueryContext(ctx, `
+SELECT id, job_id, url, event, state, attempts, status_code, error, created, last_attempt, payload
+FROM webhook_deliveries
+WHERE id < ? AND (? OR job_id = ?)
+ORDER BY id DESC
+LIMIT ?
+`, beforeID, jobID == 0, jobID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries, _, err = scanWebhookDeliveries(rows)
+	return deliveries, err
+}
+
+// ClaimWebhookDeliveries takes the ownership of pending webhook deliveries
+// whose leases have expired, e.g. because the replica delivering them exited,
+// for leaseTTL. It returns claimed deliveries together with their payloads.
+func (m *MetaStore) ClaimWebhookDeliveries(ctx context.Context, leaseTTL time.Duration) (deliveries []*flex.WebhookDelivery, payloads [][]byte, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("claiming webhook deliveries: %w", err)
+		}
+	}()
+
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+SELECT id, job_id, url, event, state, attempts, status_code, error, created, last_attempt, payload
+FROM webhook_deliveries
+WHERE state = 'PENDING' AND (lease_expires IS NULL OR lease_expires < CURRENT_TIMESTAMP())
+ORDER BY id ASC
+FOR UPDATE
+`)
+	if err != nil {
+		return nil, nil, err
+	}
+	deliveries, payloads, err = scanWebhookDeliveries(rows)
+	rows.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, delivery := range deliveries {
+		if _, err := tx.ExecContext(ctx, `
+UPDATE webhook_deliveries
+SET owner = ?, lease_expires = TIMESTAMPADD(SECOND, ?, CURRENT_TIMESTAMP())
+WHERE id = ?
+`, m.replica, int64(leaseTTL/time.Second), delivery.GetId()); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+	return deliveries, payloads, nil
+}
+
+// notifyJobs publishes the current statuses of jobs to the event bus, and
+// records job events for other replicas.
+func (m *MetaStore) notifyJobs(ctx context.Context, ids ...int64) {
+	m.recordJobEvents(ctx, ids...)
+
+	if m.bus == nil {
+		return
+	}
+	for _, id := range ids {
+		status, err := m.GetJob(ctx, id)
+		if err != nil {
+			log.Printf("WARNING: Failed to publish a job event: %v", err)
+			continue
+		}
+		m.bus.Publish(status)
+	}
+}
+
+// recordJobEvents records job events so that other replicas relay them.
+func (m *MetaStore) recordJobEvents(ctx context.Context, ids ...int64) {
+	if len(ids) == 0 {
+		return
+	}
+
+	var values []string
+	var args []interface{}
+	for _, id := range ids {
+		values = append(values, "(?, ?)")
+		args = append(args, id, m.replica)
+	}
+	if _, err := m.db.ExecContext(ctx, `INSERT INTO job_events (job_id, origin) VALUES `+strings.Join(values, ", "), args...); err != nil {
+		log.Printf("WARNING: Failed to record job events: %v", err)
+	}
+}
+
+func (m *MetaStore) notifyBulkJobResults(ctx context.Context, results []*flex.BulkJobResult) {
+	for _, result := range results {
+		if result.GetOk() {
+			m.notifyJobs(ctx, result.GetId())
+		}
+	}
+}
+
 func scanJobStatuses(rows *sql.Rows) ([]*flex.JobStatus, error) {
 	var jobs []*flex.JobStatus
 	for rows.Next() {
@@ -694,7 +1872,8 @@ func scanJobStatuses(rows *sql.Rows) ([]*flex.JobStatus, error) {
 		var created time.Time
 		var started, finished *time.Time
 		var req, res []byte
-		if err := rows.Scan(&id, &stateStr, &taskIDPtr, &flexletNamePtr, &created, &started, &finished, &req, &res); err != nil {
+		var clonedFromPtr, arrayIDPtr *int64
+		if err := rows.Scan(&id, &stateStr, &taskIDPtr, &flexletNamePtr, &created, &started, &finished, &req, &res, &clonedFromPtr, &arrayIDPtr); err != nil {
 			return nil, err
 		}
 
@@ -721,6 +1900,14 @@ func scanJobStatuses(rows *sql.Rows) ([]*flex.JobStatus, error) {
 			flexletName = *flexletNamePtr
 		}
 
+		var clonedFrom, arrayID int64
+		if clonedFromPtr != nil {
+			clonedFrom = *clonedFromPtr
+		}
+		if arrayIDPtr != nil {
+			arrayID = *arrayIDPtr
+		}
+
 		var startedProto, finishedProto *timestamppb.Timestamp
 		if started != nil {
 			startedProto = timestamppb.New(*started)
@@ -741,11 +1928,59 @@ func scanJobStatuses(rows *sql.Rows) ([]*flex.JobStatus, error) {
 			Created:     timestamppb.New(created),
 			Started:     startedProto,
 			Finished:    finishedProto,
+			ClonedFrom:  clonedFrom,
+			ArrayId:     arrayID,
 		})
 	}
 	return jobs, nil
 }
 
+func scanWebhookDeliveries(rows *sql.Rows) ([]*flex.WebhookDelivery, [][]byte, error) {
+	var deliveries []*flex.WebhookDelivery
+	var payloads [][]byte
+	for rows.Next() {
+		var id, jobID int64
+		var url, eventStr, stateStr, errMsg string
+		var attempts, statusCode int32
+		var created time.Time
+		var lastAttempt *time.Time
+		var payload []byte
+		if err := rows.Scan(&id, &jobID, &url, &eventStr, &stateStr, &attempts, &statusCode, &errMsg, &created, &lastAttempt, &payload); err != nil {
+			return nil, nil, err
+		}
+
+		event, err := parseJobState(eventStr)
+		if err != nil {
+			return nil, nil, err
+		}
+		state, err := parseWebhookDeliveryState(stateStr)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		delivery := &flex.WebhookDelivery{
+			Id:         id,
+			JobId:      jobID,
+			Url:        url,
+			Event:      event,
+			State:      state,
+			Attempts:   attempts,
+			StatusCode: statusCode,
+			Error:      errMsg,
+			Created:    timestamppb.New(created),
+		}
+		if lastAttempt != nil {
+			delivery.LastAttempt = timestamppb.New(*lastAttempt)
+		}
+		deliveries = append(deliveries, delivery)
+		payloads = append(payloads, payload)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return deliveries, payloads, nil
+}
+
 func parseJobState(state string) (flex.JobState, error) {
 	switch state {
 	case "PENDING":
@@ -778,6 +2013,8 @@ func parseFlexletState(state string) (flex.FlexletState, error) {
 		return flex.FlexletState_OFFLINE, nil
 	case "ONLINE":
 		return flex.FlexletState_ONLINE, nil
+	case "DRAINING":
+		return flex.FlexletState_DRAINING, nil
 	default:
 		return flex.FlexletState_OFFLINE, fmt.Errorf("unknown flexlet state %s", state)
 	}
@@ -789,6 +2026,34 @@ func formatFlexletState(state flex.FlexletState) string {
 		return "OFFLINE"
 	case flex.FlexletState_ONLINE:
 		return "ONLINE"
+	case flex.FlexletState_DRAINING:
+		return "DRAINING"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func parseWebhookDeliveryState(state string) (flex.WebhookDeliveryState, error) {
+	switch state {
+	case "PENDING":
+		return flex.WebhookDeliveryState_DELIVERY_PENDING, nil
+	case "SUCCEEDED":
+		return flex.WebhookDeliveryState_DELIVERY_SUCCEEDED, nil
+	case "FAILED":
+		return flex.WebhookDeliveryState_DELIVERY_FAILED, nil
+	default:
+		return flex.WebhookDeliveryState_DELIVERY_PENDING, fmt.Errorf("unknown webhook delivery state %s", state)
+	}
+}
+
+func formatWebhookDeliveryState(state flex.WebhookDeliveryState) string {
+	switch state {
+	case flex.WebhookDeliveryState_DELIVERY_PENDING:
+		return "PENDING"
+	case flex.WebhookDeliveryState_DELIVERY_SUCCEEDED:
+		return "SUCCEEDED"
+	case flex.WebhookDeliveryState_DELIVERY_FAILED:
+		return "FAILED"
 	default:
 		return "UNKNOWN"
 	}
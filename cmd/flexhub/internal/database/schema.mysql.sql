@@ -4,21 +4,37 @@ CREATE TABLE `jobs` (
     `state` ENUM('PENDING', 'RUNNING', 'FINISHED') NOT NULL DEFAULT 'PENDING',
     `task_uuid` CHAR(36) NULL,
     `created` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    `request` MEDIUMBLOB NOT NULL
+    `request` MEDIUMBLOB NOT NULL,
+    `cloned_from` BIGINT(20) NULL,
+    `array_id` BIGINT(20) NULL
 ) ENGINE=InnoDB DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;
 
 CREATE INDEX `jobs_queue` ON `jobs` (`state`, `priority` DESC, `id` ASC);
 
+CREATE INDEX `jobs_array` ON `jobs` (`array_id`, `id` DESC);
+
+CREATE TABLE `job_arrays` (
+    `id` BIGINT(20) PRIMARY KEY AUTO_INCREMENT,
+    `created` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `request` MEDIUMBLOB NOT NULL,
+    `array` MEDIUMBLOB NOT NULL
+) ENGINE=InnoDB DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;
+
 CREATE TABLE `tasks` (
     `uuid` CHAR(36) PRIMARY KEY,
-    `state` ENUM('RUNNING', 'FINISHED') NOT NULL DEFAULT 'RUNNING',
+    `state` ENUM('RUNNING', 'FINISHED', 'LOST') NOT NULL DEFAULT 'RUNNING',
     `flexlet` VARCHAR(128) NOT NULL,
+    `job_id` BIGINT(20) NULL,
     `started` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     `last_update` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     `finished` TIMESTAMP NULL,
     `response` MEDIUMBLOB NULL
 ) ENGINE=InnoDB DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;
 
+CREATE INDEX `tasks_flexlet` ON `tasks` (`flexlet`, `started` DESC);
+
+CREATE INDEX `tasks_state` ON `tasks` (`state`, `last_update`);
+
 CREATE TABLE `tags` (
     `tag` VARCHAR(128) PRIMARY KEY,
     `hash` CHAR(64) NOT NULL
@@ -26,10 +42,16 @@ CREATE TABLE `tags` (
 
 CREATE TABLE `flexlets` (
     `name` VARCHAR(128) PRIMARY KEY,
-    `state` ENUM('OFFLINE', 'ONLINE') NOT NULL DEFAULT 'OFFLINE',
+    `state` ENUM('OFFLINE', 'ONLINE', 'DRAINING') NOT NULL DEFAULT 'OFFLINE',
     `last_update` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     `cores` INT(10) NOT NULL,
-    `data` MEDIUMBLOB NOT NULL
+    `data` MEDIUMBLOB NOT NULL,
+    `drain` BOOLEAN NOT NULL DEFAULT FALSE,
+    `cordoned` BOOLEAN NOT NULL DEFAULT FALSE,
+    `host` MEDIUMBLOB NULL,
+    `unhealthy` BOOLEAN NOT NULL DEFAULT FALSE,
+    `health_error` VARCHAR(1024) NOT NULL DEFAULT '',
+    `lost_tasks` INT(10) NOT NULL DEFAULT 0
 ) ENGINE=InnoDB DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;
 
 CREATE TABLE `labels` (
@@ -38,3 +60,72 @@ CREATE TABLE `labels` (
     PRIMARY KEY (`label`, `job_id` DESC),
     FOREIGN KEY (`job_id`) REFERENCES `jobs` (`id`) ON DELETE CASCADE
 ) ENGINE=InnoDB DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;
+
+CREATE TABLE `webhook_deliveries` (
+    `id` BIGINT(20) PRIMARY KEY AUTO_INCREMENT,
+    `job_id` BIGINT(20) NOT NULL,
+    `url` VARCHAR(2048) NOT NULL,
+    `event` ENUM('PENDING', 'RUNNING', 'FINISHED') NOT NULL,
+    `state` ENUM('PENDING', 'SUCCEEDED', 'FAILED') NOT NULL DEFAULT 'PENDING',
+    `attempts` INT(10) NOT NULL DEFAULT 0,
+    `status_code` INT(10) NOT NULL DEFAULT 0,
+    `error` VARCHAR(1024) NOT NULL DEFAULT '',
+    `created` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `last_attempt` TIMESTAMP NULL,
+    `payload` MEDIUMBLOB NOT NULL,
+    `owner` VARCHAR(64) NOT NULL DEFAULT '',
+    `lease_expires` TIMESTAMP NULL
+) ENGINE=InnoDB DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;
+
+CREATE INDEX `webhook_deliveries_job` ON `webhook_deliveries` (`job_id`, `id` DESC);
+
+CREATE INDEX `webhook_deliveries_state` ON `webhook_deliveries` (`state`);
+
+CREATE TABLE `job_events` (
+    `id` BIGINT(20) PRIMARY KEY AUTO_INCREMENT,
+    `job_id` BIGINT(20) NOT NULL,
+    `origin` VARCHAR(64) NOT NULL,
+    `created` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;
+
+CREATE INDEX `job_events_created` ON `job_events` (`created`);
+
+CREATE TABLE `leases` (
+    `name` VARCHAR(64) PRIMARY KEY,
+    `holder` VARCHAR(64) NOT NULL,
+    `expires` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;
+
+CREATE TABLE `object_keys` (
+    `path` VARCHAR(255) PRIMARY KEY,
+    `wrapped_key` VARBINARY(255) NOT NULL,
+    `created` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_bin;
+
+-- Migrations for tables created by older versions. Errors are ignored.
+
+ALTER TABLE `jobs` ADD COLUMN `cloned_from` BIGINT(20) NULL;
+
+ALTER TABLE `jobs` ADD COLUMN `array_id` BIGINT(20) NULL;
+
+ALTER TABLE `flexlets` MODIFY COLUMN `state` ENUM('OFFLINE', 'ONLINE', 'DRAINING') NOT NULL DEFAULT 'OFFLINE';
+
+ALTER TABLE `flexlets` ADD COLUMN `drain` BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE `flexlets` ADD COLUMN `cordoned` BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE `tasks` ADD COLUMN `job_id` BIGINT(20) NULL;
+
+ALTER TABLE `flexlets` ADD COLUMN `host` MEDIUMBLOB NULL;
+
+ALTER TABLE `flexlets` ADD COLUMN `unhealthy` BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE `flexlets` ADD COLUMN `health_error` VARCHAR(1024) NOT NULL DEFAULT '';
+
+ALTER TABLE `tasks` MODIFY COLUMN `state` ENUM('RUNNING', 'FINISHED', 'LOST') NOT NULL DEFAULT 'RUNNING';
+
+ALTER TABLE `flexlets` ADD COLUMN `lost_tasks` INT(10) NOT NULL DEFAULT 0;
+
+ALTER TABLE `webhook_deliveries` ADD COLUMN `owner` VARCHAR(64) NOT NULL DEFAULT '';
+
+ALTER TABLE `webhook_deliveries` ADD COLUMN `lease_expires` TIMESTAMP NULL
@@ -0,0 +1,236 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package encryption implements server-side encryption of files stored by
+// flexhub.
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/nya3jp/flex/cmd/flexhub/internal/database"
+	"github.com/nya3jp/flex/internal/envelope"
+	"github.com/nya3jp/flex/internal/signedurl"
+)
+
+// Storage is a subset of server.FS needed to read encrypted files.
+type Storage interface {
+	PresignedURLForGet(ctx context.Context, path string, dur time.Duration) (string, error)
+}
+
+// Encryptor manages data keys of encrypted files and serves their decrypted
+// contents.
+//
+// Each file is encrypted with its own data key. Data keys are wrapped by the
+// master key and saved in the database, so that the master key never leaves
+// flexhub. Flexlets receive unwrapped data keys of the files a task reads and
+// writes. Clients download decrypted files through signed URLs served by
+// flexhub under the decrypt/ path of its public URL.
+type Encryptor struct {
+	meta    *database.MetaStore
+	fs      Storage
+	master  []byte
+	baseURL *url.URL
+	secret  []byte
+}
+
+// ParseMasterKey parses a base64-encoded master key.
+func ParseMasterKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	if len(key) != envelope.KeySize {
+		return nil, fmt.Errorf("invalid encryption key: got %d bytes; want %d bytes", len(key), envelope.KeySize)
+	}
+	return key, nil
+}
+
+func New(meta *database.MetaStore, fs Storage, master []byte, publicURL string, secret []byte) (*Encryptor, error) {
+	if len(master) != envelope.KeySize {
+		return nil, errors.New("invalid encryption key size")
+	}
+	if len(secret) == 0 {
+		return nil, errors.New("URL signing key is empty")
+	}
+
+	base, err := url.Parse(publicURL)
+	if err != nil {
+		return nil, err
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, fmt.Errorf("invalid public URL: expected http(s)://: %s", publicURL)
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		return nil, fmt.Errorf("invalid public URL: should end with a slash: %s", publicURL)
+	}
+	base, err = base.Parse("decrypt/")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Encryptor{
+		meta:    meta,
+		fs:      fs,
+		master:  master,
+		baseURL: base,
+		secret:  secret,
+	}, nil
+}
+
+// KeyForRead returns the data key of the file at path. It returns nil if the
+// file is not encrypted, e.g. because it was stored before encryption was
+// enabled.
+func (e *Encryptor) KeyForRead(ctx context.Context, path string) ([]byte, error) {
+	wrapped, err := e.meta.LookupObjectKey(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	if wrapped == nil {
+		return nil, nil
+	}
+	return e.unwrap(path, wrapped)
+}
+
+// KeyForWrite returns the data key to encrypt the file at path with. If the
+// file already has a data key, it is reused so that concurrent writers of the
+// same file agree on the key.
+func (e *Encryptor) KeyForWrite(ctx context.Context, path string) ([]byte, error) {
+	key, err := envelope.NewKey()
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := envelope.WrapKey(e.master, key, []byte(path))
+	if err != nil {
+		return nil, err
+	}
+	saved, err := e.meta.InsertObjectKey(ctx, path, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	return e.unwrap(path, saved)
+}
+
+func (e *Encryptor) unwrap(path string, wrapped []byte) ([]byte, error) {
+	key, err := envelope.UnwrapKey(e.master, wrapped, []byte(path))
+	if err != nil {
+		return nil, fmt.Errorf("unwrapping the data key of %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// PresignedURLForGet returns a signed URL to download the decrypted file at
+// path from flexhub.
+func (e *Encryptor) PresignedURLForGet(ctx context.Context, path string, dur time.Duration) (string, error) {
+	return signedurl.Sign(e.secret, http.MethodGet, e.url(path), time.Now().Add(dur)).String(), nil
+}
+
+// BasePath returns the URL path under which ServeHTTP should be served.
+func (e *Encryptor) BasePath() string {
+	return e.baseURL.Path
+}
+
+// ServeHTTP serves requests to signed URLs returned by PresignedURLForGet. It
+// downloads the encrypted file from the storage and streams it decrypted.
+func (e *Encryptor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := signedurl.Verify(e.secret, r, time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	ctx := r.Context()
+	name := strings.TrimPrefix(r.URL.Path, e.baseURL.Path)
+
+	key, err := e.KeyForRead(ctx, name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if key == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	srcURL, err := e.fs.PresignedURLForGet(ctx, name, time.Minute)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// Presigned URLs of some storages do not authorize HEAD requests, so
+	// always send GET.
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srcURL, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		http.NotFound(w, r)
+		return
+	case res.StatusCode/100 != 2:
+		http.Error(w, fmt.Sprintf("storage returned %s", res.Status), http.StatusBadGateway)
+		return
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", contentType)
+	if r.Method == http.MethodHead {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	dec, err := envelope.NewReader(res.Body, key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if _, err := io.Copy(w, dec); err != nil {
+		// Headers have been sent already, so just log the error. The client
+		// sees a truncated response.
+		log.Printf("WARNING: Decrypting %s failed: %v", name, err)
+	}
+}
+
+func (e *Encryptor) url(path string) *url.URL {
+	u, err := e.baseURL.Parse(path)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
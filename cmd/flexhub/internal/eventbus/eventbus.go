@@ -0,0 +1,112 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventbus
+
+import (
+	"sync"
+
+	"github.com/nya3jp/flex"
+)
+
+// subscriptionBufferSize is the number of events buffered for each
+// subscription. A subscription is closed when its buffer overflows. It is large
+// enough to hold events for the largest job array.
+const subscriptionBufferSize = 16384
+
+type Bus struct {
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+}
+
+func New() *Bus {
+	return &Bus{subs: make(map[*Subscription]struct{})}
+}
+
+// Publish sends a job status to all subscribers. It never blocks.
+func (b *Bus) Publish(status *flex.JobStatus) {
+	b.publish(status, false)
+}
+
+// PublishRemote sends a job status learned from another flexhub replica to
+// subscribers that are not local-only. It never blocks.
+func (b *Bus) PublishRemote(status *flex.JobStatus) {
+	b.publish(status, true)
+}
+
+func (b *Bus) publish(status *flex.JobStatus, remote bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs {
+		if remote && sub.local {
+			continue
+		}
+		select {
+		case sub.ch <- status:
+		default:
+			// The subscriber is too slow. Close the subscription so that it
+			// notices events have been lost.
+			delete(b.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Subscribe subscribes to job statuses published by any flexhub replica.
+func (b *Bus) Subscribe() *Subscription {
+	return b.subscribe(false)
+}
+
+// SubscribeLocal subscribes to job statuses published by this flexhub
+// replica only. It is used by consumers that must see each event only once
+// across replicas, such as webhook delivery.
+func (b *Bus) SubscribeLocal() *Subscription {
+	return b.subscribe(true)
+}
+
+func (b *Bus) subscribe(local bool) *Subscription {
+	sub := &Subscription{
+		bus:   b,
+		ch:    make(chan *flex.JobStatus, subscriptionBufferSize),
+		local: local,
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+type Subscription struct {
+	bus   *Bus
+	ch    chan *flex.JobStatus
+	local bool
+}
+
+// C returns a channel to receive job statuses from. The channel is closed
+// when the subscription overflows or is closed.
+func (s *Subscription) C() <-chan *flex.JobStatus {
+	return s.ch
+}
+
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	if _, ok := s.bus.subs[s]; ok {
+		delete(s.bus.subs, s)
+		close(s.ch)
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventbus_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/cmd/flexhub/internal/eventbus"
+	"github.com/nya3jp/flex/internal/pubsub"
+)
+
+func newStatus(id int64) *flex.JobStatus {
+	return &flex.JobStatus{Job: &flex.Job{Id: id}, State: flex.JobState_RUNNING}
+}
+
+// drain returns the job IDs of statuses buffered in sub.
+func drain(sub *eventbus.Subscription) []int64 {
+	var ids []int64
+	for {
+		select {
+		case status, ok := <-sub.C():
+			if !ok {
+				return ids
+			}
+			ids = append(ids, status.GetJob().GetId())
+		default:
+			return ids
+		}
+	}
+}
+
+func TestBus(t *testing.T) {
+	bus := eventbus.New()
+	all := bus.Subscribe()
+	defer all.Close()
+	local := bus.SubscribeLocal()
+	defer local.Close()
+
+	bus.Publish(newStatus(1))
+	bus.PublishRemote(newStatus(2))
+	bus.Publish(newStatus(3))
+
+	if got := drain(all); len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("Subscribe received %v; want [1 2 3]", got)
+	}
+	if got := drain(local); len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Errorf("SubscribeLocal received %v; want [1 3]", got)
+	}
+}
+
+func TestBus_Overflow(t *testing.T) {
+	bus := eventbus.New()
+	slow := bus.Subscribe()
+	defer slow.Close()
+
+	// Publish never blocks; it closes the subscription instead.
+	const n = 16384
+	for i := int64(1); i <= n+1; i++ {
+		bus.Publish(newStatus(i))
+	}
+
+	got := 0
+	for range slow.C() {
+		got++
+	}
+	if got != n {
+		t.Errorf("Received %d statuses before the subscription was closed; want %d", got, n)
+	}
+
+	// A new subscription is not affected.
+	fresh := bus.Subscribe()
+	defer fresh.Close()
+	bus.Publish(newStatus(n + 2))
+	if got := drain(fresh); len(got) != 1 || got[0] != n+2 {
+		t.Errorf("New subscription received %v; want [%d]", got, n+2)
+	}
+}
+
+func TestSubscription_Close(t *testing.T) {
+	bus := eventbus.New()
+	sub := bus.Subscribe()
+	bus.Publish(newStatus(1))
+	sub.Close()
+	sub.Close() // closing twice is fine
+
+	// Publishing after Close must not panic.
+	bus.Publish(newStatus(2))
+
+	if got := drain(sub); len(got) != 1 || got[0] != 1 {
+		t.Errorf("Received %v; want [1]", got)
+	}
+	if _, ok := <-sub.C(); ok {
+		t.Error("Channel is open after Close")
+	}
+}
+
+func TestForward(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bus := eventbus.New()
+	ch := pubsub.NewChannel(10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		eventbus.Forward(ctx, bus, ch)
+	}()
+
+	// Wait for Forward to subscribe.
+	for len(receiveJobIDs(ch, 1, 100*time.Millisecond)) == 0 {
+		bus.Publish(newStatus(1))
+	}
+
+	// Events from other replicas are not forwarded again.
+	bus.PublishRemote(newStatus(2))
+	bus.Publish(newStatus(3))
+	for {
+		got := receiveJobIDs(ch, 1, 10*time.Second)
+		if len(got) == 0 {
+			t.Fatal("Event for job 3 was not forwarded")
+		}
+		if got[0] == 2 {
+			t.Error("Event from another replica was forwarded")
+		}
+		if got[0] == 3 {
+			break
+		}
+	}
+
+	cancel()
+	<-done
+}
+
+// deadlinePublisher reports whether contexts passed to Publish have deadlines.
+type deadlinePublisher struct {
+	deadlines chan bool
+}
+
+func (p *deadlinePublisher) Publish(ctx context.Context, event *flex.JobEvent) error {
+	_, ok := ctx.Deadline()
+	p.deadlines <- ok
+	return nil
+}
+
+func (p *deadlinePublisher) Close() error { return nil }
+
+func TestForward_Deadline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bus := eventbus.New()
+	pub := &deadlinePublisher{deadlines: make(chan bool, 100)}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		eventbus.Forward(ctx, bus, pub)
+	}()
+
+	// A hung broker must not stall forwarding forever, so each publication
+	// has a deadline even though ctx does not.
+	for {
+		bus.Publish(newStatus(1))
+		select {
+		case ok := <-pub.deadlines:
+			if !ok {
+				t.Error("Publish was called without a deadline")
+			}
+		case <-time.After(100 * time.Millisecond):
+			continue
+		}
+		break
+	}
+
+	cancel()
+	<-done
+}
+
+// receiveJobIDs receives up to n events from ch within timeout and returns
+// their job IDs.
+func receiveJobIDs(ch *pubsub.Channel, n int, timeout time.Duration) []int64 {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var ids []int64
+	ch.Receive(ctx, func(ctx context.Context, event *flex.JobEvent) {
+		ids = append(ids, event.GetJobId())
+		if len(ids) == n {
+			cancel()
+		}
+	})
+	return ids
+}
@@ -0,0 +1,70 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventbus
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/internal/pubsub"
+)
+
+// publishTimeout bounds each publication so that a hung broker does not stall
+// forwarding until the bus drops the subscription.
+const publishTimeout = 10 * time.Second
+
+// Forward publishes events on the bus to publisher until ctx is canceled.
+func Forward(ctx context.Context, bus *Bus, publisher pubsub.Publisher) {
+	sub := bus.SubscribeLocal()
+	defer func() { sub.Close() }()
+
+	for {
+		select {
+		case job, ok := <-sub.C():
+			if !ok {
+				log.Print("WARNING: Event publisher fell behind; some events are not published")
+				sub = bus.SubscribeLocal()
+				continue
+			}
+			if err := publish(ctx, publisher, newJobEvent(job)); err != nil {
+				log.Printf("WARNING: Failed to publish an event for job %d: %v", job.GetJob().GetId(), err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func publish(ctx context.Context, publisher pubsub.Publisher, event *flex.JobEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+	return publisher.Publish(ctx, event)
+}
+
+func newJobEvent(job *flex.JobStatus) *flex.JobEvent {
+	spec := job.GetJob().GetSpec()
+	return &flex.JobEvent{
+		JobId:       job.GetJob().GetId(),
+		State:       job.GetState(),
+		Labels:      spec.GetAnnotations().GetLabels(),
+		Constraints: spec.GetConstraints(),
+		ArrayId:     job.GetArrayId(),
+		Time:        timestamppb.Now(),
+	}
+}
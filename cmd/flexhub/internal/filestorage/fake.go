@@ -23,13 +23,18 @@ import (
 	"os"
 	"strings"
 	"time"
+
+	"github.com/nya3jp/flex/internal/signedurl"
 )
 
+// Anonymous stores files on an HTTP server such as testfs. If secret is
+// non-empty, requests are authorized by URLs signed with it.
 type Anonymous struct {
 	baseURL *url.URL
+	secret  []byte
 }
 
-func NewAnonymous(baseURL string) (a *Anonymous, err error) {
+func NewAnonymous(baseURL string, secret []byte) (a *Anonymous, err error) {
 	defer func() {
 		if err != nil {
 			err = fmt.Errorf("setting up anonymous access: %w", err)
@@ -49,6 +54,7 @@ func NewAnonymous(baseURL string) (a *Anonymous, err error) {
 
 	return &Anonymous{
 		baseURL: parsed,
+		secret:  secret,
 	}, nil
 }
 
@@ -97,26 +103,46 @@ func (g *Anonymous) Put(ctx context.Context, path string, r io.ReadSeeker) (err
 }
 
 func (g *Anonymous) PresignedURLForGet(ctx context.Context, path string, dur time.Duration) (url string, err error) {
-	return g.CanonicalURL(path), nil
+	return g.sign(http.MethodGet, g.url(path), dur).String(), nil
 }
 
 func (g *Anonymous) PresignedURLForPut(ctx context.Context, path string, dur time.Duration) (string, error) {
-	return g.CanonicalURL(path), nil
+	return g.sign(http.MethodPut, g.url(path), dur).String(), nil
 }
 
 func (g *Anonymous) CanonicalURL(path string) string {
-	return g.request(http.MethodGet, path, nil).URL.String()
+	return g.url(path).String()
 }
 
-func (g *Anonymous) request(method, path string, body io.Reader) *http.Request {
-	if body != nil {
-		body = struct{ io.Reader }{body}
+// requestSignatureTime is the validity of signatures of requests made by
+// Anonymous itself.
+const requestSignatureTime = time.Minute
+
+func (g *Anonymous) sign(method string, u *url.URL, dur time.Duration) *url.URL {
+	if len(g.secret) == 0 {
+		return u
 	}
+	return signedurl.Sign(g.secret, method, u, time.Now().Add(dur))
+}
 
+func (g *Anonymous) url(path string) *url.URL {
 	u, err := g.baseURL.Parse(path)
 	if err != nil {
 		panic(err)
 	}
+	return u
+}
+
+func (g *Anonymous) request(method, path string, body io.Reader) *http.Request {
+	if body != nil {
+		body = struct{ io.Reader }{body}
+	}
+
+	signMethod := method
+	if method == http.MethodHead {
+		signMethod = http.MethodGet
+	}
+	u := g.sign(signMethod, g.url(path), requestSignatureTime)
 
 	req, err := http.NewRequest(method, u.String(), body)
 	if err != nil {
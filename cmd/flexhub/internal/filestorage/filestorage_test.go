@@ -0,0 +1,205 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nya3jp/flex/cmd/flexhub/internal/filestorage"
+	"github.com/nya3jp/flex/cmd/flexhub/internal/server"
+	"github.com/nya3jp/flex/internal/fileserver"
+)
+
+// testFS runs conformance tests of server.FS against fs.
+func testFS(t *testing.T, fs server.FS) {
+	ctx := context.Background()
+
+	httpDo := func(method, url, body string) (int, string) {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		b, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.StatusCode, string(b)
+	}
+
+	const pkgPath = "packages/conformance"
+	const outPath = "tasks/conformance/stdout.txt"
+
+	if err := fs.Exists(ctx, pkgPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Exists(%q) before Put = %v; want %v", pkgPath, err, os.ErrNotExist)
+	}
+	if err := fs.Put(ctx, pkgPath, strings.NewReader("package")); err != nil {
+		t.Fatalf("Put(%q): %v", pkgPath, err)
+	}
+	if err := fs.Exists(ctx, pkgPath); err != nil {
+		t.Errorf("Exists(%q) after Put: %v", pkgPath, err)
+	}
+
+	getURL, err := fs.PresignedURLForGet(ctx, pkgPath, time.Minute)
+	if err != nil {
+		t.Fatalf("PresignedURLForGet(%q): %v", pkgPath, err)
+	}
+	if code, body := httpDo(http.MethodGet, getURL, ""); code != http.StatusOK || body != "package" {
+		t.Errorf("GET %s = %d %q; want %d %q", getURL, code, body, http.StatusOK, "package")
+	}
+
+	putURL, err := fs.PresignedURLForPut(ctx, outPath, time.Minute)
+	if err != nil {
+		t.Fatalf("PresignedURLForPut(%q): %v", outPath, err)
+	}
+	if code, _ := httpDo(http.MethodPut, putURL, "output"); code/100 != 2 {
+		t.Errorf("PUT %s = %d; want 2xx", putURL, code)
+	}
+	if err := fs.Exists(ctx, outPath); err != nil {
+		t.Errorf("Exists(%q) after presigned PUT: %v", outPath, err)
+	}
+	getURL, err = fs.PresignedURLForGet(ctx, outPath, time.Minute)
+	if err != nil {
+		t.Fatalf("PresignedURLForGet(%q): %v", outPath, err)
+	}
+	if code, body := httpDo(http.MethodGet, getURL, ""); code != http.StatusOK || body != "output" {
+		t.Errorf("GET %s = %d %q; want %d %q", getURL, code, body, http.StatusOK, "output")
+	}
+
+	if fs.CanonicalURL(pkgPath) != fs.CanonicalURL(pkgPath) || fs.CanonicalURL(pkgPath) == fs.CanonicalURL(outPath) {
+		t.Errorf("CanonicalURL is not a stable, unique identifier: %q, %q", fs.CanonicalURL(pkgPath), fs.CanonicalURL(outPath))
+	}
+}
+
+func TestAnonymous(t *testing.T) {
+	secret := []byte("secret")
+	ts := httptest.NewServer(fileserver.New(t.TempDir(), "/", secret))
+	defer ts.Close()
+
+	fs, err := filestorage.NewAnonymous(ts.URL+"/", secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testFS(t, fs)
+}
+
+func TestLocal(t *testing.T) {
+	var fs *filestorage.Local
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fs.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	fs, err := filestorage.NewLocal("file://"+t.TempDir(), ts.URL+"/", []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testFS(t, fs)
+}
+
+func TestS3(t *testing.T) {
+	const accessKeyID = "flex"
+	ts := httptest.NewServer(newFakeS3(accessKeyID))
+	defer ts.Close()
+
+	fs, err := filestorage.NewS3(
+		context.Background(),
+		fmt.Sprintf("s3://bucket/dir/?endpoint=%s&region=us-east-1&path_style=true", ts.URL),
+		filestorage.S3Credentials{AccessKeyID: accessKeyID, SecretAccessKey: "secret"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testFS(t, fs)
+}
+
+func TestGS(t *testing.T) {
+	// GS has no local stand-in. Set FLEX_TEST_GS_URL to gs://BUCKET/DIR/ to
+	// run the tests against a real bucket.
+	baseURL := os.Getenv("FLEX_TEST_GS_URL")
+	if baseURL == "" {
+		t.Skip("FLEX_TEST_GS_URL is not set")
+	}
+	fs, err := filestorage.NewGS(context.Background(), baseURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testFS(t, fs)
+}
+
+// fakeS3 is a minimal S3-compatible server supporting path-style GET, HEAD and
+// PUT of objects. It checks that requests are signed with the expected access
+// key, but does not verify signatures.
+type fakeS3 struct {
+	accessKeyID string
+
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newFakeS3(accessKeyID string) *fakeS3 {
+	return &fakeS3{accessKeyID: accessKeyID, objects: make(map[string][]byte)}
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	credPrefix := s.accessKeyID + "/"
+	if !strings.Contains(r.Header.Get("Authorization"), "Credential="+credPrefix) &&
+		!strings.HasPrefix(r.URL.Query().Get("X-Amz-Credential"), credPrefix) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		data, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case http.MethodPut:
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		s.objects[r.URL.Path] = data
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -140,6 +140,12 @@ func (g *GS) PresignedURLForPut(ctx context.Context, path string, dur time.Durat
 	return g.presignURL(ctx, path, http.MethodPut, dur)
 }
 
+// KeepsContentEncoding returns true since objects keep Content-Encoding
+// metadata given on uploads.
+func (g *GS) KeepsContentEncoding() bool {
+	return true
+}
+
 func (g *GS) CanonicalURL(path string) string {
 	obj := g.object(path)
 	return fmt.Sprintf("gs://%s/%s", obj.BucketName(), obj.ObjectName())
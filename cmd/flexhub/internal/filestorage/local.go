@@ -0,0 +1,146 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filestorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nya3jp/flex/internal/fileserver"
+	"github.com/nya3jp/flex/internal/signedurl"
+)
+
+// Local stores files on the local disk of flexhub. Flexhub itself serves
+// signed URLs to them under the fs/ path of its public URL.
+type Local struct {
+	srv     *fileserver.Server
+	baseURL *url.URL
+	secret  []byte
+}
+
+func NewLocal(fsURL, publicURL string, secret []byte) (l *Local, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("setting up local storage: %w", err)
+		}
+	}()
+
+	parsed, err := url.Parse(fsURL)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme != "file" || parsed.Host != "" || !filepath.IsAbs(parsed.Path) {
+		return nil, fmt.Errorf("invalid URL: expected file:///path: %s", fsURL)
+	}
+
+	if len(secret) == 0 {
+		return nil, errors.New("URL signing key is empty")
+	}
+
+	base, err := url.Parse(publicURL)
+	if err != nil {
+		return nil, err
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, fmt.Errorf("invalid public URL: expected http(s)://: %s", publicURL)
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		return nil, fmt.Errorf("invalid public URL: should end with a slash: %s", publicURL)
+	}
+	base, err = base.Parse("fs/")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(parsed.Path, 0700); err != nil {
+		return nil, err
+	}
+	if err := fileserver.RemoveTempFiles(parsed.Path); err != nil {
+		return nil, err
+	}
+
+	return &Local{
+		srv:     fileserver.New(parsed.Path, base.Path, secret),
+		baseURL: base,
+		secret:  secret,
+	}, nil
+}
+
+func (l *Local) Exists(ctx context.Context, path string) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("checking: %w", err)
+		}
+	}()
+
+	localPath, err := l.srv.LocalPath(path)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stat(localPath)
+	return err
+}
+
+func (l *Local) Put(ctx context.Context, path string, r io.ReadSeeker) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("uploading: %w", err)
+		}
+	}()
+
+	localPath, err := l.srv.LocalPath(path)
+	if err != nil {
+		return err
+	}
+	return fileserver.WriteFileAtomic(localPath, r)
+}
+
+func (l *Local) PresignedURLForGet(ctx context.Context, path string, dur time.Duration) (string, error) {
+	return signedurl.Sign(l.secret, http.MethodGet, l.url(path), time.Now().Add(dur)).String(), nil
+}
+
+func (l *Local) PresignedURLForPut(ctx context.Context, path string, dur time.Duration) (string, error) {
+	return signedurl.Sign(l.secret, http.MethodPut, l.url(path), time.Now().Add(dur)).String(), nil
+}
+
+func (l *Local) CanonicalURL(path string) string {
+	return l.url(path).String()
+}
+
+// BasePath returns the URL path under which ServeHTTP should be served.
+func (l *Local) BasePath() string {
+	return l.baseURL.Path
+}
+
+// ServeHTTP serves requests to signed URLs of stored files.
+func (l *Local) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l.srv.ServeHTTP(w, r)
+}
+
+func (l *Local) url(path string) *url.URL {
+	u, err := l.baseURL.Parse(path)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
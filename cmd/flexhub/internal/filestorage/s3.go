@@ -22,10 +22,12 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	smithyhttp "github.com/aws/smithy-go/transport/http"
 )
@@ -35,7 +37,21 @@ type S3 struct {
 	baseURL *url.URL
 }
 
-func NewS3(ctx context.Context, baseURL string) (s *S3, err error) {
+// S3Credentials are static credentials to access S3. If they are empty, the
+// default credential chain of the AWS SDK is used.
+type S3Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// NewS3 sets up S3 access. baseURL is of the form s3://BUCKET/DIR/ and may
+// have the following query parameters to use S3-compatible services such as
+// MinIO and Ceph:
+//
+//	endpoint:   URL of the S3 endpoint, e.g. http://localhost:9000
+//	region:     region of the bucket
+//	path_style: if true, use path-style addressing (ENDPOINT/BUCKET/KEY)
+func NewS3(ctx context.Context, baseURL string, creds S3Credentials) (s *S3, err error) {
 	defer func() {
 		if err != nil {
 			err = fmt.Errorf("setting up S3 access: %w", err)
@@ -53,11 +69,49 @@ func NewS3(ctx context.Context, baseURL string) (s *S3, err error) {
 		return nil, fmt.Errorf("invalid URL: should end with a slash: %s", baseURL)
 	}
 
-	cfg, err := config.LoadDefaultConfig(ctx)
+	query := parsed.Query()
+	parsed.RawQuery = ""
+
+	var cfgOpts []func(*config.LoadOptions) error
+	var clOpts []func(*s3.Options)
+	for key, values := range query {
+		value := values[len(values)-1]
+		switch key {
+		case "endpoint":
+			endpoint, err := url.Parse(value)
+			if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+				return nil, fmt.Errorf("invalid endpoint: %s", value)
+			}
+			clOpts = append(clOpts, func(o *s3.Options) {
+				o.EndpointResolver = s3.EndpointResolverFromURL(value)
+			})
+		case "region":
+			cfgOpts = append(cfgOpts, config.WithRegion(value))
+		case "path_style":
+			pathStyle, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path_style: %s", value)
+			}
+			clOpts = append(clOpts, func(o *s3.Options) {
+				o.UsePathStyle = pathStyle
+			})
+		default:
+			return nil, fmt.Errorf("unknown URL parameter: %s", key)
+		}
+	}
+
+	if creds.AccessKeyID != "" || creds.SecretAccessKey != "" {
+		if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+			return nil, errors.New("both access key ID and secret access key must be set")
+		}
+		cfgOpts = append(cfgOpts, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(creds.AccessKeyID, creds.SecretAccessKey, "")))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, cfgOpts...)
 	if err != nil {
 		return nil, err
 	}
-	cl := s3.NewFromConfig(cfg)
+	cl := s3.NewFromConfig(cfg, clOpts...)
 	return &S3{
 		cl:      cl,
 		baseURL: parsed,
@@ -133,6 +187,12 @@ func (s *S3) PresignedURLForPut(ctx context.Context, path string, dur time.Durat
 	return req.URL, nil
 }
 
+// KeepsContentEncoding returns true since objects keep Content-Encoding
+// metadata given on uploads.
+func (s *S3) KeepsContentEncoding() bool {
+	return true
+}
+
 func (s *S3) CanonicalURL(path string) string {
 	return fmt.Sprintf("s3://%s/%s", s.baseURL.Host, s.fullPath(path))
 }
@@ -17,11 +17,18 @@ package restfix
 import (
 	"errors"
 
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/nya3jp/flex"
 )
 
+// ProtoJSONOptions is the protojson encoding used for messages served to REST
+// clients.
+var ProtoJSONOptions = protojson.MarshalOptions{
+	EmitUnpopulated: true,
+}
+
 func JobLimits(limits *flex.JobLimits) error {
 	if limits == nil {
 		return errors.New("nil JobLimits")
@@ -54,6 +61,9 @@ func JobSpec(spec *flex.JobSpec) error {
 	if spec.Annotations == nil {
 		spec.Annotations = &flex.JobAnnotations{}
 	}
+	if spec.Notifications == nil {
+		spec.Notifications = &flex.JobNotifications{}
+	}
 	return nil
 }
 
@@ -97,6 +107,12 @@ func FlexletStatus(flexlet *flex.FlexletStatus) error {
 	if err := Flexlet(flexlet.Flexlet); err != nil {
 		return err
 	}
+	if flexlet.Host == nil {
+		flexlet.Host = &flex.FlexletHostInfo{}
+	}
+	if flexlet.Host.Uptime == nil {
+		flexlet.Host.Uptime = durationpb.New(0)
+	}
 	for _, job := range flexlet.CurrentJobs {
 		if err := Job(job); err != nil {
 			return err
@@ -25,13 +25,17 @@ import (
 )
 
 var anonymousAllowedMethods = map[string]struct{}{
-	"/flex.FlexService/GetJob":       {},
-	"/flex.FlexService/GetJobOutput": {},
-	"/flex.FlexService/GetPackage":   {},
-	"/flex.FlexService/GetStats":     {},
-	"/flex.FlexService/ListFlexlets": {},
-	"/flex.FlexService/ListJobs":     {},
-	"/flex.FlexService/ListTags":     {},
+	"/flex.FlexService/GetJob":        {},
+	"/flex.FlexService/GetJobArray":   {},
+	"/flex.FlexService/GetJobOutput":  {},
+	"/flex.FlexService/GetFlexlet":    {},
+	"/flex.FlexService/GetPackage":    {},
+	"/flex.FlexService/GetStats":      {},
+	"/flex.FlexService/ListFlexlets":  {},
+	"/flex.FlexService/ListJobs":      {},
+	"/flex.FlexService/ListTags":      {},
+	"/flex.FlexService/ReadJobOutput": {},
+	"/flex.FlexService/WatchJobs":     {},
 }
 
 func makeAuthOptions(password string) []grpc.ServerOption {
@@ -17,17 +17,26 @@ package server
 import (
 	"context"
 	"io"
+	"net/http"
 	"path"
 	"time"
+
+	"github.com/nya3jp/flex"
 )
 
 const (
 	defaultTimeLimit = time.Minute
+	maxJobArraySize  = 10000
 	preTaskTime      = time.Minute
 	postTaskTime     = time.Minute
+	readChunkSize    = 64 * 1024
 
 	stdoutName = "stdout.txt"
 	stderrName = "stderr.txt"
+	logName    = "log.txt"
+
+	// outputEncoding is the content encoding flexlets compress outputs with.
+	outputEncoding = "gzip"
 )
 
 type FS interface {
@@ -38,6 +47,20 @@ type FS interface {
 	CanonicalURL(path string) string
 }
 
+// FSHandler is implemented by FS that are served by flexhub itself.
+type FSHandler interface {
+	http.Handler
+	// BasePath returns the URL path prefix to serve files under.
+	BasePath() string
+}
+
+// ContentEncodingFS is implemented by FS that keep the Content-Encoding header
+// of uploads and serve files with it.
+type ContentEncodingFS interface {
+	FS
+	KeepsContentEncoding() bool
+}
+
 func pathForPackage(hash string) string {
 	return path.Join("packages", hash)
 }
@@ -45,3 +68,21 @@ func pathForPackage(hash string) string {
 func pathForTask(id string, name string) string {
 	return path.Join("tasks", id, name)
 }
+
+func matchJobFilter(job *flex.JobStatus, filter *flex.JobFilter) bool {
+	if state := filter.GetState(); state != flex.JobState_UNSPECIFIED && job.GetState() != state {
+		return false
+	}
+	if arrayID := filter.GetArrayId(); arrayID != 0 && job.GetArrayId() != arrayID {
+		return false
+	}
+	if label := filter.GetLabel(); label != "" {
+		for _, l := range job.GetJob().GetSpec().GetAnnotations().GetLabels() {
+			if l == label {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
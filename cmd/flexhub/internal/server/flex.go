@@ -15,35 +15,46 @@
 package server
 
 import (
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/nya3jp/flex"
 	"github.com/nya3jp/flex/cmd/flexhub/internal/database"
+	"github.com/nya3jp/flex/cmd/flexhub/internal/encryption"
+	"github.com/nya3jp/flex/cmd/flexhub/internal/eventbus"
+	"github.com/nya3jp/flex/internal/envelope"
 	"github.com/nya3jp/flex/internal/hashutil"
-	"github.com/nya3jp/flex/internal/pubsub"
 )
 
 type flexServer struct {
 	flex.UnimplementedFlexServiceServer
-	meta      *database.MetaStore
-	fs        FS
-	publisher *pubsub.Publisher
+	meta *database.MetaStore
+	bus  *eventbus.Bus
+	fs   FS
+	enc  *encryption.Encryptor // nil if encryption is disabled
 }
 
-func newFlexServer(meta *database.MetaStore, fs FS, publisher *pubsub.Publisher) *flexServer {
+func newFlexServer(meta *database.MetaStore, bus *eventbus.Bus, fs FS, enc *encryption.Encryptor) *flexServer {
 	return &flexServer{
-		meta:      meta,
-		fs:        fs,
-		publisher: publisher,
+		meta: meta,
+		bus:  bus,
+		fs:   fs,
+		enc:  enc,
 	}
 }
 
@@ -54,42 +65,121 @@ func (s *flexServer) SubmitJob(ctx context.Context, req *flex.SubmitJobRequest)
 	if req.Spec == nil {
 		req.Spec = &flex.JobSpec{}
 	}
-	if req.Spec.Limits == nil {
-		req.Spec.Limits = &flex.JobLimits{}
+
+	if req.Array == nil {
+		id, err := s.submitJob(ctx, req.GetSpec(), true, 0)
+		if err != nil {
+			return nil, err
+		}
+		return &flex.SubmitJobResponse{Id: id}, nil
+	}
+
+	arrayID, err := s.submitJobArray(ctx, req.GetSpec(), req.GetArray())
+	if err != nil {
+		return nil, err
+	}
+	return &flex.SubmitJobResponse{ArrayId: arrayID}, nil
+}
+
+func (s *flexServer) submitJob(ctx context.Context, spec *flex.JobSpec, resolveTags bool, clonedFrom int64) (int64, error) {
+	if err := s.prepareJobSpec(ctx, spec, resolveTags); err != nil {
+		return 0, err
+	}
+
+	return s.meta.InsertJob(ctx, spec, clonedFrom)
+}
+
+func (s *flexServer) submitJobArray(ctx context.Context, spec *flex.JobSpec, array *flex.JobArray) (int64, error) {
+	size := int64(len(array.GetParams()))
+	if size == 0 {
+		size = int64(array.GetEnd()) - int64(array.GetStart()) + 1
 	}
-	if req.Spec.Limits.Time == nil {
-		req.Spec.Limits.Time = durationpb.New(defaultTimeLimit)
+	if size <= 0 {
+		return 0, status.Error(codes.InvalidArgument, "empty job array")
 	}
+	if size > maxJobArraySize {
+		return 0, status.Errorf(codes.InvalidArgument, "job array too large: %d > %d", size, maxJobArraySize)
+	}
+
+	if err := s.prepareJobSpec(ctx, spec, true); err != nil {
+		return 0, err
+	}
+
+	return s.meta.InsertJobArray(ctx, spec, array)
+}
 
-	for _, pkg := range req.GetSpec().GetInputs().GetPackages() {
-		if tag := pkg.GetTag(); tag != "" {
+func (s *flexServer) prepareJobSpec(ctx context.Context, spec *flex.JobSpec, resolveTags bool) error {
+	if spec.Limits == nil {
+		spec.Limits = &flex.JobLimits{}
+	}
+	if spec.Limits.Time == nil {
+		spec.Limits.Time = durationpb.New(defaultTimeLimit)
+	}
+	if spec.Limits.MaxOutputBytes < 0 {
+		return status.Error(codes.InvalidArgument, "max_output_bytes must not be negative")
+	}
+
+	for _, pkg := range spec.GetInputs().GetPackages() {
+		if tag := pkg.GetTag(); tag != "" && resolveTags {
 			hash, err := s.meta.LookupTag(ctx, tag)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			pkg.Hash = hash
 		}
 		if !hashutil.IsStdHash(pkg.GetHash()) {
-			return nil, errors.New("invalid package hash")
+			return errors.New("invalid package hash")
+		}
+	}
+
+	for _, webhook := range spec.GetNotifications().GetWebhooks() {
+		parsed, err := url.Parse(webhook)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return status.Errorf(codes.InvalidArgument, "invalid webhook URL: %s", webhook)
 		}
 	}
+	return nil
+}
+
+func (s *flexServer) CancelJob(ctx context.Context, req *flex.CancelJobRequest) (*flex.CancelJobResponse, error) {
+	if err := s.meta.CancelJob(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	return &flex.CancelJobResponse{}, nil
+}
 
-	id, err := s.meta.InsertJob(ctx, req.GetSpec())
+func (s *flexServer) CloneJob(ctx context.Context, req *flex.CloneJobRequest) (*flex.CloneJobResponse, error) {
+	status, err := s.meta.GetJob(ctx, req.GetId())
 	if err != nil {
 		return nil, err
 	}
 
-	if s.publisher != nil {
-		if err := s.publisher.Send(ctx); err != nil {
-			return nil, err
+	spec := status.GetJob().GetSpec()
+	if o := req.GetOverrides(); o != nil {
+		if o.Command != nil {
+			spec.Command = o.Command
+		}
+		if o.Limits != nil {
+			// Merge limits so that overriding one of them, e.g. the time
+			// limit, keeps others such as output limits.
+			if spec.Limits == nil {
+				spec.Limits = &flex.JobLimits{}
+			}
+			proto.Merge(spec.Limits, o.Limits)
+		}
+		if o.Constraints != nil {
+			spec.Constraints = o.Constraints
+		}
+		if o.Annotations != nil {
+			spec.Annotations = o.Annotations
 		}
 	}
 
-	return &flex.SubmitJobResponse{Id: id}, nil
-}
-
-func (s *flexServer) CancelJob(ctx context.Context, req *flex.CancelJobRequest) (*flex.CancelJobResponse, error) {
-	return nil, errors.New("not implemented")
+	id, err := s.submitJob(ctx, spec, req.GetResolveTags(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &flex.CloneJobResponse{Id: id}, nil
 }
 
 func (s *flexServer) GetJob(ctx context.Context, req *flex.GetJobRequest) (*flex.GetJobResponse, error) {
@@ -101,36 +191,292 @@ func (s *flexServer) GetJob(ctx context.Context, req *flex.GetJobRequest) (*flex
 }
 
 func (s *flexServer) GetJobOutput(ctx context.Context, req *flex.GetJobOutputRequest) (*flex.GetJobOutputResponse, error) {
-	status, err := s.meta.GetJob(ctx, req.GetId())
+	path, encoding, err := s.jobOutput(ctx, req.GetId(), req.GetType())
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := s.presignedURLForGet(ctx, path, time.Minute)
 	if err != nil {
 		return nil, err
 	}
 
+	loc := &flex.FileLocation{
+		CanonicalUrl: s.fs.CanonicalURL(path),
+		PresignedUrl: url,
+	}
+	return &flex.GetJobOutputResponse{Location: loc, ContentEncoding: encoding}, nil
+}
+
+// ReadJobOutput streams an output of a job through flexhub, for clients that
+// cannot reach the file storage.
+func (s *flexServer) ReadJobOutput(req *flex.ReadJobOutputRequest, stream flex.FlexService_ReadJobOutputServer) error {
+	ctx := stream.Context()
+
+	if req.GetOffset() < 0 || req.GetLength() < 0 {
+		return status.Error(codes.InvalidArgument, "offset and length must not be negative")
+	}
+
+	path, encoding, err := s.jobOutput(ctx, req.GetId(), req.GetType())
+	if err != nil {
+		return err
+	}
+
+	// Offsets and lengths apply to decoded outputs, so an encoded one is
+	// streamed as stored only in full.
+	passThrough := encoding == ""
+	if req.GetOffset() == 0 && req.GetLength() == 0 {
+		for _, e := range req.GetAcceptEncodings() {
+			if e == encoding {
+				passThrough = true
+			}
+		}
+	}
+
+	var r io.Reader
+	if passThrough {
+		f, err := s.openFile(ctx, path, req.GetOffset())
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	} else {
+		f, err := s.openFile(ctx, path, 0)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r, err = decodeOutput(f, encoding)
+		if err != nil {
+			return err
+		}
+		if err := skipBytes(r, req.GetOffset()); err != nil {
+			return err
+		}
+		encoding = ""
+	}
+	if length := req.GetLength(); length > 0 {
+		r = io.LimitReader(r, length)
+	}
+
+	buf := make([]byte, readChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&flex.ReadJobOutputResponse{Data: buf[:n], ContentEncoding: encoding}); err != nil {
+				return err
+			}
+			encoding = ""
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// jobOutput returns the path of an output of a job in the file storage, and
+// its content encoding.
+func (s *flexServer) jobOutput(ctx context.Context, id int64, outputType flex.GetJobOutputRequest_JobOutputType) (path, encoding string, err error) {
+	status, err := s.meta.GetJob(ctx, id)
+	if err != nil {
+		return "", "", err
+	}
+
 	var name string
-	switch req.GetType() {
+	switch outputType {
 	case flex.GetJobOutputRequest_STDOUT:
 		name = stdoutName
 	case flex.GetJobOutputRequest_STDERR:
 		name = stderrName
+	case flex.GetJobOutputRequest_LOG:
+		name = logName
 	default:
-		return nil, fmt.Errorf("unknown output type: %d", req.GetType())
+		return "", "", fmt.Errorf("unknown output type: %d", outputType)
+	}
+	return pathForTask(status.GetTaskId(), name), status.GetResult().GetOutputEncoding(), nil
+}
+
+// decodeOutput returns a reader that decodes r in encoding.
+func decodeOutput(r io.Reader, encoding string) (io.Reader, error) {
+	switch encoding {
+	case "":
+		return r, nil
+	case "gzip":
+		return gzip.NewReader(r)
+	default:
+		return nil, fmt.Errorf("unknown output encoding: %s", encoding)
+	}
+}
+
+// skipBytes discards the first n bytes of r. It is not an error if r is
+// shorter than n bytes.
+func skipBytes(r io.Reader, n int64) error {
+	if n <= 0 {
+		return nil
+	}
+	if _, err := io.CopyN(ioutil.Discard, r, n); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// openFile opens the file at path in the file storage, decrypting it if it is
+// encrypted, and skips its first offset bytes.
+func (s *flexServer) openFile(ctx context.Context, path string, offset int64) (io.ReadCloser, error) {
+	var key []byte
+	if s.enc != nil {
+		var err error
+		key, err = s.enc.KeyForRead(ctx, path)
+		if err != nil {
+			return nil, err
+		}
 	}
-	path := pathForTask(status.GetTaskId(), name)
 
 	url, err := s.fs.PresignedURLForGet(ctx, path, time.Minute)
 	if err != nil {
 		return nil, err
 	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Read stored bytes as they are. Otherwise files stored with
+	// Content-Encoding may be decoded by storages or by the HTTP client.
+	req.Header.Set("Accept-Encoding", "gzip")
+	if key == nil && offset > 0 {
+		// Encrypted files are decrypted from the beginning, but others can be
+		// read partially.
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
 
-	loc := &flex.FileLocation{
-		CanonicalUrl: s.fs.CanonicalURL(path),
-		PresignedUrl: url,
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	skip := offset
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusPartialContent:
+		skip = 0
+	case http.StatusRequestedRangeNotSatisfiable:
+		res.Body.Close()
+		return ioutil.NopCloser(strings.NewReader("")), nil
+	case http.StatusNotFound:
+		res.Body.Close()
+		return nil, status.Errorf(codes.NotFound, "file not found: %s", path)
+	default:
+		res.Body.Close()
+		return nil, fmt.Errorf("reading %s: %s", path, res.Status)
+	}
+
+	var r io.Reader = res.Body
+	if key != nil {
+		r, err = envelope.NewReader(res.Body, key)
+		if err != nil {
+			res.Body.Close()
+			return nil, err
+		}
+	}
+	if err := skipBytes(r, skip); err != nil {
+		res.Body.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{r, res.Body}, nil
+}
+
+func (s *flexServer) GetJobArray(ctx context.Context, req *flex.GetJobArrayRequest) (*flex.GetJobArrayResponse, error) {
+	array, err := s.meta.GetJobArray(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &flex.GetJobArrayResponse{Array: array}, nil
+}
+
+// WatchJobs streams statuses of jobs whose ID is in req.Ids (or any job if it
+// is empty) and which match req.Filter. Current statuses of jobs specified by
+// req.Ids or req.Filter.ArrayId are sent first, followed by state transitions.
+func (s *flexServer) WatchJobs(req *flex.WatchJobsRequest, stream flex.FlexService_WatchJobsServer) error {
+	ctx := stream.Context()
+
+	ids := make(map[int64]struct{})
+	for _, id := range req.GetIds() {
+		ids[id] = struct{}{}
+	}
+	filter := req.GetFilter()
+	if len(ids) == 0 && filter.GetState() == flex.JobState_UNSPECIFIED && filter.GetLabel() == "" && filter.GetArrayId() == 0 {
+		return status.Error(codes.InvalidArgument, "either job IDs or a filter must be specified")
+	}
+
+	match := func(job *flex.JobStatus) bool {
+		if len(ids) > 0 {
+			if _, ok := ids[job.GetJob().GetId()]; !ok {
+				return false
+			}
+		}
+		return matchJobFilter(job, filter)
+	}
+
+	// Subscribe before reading current statuses so that no transition is
+	// missed.
+	sub := s.bus.Subscribe()
+	defer sub.Close()
+
+	var snapshots []*flex.JobStatus
+	for _, id := range req.GetIds() {
+		job, err := s.meta.GetJob(ctx, id)
+		if err != nil {
+			return err
+		}
+		snapshots = append(snapshots, job)
+	}
+	if len(ids) == 0 && filter.GetArrayId() != 0 {
+		jobs, err := s.meta.ListJobs(ctx, &flex.JobFilter{ArrayId: filter.GetArrayId()}, maxJobArraySize, math.MaxInt64)
+		if err != nil {
+			return err
+		}
+		snapshots = jobs
+	}
+
+	for _, job := range snapshots {
+		if match(job) {
+			if err := stream.Send(&flex.WatchJobsResponse{Job: job}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case job, ok := <-sub.C():
+			if !ok {
+				return status.Error(codes.Unavailable, "event stream overflowed")
+			}
+			if match(job) {
+				if err := stream.Send(&flex.WatchJobsResponse{Job: job}); err != nil {
+					return err
+				}
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
-	return &flex.GetJobOutputResponse{Location: loc}, nil
 }
 
 func (s *flexServer) ListJobs(ctx context.Context, req *flex.ListJobsRequest) (*flex.ListJobsResponse, error) {
-	jobs, err := s.meta.ListJobs(ctx, req.GetState(), req.GetLabel(), req.GetLimit(), req.GetBeforeId())
+	filter := &flex.JobFilter{
+		State:   req.GetState(),
+		Label:   req.GetLabel(),
+		ArrayId: req.GetArrayId(),
+	}
+	jobs, err := s.meta.ListJobs(ctx, filter, req.GetLimit(), req.GetBeforeId())
 	if err != nil {
 		return nil, err
 	}
@@ -144,6 +490,30 @@ func (s *flexServer) UpdateJobLabels(ctx context.Context, req *flex.UpdateJobLab
 	return &flex.UpdateJobLabelsResponse{}, nil
 }
 
+func (s *flexServer) BulkUpdateJobLabels(ctx context.Context, req *flex.BulkUpdateJobLabelsRequest) (*flex.BulkUpdateJobLabelsResponse, error) {
+	results, err := s.meta.BulkUpdateJobLabels(ctx, req.GetFilter(), req.GetAdds(), req.GetDels())
+	if err != nil {
+		return nil, err
+	}
+	return &flex.BulkUpdateJobLabelsResponse{Results: results}, nil
+}
+
+func (s *flexServer) BulkCancelJobs(ctx context.Context, req *flex.BulkCancelJobsRequest) (*flex.BulkCancelJobsResponse, error) {
+	results, err := s.meta.BulkCancelJobs(ctx, req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	return &flex.BulkCancelJobsResponse{Results: results}, nil
+}
+
+func (s *flexServer) BulkRetryJobs(ctx context.Context, req *flex.BulkRetryJobsRequest) (*flex.BulkRetryJobsResponse, error) {
+	results, err := s.meta.BulkRetryJobs(ctx, req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	return &flex.BulkRetryJobsResponse{Results: results}, nil
+}
+
 func (s *flexServer) InsertPackage(stream flex.FlexService_InsertPackageServer) error {
 	ctx := stream.Context()
 
@@ -178,18 +548,78 @@ func (s *flexServer) InsertPackage(stream flex.FlexService_InsertPackageServer)
 	}
 
 	hash := hasher.SumString()
+	path := pathForPackage(hash)
 
 	if _, err := f.Seek(0, io.SeekStart); err != nil {
 		return err
 	}
 
-	if err := s.fs.Put(ctx, pathForPackage(hash), f); err != nil {
+	var r io.ReadSeeker = f
+	if s.enc != nil {
+		ef, err := s.encryptFile(ctx, path, f)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(ef.Name())
+		defer ef.Close()
+		r = ef
+	}
+
+	if err := s.fs.Put(ctx, path, r); err != nil {
 		return err
 	}
 
 	return stream.SendAndClose(&flex.InsertPackageResponse{Hash: hash})
 }
 
+// encryptFile encrypts r with the data key of the file at path into a
+// temporary file.
+func (s *flexServer) encryptFile(ctx context.Context, path string, r io.Reader) (*os.File, error) {
+	key, err := s.enc.KeyForWrite(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.CreateTemp("", "flexhub.encrypted.")
+	if err != nil {
+		return nil, err
+	}
+	if err := func() error {
+		w, err := envelope.NewWriter(f, key)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(w, r); err != nil {
+			return err
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+		_, err = f.Seek(0, io.SeekStart)
+		return err
+	}(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, err
+	}
+	return f, nil
+}
+
+// presignedURLForGet returns a URL to download the file at path. If the file
+// is encrypted, the URL points to flexhub, which serves it decrypted.
+func (s *flexServer) presignedURLForGet(ctx context.Context, path string, dur time.Duration) (string, error) {
+	if s.enc != nil {
+		key, err := s.enc.KeyForRead(ctx, path)
+		if err != nil {
+			return "", err
+		}
+		if key != nil {
+			return s.enc.PresignedURLForGet(ctx, path, dur)
+		}
+	}
+	return s.fs.PresignedURLForGet(ctx, path, dur)
+}
+
 func (s *flexServer) GetPackage(ctx context.Context, req *flex.GetPackageRequest) (*flex.GetPackageResponse, error) {
 	if tag := req.GetTag(); tag != "" {
 		hash, err := s.meta.LookupTag(ctx, tag)
@@ -240,7 +670,7 @@ func (s *flexServer) FetchPackage(ctx context.Context, req *flex.FetchPackageReq
 		return nil, err
 	}
 
-	url, err := s.fs.PresignedURLForGet(ctx, path, time.Minute)
+	url, err := s.presignedURLForGet(ctx, path, time.Minute)
 	if err != nil {
 		return nil, err
 	}
@@ -287,6 +717,50 @@ func (s *flexServer) ListFlexlets(ctx context.Context, req *flex.ListFlexletsReq
 	return &flex.ListFlexletsResponse{Flexlets: flexlets}, nil
 }
 
+func (s *flexServer) GetFlexlet(ctx context.Context, req *flex.GetFlexletRequest) (*flex.GetFlexletResponse, error) {
+	flexlet, tasks, stats, err := s.meta.GetFlexlet(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &flex.GetFlexletResponse{Flexlet: flexlet, RecentTasks: tasks, TaskStats: stats}, nil
+}
+
+func (s *flexServer) CordonFlexlet(ctx context.Context, req *flex.CordonFlexletRequest) (*flex.CordonFlexletResponse, error) {
+	if err := s.meta.CordonFlexlet(ctx, req.GetName(), true); err != nil {
+		return nil, err
+	}
+	return &flex.CordonFlexletResponse{}, nil
+}
+
+func (s *flexServer) UncordonFlexlet(ctx context.Context, req *flex.UncordonFlexletRequest) (*flex.UncordonFlexletResponse, error) {
+	if err := s.meta.CordonFlexlet(ctx, req.GetName(), false); err != nil {
+		return nil, err
+	}
+	return &flex.UncordonFlexletResponse{}, nil
+}
+
+func (s *flexServer) DeleteFlexlet(ctx context.Context, req *flex.DeleteFlexletRequest) (*flex.DeleteFlexletResponse, error) {
+	if err := s.meta.DeleteFlexlet(ctx, req.GetName()); err != nil {
+		return nil, err
+	}
+	return &flex.DeleteFlexletResponse{}, nil
+}
+
+func (s *flexServer) DrainFlexlet(ctx context.Context, req *flex.DrainFlexletRequest) (*flex.DrainFlexletResponse, error) {
+	if err := s.meta.DrainFlexlet(ctx, req.GetName()); err != nil {
+		return nil, err
+	}
+	return &flex.DrainFlexletResponse{}, nil
+}
+
+func (s *flexServer) ListWebhookDeliveries(ctx context.Context, req *flex.ListWebhookDeliveriesRequest) (*flex.ListWebhookDeliveriesResponse, error) {
+	deliveries, err := s.meta.ListWebhookDeliveries(ctx, req.GetJobId(), req.GetLimit(), req.GetBeforeId())
+	if err != nil {
+		return nil, err
+	}
+	return &flex.ListWebhookDeliveriesResponse{Deliveries: deliveries}, nil
+}
+
 func (s *flexServer) GetStats(ctx context.Context, req *flex.GetStatsRequest) (*flex.GetStatsResponse, error) {
 	stats, err := s.meta.GetStats(ctx)
 	if err != nil {
@@ -24,6 +24,7 @@ import (
 
 	"github.com/nya3jp/flex"
 	"github.com/nya3jp/flex/cmd/flexhub/internal/database"
+	"github.com/nya3jp/flex/cmd/flexhub/internal/encryption"
 	"github.com/nya3jp/flex/cmd/flexhub/internal/waitqueue"
 	"github.com/nya3jp/flex/internal/flexletpb"
 )
@@ -32,14 +33,16 @@ type flexletServer struct {
 	flexletpb.UnimplementedFlexletServiceServer
 	meta  *database.MetaStore
 	fs    FS
+	enc   *encryption.Encryptor // nil if encryption is disabled
 	queue *waitqueue.WaitQueue
 }
 
-func newFlexletServer(meta *database.MetaStore, fs FS) *flexletServer {
+func newFlexletServer(meta *database.MetaStore, queue *waitqueue.WaitQueue, fs FS, enc *encryption.Encryptor) *flexletServer {
 	return &flexletServer{
 		meta:  meta,
 		fs:    fs,
-		queue: waitqueue.New(meta),
+		enc:   enc,
+		queue: queue,
 	}
 }
 
@@ -52,7 +55,7 @@ func (s *flexletServer) TakeTask(ctx context.Context, req *flexletpb.TakeTaskReq
 		defer cancel()
 		return s.queue.WaitTask(waitCtx, req.GetFlexletName())
 	}()
-	if errors.Is(err, database.ErrNoPendingTask) {
+	if errors.Is(err, database.ErrNoPendingTask) || errors.Is(err, database.ErrFlexletUnavailable) {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 	if err != nil {
@@ -66,12 +69,21 @@ func (s *flexletServer) TakeTask(ctx context.Context, req *flexletpb.TakeTaskReq
 		if err != nil {
 			return nil, err
 		}
+		var key []byte
+		if s.enc != nil {
+			key, err = s.enc.KeyForRead(ctx, path)
+			if err != nil {
+				return nil, err
+			}
+		}
 		tpkgs = append(tpkgs, &flexletpb.TaskPackage{
 			Location: &flex.FileLocation{
 				CanonicalUrl: s.fs.CanonicalURL(path),
 				PresignedUrl: url,
 			},
-			InstallDir: jpkg.GetInstallDir(),
+			InstallDir:    jpkg.GetInstallDir(),
+			EncryptionKey: key,
+			Hash:          jpkg.GetHash(),
 		})
 	}
 
@@ -88,6 +100,28 @@ func (s *flexletServer) TakeTask(ctx context.Context, req *flexletpb.TakeTaskReq
 		return nil, err
 	}
 
+	logPath := pathForTask(ref.GetTaskId(), logName)
+	logURL, err := s.fs.PresignedURLForPut(ctx, logPath, writeLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdoutKey, stderrKey, logKey []byte
+	if s.enc != nil {
+		stdoutKey, err = s.enc.KeyForWrite(ctx, stdoutPath)
+		if err != nil {
+			return nil, err
+		}
+		stderrKey, err = s.enc.KeyForWrite(ctx, stderrPath)
+		if err != nil {
+			return nil, err
+		}
+		logKey, err = s.enc.KeyForWrite(ctx, logPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	task := &flexletpb.Task{
 		Ref: ref,
 		Spec: &flexletpb.TaskSpec{
@@ -102,6 +136,14 @@ func (s *flexletServer) TakeTask(ctx context.Context, req *flexletpb.TakeTaskReq
 					CanonicalUrl: s.fs.CanonicalURL(stderrPath),
 					PresignedUrl: stderrURL,
 				},
+				Log: &flex.FileLocation{
+					CanonicalUrl: s.fs.CanonicalURL(logPath),
+					PresignedUrl: logURL,
+				},
+				StdoutEncryptionKey: stdoutKey,
+				StderrEncryptionKey: stderrKey,
+				LogEncryptionKey:    logKey,
+				Encoding:            outputEncoding,
 			},
 			Limits: jobSpec.GetLimits(),
 		},
@@ -110,13 +152,25 @@ func (s *flexletServer) TakeTask(ctx context.Context, req *flexletpb.TakeTaskReq
 }
 
 func (s *flexletServer) UpdateTask(ctx context.Context, req *flexletpb.UpdateTaskRequest) (*flexletpb.UpdateTaskResponse, error) {
-	return &flexletpb.UpdateTaskResponse{}, s.meta.UpdateTask(ctx, req.GetRef())
+	leaseLost, err := s.meta.UpdateTask(ctx, req.GetRef())
+	if err != nil {
+		return nil, err
+	}
+	return &flexletpb.UpdateTaskResponse{LeaseLost: leaseLost}, nil
 }
 
 func (s *flexletServer) FinishTask(ctx context.Context, req *flexletpb.FinishTaskRequest) (*flexletpb.FinishTaskResponse, error) {
-	return &flexletpb.FinishTaskResponse{}, s.meta.FinishTask(ctx, req.GetRef(), req.GetResult(), req.GetNeedRetry())
+	leaseLost, err := s.meta.FinishTask(ctx, req.GetRef(), req.GetResult(), req.GetNeedRetry())
+	if err != nil {
+		return nil, err
+	}
+	return &flexletpb.FinishTaskResponse{LeaseLost: leaseLost}, nil
 }
 
 func (s *flexletServer) UpdateFlexlet(ctx context.Context, req *flexletpb.UpdateFlexletRequest) (*flexletpb.UpdateFlexletResponse, error) {
-	return &flexletpb.UpdateFlexletResponse{}, s.meta.UpdateFlexlet(ctx, req.GetStatus())
+	drain, err := s.meta.UpdateFlexlet(ctx, req.GetStatus())
+	if err != nil {
+		return nil, err
+	}
+	return &flexletpb.UpdateFlexletResponse{Drain: drain}, nil
 }
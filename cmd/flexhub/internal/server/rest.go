@@ -15,8 +15,11 @@
 package server
 
 import (
+	"io"
+	"log"
 	"math"
 	"net/http"
+	"strings"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-contrib/static"
@@ -24,10 +27,10 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/cmd/flexhub/internal/encryption"
 	"github.com/nya3jp/flex/cmd/flexhub/internal/restfix"
 	"github.com/nya3jp/flex/internal/grpcutil"
 )
@@ -39,20 +42,45 @@ func init() {
 type restServer struct {
 	cl     flex.FlexServiceClient
 	engine *gin.Engine
+	// redirectEncoded is whether clients can be redirected to encoded outputs,
+	// i.e. the storage serves them with Content-Encoding.
+	redirectEncoded bool
 }
 
-func newRESTServer(cl flex.FlexServiceClient) *restServer {
+func newRESTServer(cl flex.FlexServiceClient, fs FS, enc *encryption.Encryptor) *restServer {
 	engine := gin.New()
 	s := &restServer{cl: cl, engine: engine}
+	// Encrypted outputs are served by flexhub, which does not know their
+	// encodings.
+	if cefs, ok := fs.(ContentEncodingFS); ok && cefs.KeepsContentEncoding() && enc == nil {
+		s.redirectEncoded = true
+	}
 	engine.Use(cors.Default()) // allow all CORS requests
 	engine.GET("/healthz", s.handleHealthz)
+	// Serve files stored by flexhub itself, and decrypted files if encryption
+	// is enabled. Requests are authorized by URL signatures, not by
+	// credentials.
+	var handlers []FSHandler
+	if h, ok := fs.(FSHandler); ok {
+		handlers = append(handlers, h)
+	}
+	if enc != nil {
+		handlers = append(handlers, enc)
+	}
+	for _, h := range handlers {
+		for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodPut} {
+			engine.Handle(method, h.BasePath()+"*path", gin.WrapH(h))
+		}
+	}
 	engine.Use(static.Serve("/", static.LocalFile("./web", true)))
 	api := engine.Group("/api")
 	api.GET("/jobs", s.handleAPIJobs)
 	api.GET("/jobs/:id", s.handleAPIJob)
 	api.GET("/jobs/:id/stdout", s.handleAPIJobStdout)
 	api.GET("/jobs/:id/stderr", s.handleAPIJobStderr)
+	api.GET("/jobs/:id/log", s.handleAPIJobLog)
 	api.GET("/flexlets", s.handleAPIFlexlets)
+	api.GET("/flexlets/:name", s.handleAPIFlexlet)
 	api.GET("/stats", s.handleAPIStats)
 	return s
 }
@@ -69,6 +97,7 @@ type jobsRequest struct {
 	Limit    int64  `form:"limit"`
 	BeforeID int64  `form:"before"`
 	Label    string `form:"label"`
+	ArrayID  int64  `form:"array"`
 }
 
 func (s *restServer) handleAPIJobs(ctx *gin.Context) {
@@ -88,6 +117,7 @@ func (s *restServer) handleAPIJobs(ctx *gin.Context) {
 			Limit:    req.Limit,
 			BeforeId: req.BeforeID,
 			Label:    req.Label,
+			ArrayId:  req.ArrayID,
 		}
 		res, err := s.cl.ListJobs(ctx, rpcReq, withCreds(ctx))
 		if err != nil {
@@ -137,16 +167,46 @@ func (s *restServer) handleAPIJobStderr(ctx *gin.Context) {
 	s.handleAPIJobOutput(ctx, flex.GetJobOutputRequest_STDERR)
 }
 
+func (s *restServer) handleAPIJobLog(ctx *gin.Context) {
+	s.handleAPIJobOutput(ctx, flex.GetJobOutputRequest_LOG)
+}
+
 type jobOutputRequest struct {
 	ID int64 `uri:"id"`
 }
 
+type jobOutputQuery struct {
+	Proxy  bool  `form:"proxy"`
+	Offset int64 `form:"offset"`
+	Length int64 `form:"length"`
+}
+
 func (s *restServer) handleAPIJobOutput(ctx *gin.Context, outputType flex.GetJobOutputRequest_JobOutputType) {
 	respond(ctx, func() error {
 		var req jobOutputRequest
 		if err := ctx.ShouldBindUri(&req); err != nil {
 			return err
 		}
+		var query jobOutputQuery
+		if err := ctx.ShouldBindQuery(&query); err != nil {
+			return err
+		}
+
+		proxyReq := &flex.ReadJobOutputRequest{
+			Id:     req.ID,
+			Type:   outputType,
+			Offset: query.Offset,
+			Length: query.Length,
+		}
+		// Let the client decompress the output if it can. Offsets and lengths
+		// apply to decoded outputs, so an encoded one is sent only in full.
+		if query.Offset == 0 && query.Length == 0 && acceptsGzip(ctx) {
+			proxyReq.AcceptEncodings = []string{"gzip"}
+		}
+
+		if query.Proxy {
+			return s.proxyJobOutput(ctx, proxyReq)
+		}
 
 		rpcReq := &flex.GetJobOutputRequest{
 			Id:   req.ID,
@@ -156,11 +216,58 @@ func (s *restServer) handleAPIJobOutput(ctx *gin.Context, outputType flex.GetJob
 		if err != nil {
 			return err
 		}
+		if res.GetContentEncoding() != "" && (!s.redirectEncoded || len(proxyReq.GetAcceptEncodings()) == 0) {
+			// Decode the output for the client, or stream it with
+			// Content-Encoding if the storage does not serve it so.
+			return s.proxyJobOutput(ctx, proxyReq)
+		}
 		ctx.Redirect(http.StatusFound, res.GetLocation().GetPresignedUrl())
 		return nil
 	})
 }
 
+// proxyJobOutput streams a job output through flexhub instead of redirecting
+// to the file storage.
+func (s *restServer) proxyJobOutput(ctx *gin.Context, req *flex.ReadJobOutputRequest) error {
+	stream, err := s.cl.ReadJobOutput(ctx, req, withCreds(ctx))
+	if err != nil {
+		return err
+	}
+
+	// Receive the first chunk before writing headers so that errors are
+	// reported with a proper status code.
+	res, err := stream.Recv()
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	ctx.Header("Content-Type", "text/plain; charset=utf-8")
+	if encoding := res.GetContentEncoding(); encoding != "" {
+		ctx.Header("Content-Encoding", encoding)
+	}
+	ctx.Status(http.StatusOK)
+	for err == nil {
+		if _, err := ctx.Writer.Write(res.GetData()); err != nil {
+			return nil
+		}
+		res, err = stream.Recv()
+	}
+	if err != io.EOF {
+		log.Printf("WARNING: Proxying job %d output failed: %v", req.GetId(), err)
+	}
+	return nil
+}
+
+// acceptsGzip returns whether the client accepts gzip content encoding.
+func acceptsGzip(ctx *gin.Context) bool {
+	for _, field := range strings.Split(ctx.GetHeader("Accept-Encoding"), ",") {
+		if strings.TrimSpace(strings.SplitN(field, ";", 2)[0]) == "gzip" {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *restServer) handleAPIFlexlets(ctx *gin.Context) {
 	respond(ctx, func() error {
 		res, err := s.cl.ListFlexlets(ctx, &flex.ListFlexletsRequest{}, withCreds(ctx))
@@ -176,6 +283,32 @@ func (s *restServer) handleAPIFlexlets(ctx *gin.Context) {
 	})
 }
 
+type flexletRequest struct {
+	Name string `uri:"name"`
+}
+
+func (s *restServer) handleAPIFlexlet(ctx *gin.Context) {
+	respond(ctx, func() error {
+		var req flexletRequest
+		if err := ctx.ShouldBindUri(&req); err != nil {
+			return err
+		}
+
+		rpcReq := &flex.GetFlexletRequest{
+			Name: req.Name,
+		}
+		res, err := s.cl.GetFlexlet(ctx, rpcReq, withCreds(ctx))
+		if err != nil {
+			return err
+		}
+
+		if err := restfix.FlexletStatus(res.GetFlexlet()); err != nil {
+			return err
+		}
+		return writeProtoJSON(ctx, res)
+	})
+}
+
 func (s *restServer) handleAPIStats(ctx *gin.Context) {
 	respond(ctx, func() error {
 		res, err := s.cl.GetStats(ctx, &flex.GetStatsRequest{}, withCreds(ctx))
@@ -203,6 +336,10 @@ func respond(ctx *gin.Context, f func() error) {
 		ctx.String(http.StatusUnauthorized, s.Message())
 	case codes.PermissionDenied:
 		ctx.String(http.StatusForbidden, s.Message())
+	case codes.NotFound:
+		ctx.String(http.StatusNotFound, s.Message())
+	case codes.InvalidArgument:
+		ctx.String(http.StatusBadRequest, s.Message())
 	default:
 		ctx.String(http.StatusInternalServerError, s.Message())
 	}
@@ -217,12 +354,8 @@ type protoJSONRender struct {
 	msg proto.Message
 }
 
-var protoJSONOptions = protojson.MarshalOptions{
-	EmitUnpopulated: true,
-}
-
 func (r protoJSONRender) Render(w http.ResponseWriter) error {
-	b, err := protoJSONOptions.Marshal(r.msg)
+	b, err := restfix.ProtoJSONOptions.Marshal(r.msg)
 	if err != nil {
 		return err
 	}
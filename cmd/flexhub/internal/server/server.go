@@ -28,8 +28,10 @@ import (
 
 	"github.com/nya3jp/flex"
 	"github.com/nya3jp/flex/cmd/flexhub/internal/database"
+	"github.com/nya3jp/flex/cmd/flexhub/internal/encryption"
+	"github.com/nya3jp/flex/cmd/flexhub/internal/eventbus"
+	"github.com/nya3jp/flex/cmd/flexhub/internal/waitqueue"
 	"github.com/nya3jp/flex/internal/flexletpb"
-	"github.com/nya3jp/flex/internal/pubsub"
 )
 
 func newDualHandler(grpcServer *grpc.Server, restServer http.Handler) http.Handler {
@@ -44,7 +46,7 @@ func newDualHandler(grpcServer *grpc.Server, restServer http.Handler) http.Handl
 	return h2cHandler
 }
 
-func Run(ctx context.Context, port int, meta *database.MetaStore, fs FS, password string, publisher *pubsub.Publisher) error {
+func Run(ctx context.Context, port int, meta *database.MetaStore, bus *eventbus.Bus, fs FS, enc *encryption.Encryptor, password string) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -60,11 +62,14 @@ func Run(ctx context.Context, port int, meta *database.MetaStore, fs FS, passwor
 	}
 	defer cc.Close()
 
+	queue := waitqueue.New(meta)
+	go queue.Run(ctx, bus)
+
 	grpcServer := grpc.NewServer(makeAuthOptions(password)...)
-	flex.RegisterFlexServiceServer(grpcServer, newFlexServer(meta, fs, publisher))
-	flexletpb.RegisterFlexletServiceServer(grpcServer, newFlexletServer(meta, fs))
+	flex.RegisterFlexServiceServer(grpcServer, newFlexServer(meta, bus, fs, enc))
+	flexletpb.RegisterFlexletServiceServer(grpcServer, newFlexletServer(meta, queue, fs, enc))
 
-	restServer := newRESTServer(flex.NewFlexServiceClient(cc))
+	restServer := newRESTServer(flex.NewFlexServiceClient(cc), fs, enc)
 
 	httpServer := &http.Server{
 		Handler:     newDualHandler(grpcServer, restServer),
@@ -15,8 +15,10 @@
 package waitqueue
 
 import (
+	"container/list"
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -24,39 +26,146 @@ import (
 
 	"github.com/nya3jp/flex"
 	"github.com/nya3jp/flex/cmd/flexhub/internal/database"
-	"github.com/nya3jp/flex/internal/concurrent"
+	"github.com/nya3jp/flex/cmd/flexhub/internal/eventbus"
 	"github.com/nya3jp/flex/internal/ctxutil"
 	"github.com/nya3jp/flex/internal/flexletpb"
 )
 
+const (
+	// pollInterval is the interval at which a waiting flexlet is woken up
+	// even without notifications, so that jobs are eventually dispatched
+	// when notifications are missed. Only one flexlet is woken up per
+	// interval, so the DB load does not grow with the number of waiters.
+	pollInterval = 5 * time.Second
+
+	// unavailableInterval is the interval at which a flexlet that may not
+	// take tasks, e.g. because it is cordoned, checks again.
+	unavailableInterval = 5 * time.Second
+)
+
+// WaitQueue dispatches pending jobs to flexlets waiting for tasks.
+//
+// Waiting flexlets are parked in FIFO order and woken up one at a time as jobs
+// become pending, which is learned from the event bus fed by job submissions
+// and requeues. A woken flexlet takes a task from the database, which remains
+// the source of truth. Jobs have no placement constraints at the moment, so
+// all available flexlets form a single class; flexlets that may not take tasks
+// are not parked and poll instead so that they do not consume wakeups.
 type WaitQueue struct {
 	meta *database.MetaStore
-	lock *concurrent.Limiter
+
+	mu      sync.Mutex
+	waiters *list.List // of chan struct{}
 }
 
 func New(meta *database.MetaStore) *WaitQueue {
 	return &WaitQueue{
-		meta: meta,
-		lock: concurrent.NewLimiter(1),
+		meta:    meta,
+		waiters: list.New(),
 	}
 }
 
-func (q *WaitQueue) WaitTask(ctx context.Context, flexletName string) (*flexletpb.TaskRef, *flex.JobSpec, error) {
-	if err := q.lock.Take(ctx); err != nil {
-		return nil, nil, fixError(ctx, err)
+// Run wakes up waiting flexlets as jobs become pending until ctx is canceled.
+func (q *WaitQueue) Run(ctx context.Context, bus *eventbus.Bus) {
+	sub := bus.Subscribe()
+	defer func() { sub.Close() }()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job, ok := <-sub.C():
+			if !ok {
+				// Events were lost. Wake up all waiters so that pending
+				// jobs are not left behind.
+				sub = bus.Subscribe()
+				q.wakeAll()
+				continue
+			}
+			if job.GetState() == flex.JobState_PENDING {
+				q.wake()
+			}
+		case <-ticker.C:
+			q.wake()
+		}
 	}
-	defer q.lock.Done()
+}
 
+func (q *WaitQueue) WaitTask(ctx context.Context, flexletName string) (*flexletpb.TaskRef, *flex.JobSpec, error) {
+	ch := make(chan struct{}, 1)
 	for {
-		taskID, spec, err := q.meta.TakeTask(ctx, flexletName)
+		// Park before taking a task so that a job that becomes pending
+		// right after the attempt wakes us up.
+		elem := q.park(ch)
+
+		ref, spec, err := q.meta.TakeTask(ctx, flexletName)
 		if errors.Is(err, database.ErrNoPendingTask) {
-			ctxutil.Sleep(ctx, time.Second)
+			select {
+			case <-ch:
+				continue
+			case <-ctx.Done():
+				q.unpark(elem, ch)
+				return nil, nil, fixError(ctx, ctx.Err())
+			}
+		}
+		q.unpark(elem, ch)
+
+		if errors.Is(err, database.ErrFlexletUnavailable) {
+			if err := ctxutil.Sleep(ctx, unavailableInterval); err != nil {
+				return nil, nil, fixError(ctx, err)
+			}
 			continue
 		}
 		if err != nil {
+			// Let another flexlet try so that the job is not left behind.
+			q.wake()
 			return nil, nil, fixError(ctx, err)
 		}
-		return taskID, spec, nil
+		return ref, spec, nil
+	}
+}
+
+func (q *WaitQueue) park(ch chan struct{}) *list.Element {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.waiters.PushBack(ch)
+}
+
+// unpark removes a waiter from the queue. If the waiter has been woken up
+// meanwhile, the wakeup is passed to another waiter.
+func (q *WaitQueue) unpark(elem *list.Element, ch chan struct{}) {
+	q.mu.Lock()
+	q.waiters.Remove(elem)
+	q.mu.Unlock()
+
+	select {
+	case <-ch:
+		q.wake()
+	default:
+	}
+}
+
+// wake wakes up the longest waiting flexlet, if any.
+func (q *WaitQueue) wake() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if elem := q.waiters.Front(); elem != nil {
+		q.waiters.Remove(elem)
+		elem.Value.(chan struct{}) <- struct{}{}
+	}
+}
+
+func (q *WaitQueue) wakeAll() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for elem := q.waiters.Front(); elem != nil; elem = q.waiters.Front() {
+		q.waiters.Remove(elem)
+		elem.Value.(chan struct{}) <- struct{}{}
 	}
 }
 
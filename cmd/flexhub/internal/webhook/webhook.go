@@ -0,0 +1,229 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/cmd/flexhub/internal/database"
+	"github.com/nya3jp/flex/cmd/flexhub/internal/eventbus"
+	"github.com/nya3jp/flex/cmd/flexhub/internal/restfix"
+	"github.com/nya3jp/flex/internal/concurrent"
+)
+
+const (
+	// SignatureHeader is the HTTP header carrying the HMAC-SHA256 signature of
+	// a request body in the form of "sha256=<hex>".
+	SignatureHeader = "X-Flex-Signature"
+	DeliveryHeader  = "X-Flex-Delivery"
+	EventHeader     = "X-Flex-Event"
+
+	maxAttempts    = 8
+	requestTimeout = 10 * time.Second
+	maxRetryDelay  = 5 * time.Minute
+	maxConcurrency = 16
+
+	// deliveryLease is how long a replica owns a webhook delivery after each
+	// attempt. It covers the longest wait before the next attempt, so that
+	// other replicas take over a delivery only when its owner has exited.
+	deliveryLease = maxRetryDelay + time.Minute
+	// claimInterval is the interval of claiming deliveries abandoned by
+	// other replicas.
+	claimInterval = time.Minute
+)
+
+// Dispatcher delivers job state transitions to webhooks specified in job
+// specs.
+type Dispatcher struct {
+	meta    *database.MetaStore
+	secret  []byte
+	client  *http.Client
+	limiter *concurrent.Limiter
+}
+
+func NewDispatcher(meta *database.MetaStore, secret string) *Dispatcher {
+	return &Dispatcher{
+		meta:    meta,
+		secret:  []byte(secret),
+		client:  &http.Client{Timeout: requestTimeout},
+		limiter: concurrent.NewLimiter(maxConcurrency),
+	}
+}
+
+// Run delivers events published to bus until ctx is canceled. Deliveries left
+// pending by exited replicas, including previous runs of this one, are
+// resumed once their leases expire.
+func (d *Dispatcher) Run(ctx context.Context, bus *eventbus.Bus) {
+	sub := bus.SubscribeLocal()
+	defer func() { sub.Close() }()
+
+	d.resume(ctx)
+	ticker := time.NewTicker(claimInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			d.resume(ctx)
+		case job, ok := <-sub.C():
+			if !ok {
+				log.Print("WARNING: Webhook dispatcher fell behind; some events are not delivered")
+				sub = bus.SubscribeLocal()
+				continue
+			}
+			d.dispatch(ctx, job)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// resume claims and resumes deliveries abandoned by other replicas.
+func (d *Dispatcher) resume(ctx context.Context) {
+	deliveries, payloads, err := d.meta.ClaimWebhookDeliveries(ctx, deliveryLease)
+	if err != nil {
+		log.Printf("WARNING: Failed to resume webhook deliveries: %v", err)
+		return
+	}
+	for i, delivery := range deliveries {
+		go d.deliver(ctx, delivery, payloads[i])
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, job *flex.JobStatus) {
+	webhooks := job.GetJob().GetSpec().GetNotifications().GetWebhooks()
+	if len(webhooks) == 0 {
+		return
+	}
+	event := job.GetState()
+	if event != flex.JobState_RUNNING && event != flex.JobState_FINISHED {
+		return
+	}
+
+	payload, err := marshalJobStatus(job)
+	if err != nil {
+		log.Printf("WARNING: Failed to encode job %d for webhooks: %v", job.GetJob().GetId(), err)
+		return
+	}
+
+	for _, url := range webhooks {
+		id, err := d.meta.InsertWebhookDelivery(ctx, job.GetJob().GetId(), url, event, payload, deliveryLease)
+		if err != nil {
+			log.Printf("WARNING: Failed to record a webhook delivery: %v", err)
+			continue
+		}
+		delivery := &flex.WebhookDelivery{
+			Id:    id,
+			JobId: job.GetJob().GetId(),
+			Url:   url,
+			Event: event,
+		}
+		go d.deliver(ctx, delivery, payload)
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery *flex.WebhookDelivery, payload []byte) {
+	retry := concurrent.NewRetry(time.Second, maxRetryDelay)
+	for attempts := delivery.GetAttempts() + 1; ; attempts++ {
+		statusCode, err := d.post(ctx, delivery, payload)
+		if ctx.Err() != nil {
+			return
+		}
+
+		state := flex.WebhookDeliveryState_DELIVERY_SUCCEEDED
+		var errMsg string
+		if err != nil {
+			errMsg = err.Error()
+			state = flex.WebhookDeliveryState_DELIVERY_PENDING
+			if attempts >= maxAttempts {
+				state = flex.WebhookDeliveryState_DELIVERY_FAILED
+			}
+		}
+
+		owned, err := d.meta.UpdateWebhookDelivery(ctx, delivery.GetId(), state, attempts, statusCode, errMsg, deliveryLease)
+		if err != nil {
+			log.Printf("WARNING: Failed to record a webhook delivery: %v", err)
+		} else if !owned {
+			log.Printf("WARNING: Webhook delivery %d was taken over by another replica", delivery.GetId())
+			return
+		}
+		if state != flex.WebhookDeliveryState_DELIVERY_PENDING {
+			return
+		}
+
+		if err := retry.Wait(ctx); err != nil {
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) post(ctx context.Context, delivery *flex.WebhookDelivery, payload []byte) (statusCode int32, err error) {
+	if err := d.limiter.Take(ctx); err != nil {
+		return 0, err
+	}
+	defer d.limiter.Done()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.GetUrl(), bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.GetId(), 10))
+	req.Header.Set(EventHeader, delivery.GetEvent().String())
+	if len(d.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(d.secret, payload))
+	}
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode/100 != 2 {
+		return int32(res.StatusCode), errors.New(res.Status)
+	}
+	return int32(res.StatusCode), nil
+}
+
+// Sign returns the value of SignatureHeader for payload.
+func Sign(secret, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func marshalJobStatus(job *flex.JobStatus) ([]byte, error) {
+	job = proto.Clone(job).(*flex.JobStatus)
+	if err := restfix.JobStatus(job); err != nil {
+		return nil, err
+	}
+	return restfix.ProtoJSONOptions.Marshal(job)
+}
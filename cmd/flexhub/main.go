@@ -16,8 +16,11 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net/url"
 	"os"
@@ -30,13 +33,16 @@ import (
 	"golang.org/x/sys/unix"
 
 	"github.com/nya3jp/flex/cmd/flexhub/internal/database"
+	"github.com/nya3jp/flex/cmd/flexhub/internal/encryption"
+	"github.com/nya3jp/flex/cmd/flexhub/internal/eventbus"
 	"github.com/nya3jp/flex/cmd/flexhub/internal/filestorage"
 	"github.com/nya3jp/flex/cmd/flexhub/internal/server"
+	"github.com/nya3jp/flex/cmd/flexhub/internal/webhook"
 	"github.com/nya3jp/flex/internal/ctxutil"
 	"github.com/nya3jp/flex/internal/pubsub"
 )
 
-func newFileSystem(ctx context.Context, fsURL string) (server.FS, error) {
+func newFileSystem(ctx context.Context, fsURL, publicURL string, signingKey []byte, s3Creds filestorage.S3Credentials) (server.FS, error) {
 	parsed, err := url.Parse(fsURL)
 	if err != nil {
 		return nil, err
@@ -45,21 +51,108 @@ func newFileSystem(ctx context.Context, fsURL string) (server.FS, error) {
 	case "gs":
 		return filestorage.NewGS(ctx, fsURL)
 	case "s3":
-		return filestorage.NewS3(ctx, fsURL)
+		return filestorage.NewS3(ctx, fsURL, s3Creds)
 	case "http":
-		return filestorage.NewAnonymous(fsURL)
+		return filestorage.NewAnonymous(fsURL, signingKey)
+	case "file":
+		return filestorage.NewLocal(fsURL, publicURL, signingKey)
 	default:
 		return nil, fmt.Errorf("unknown filesystem scheme: %s", parsed.Scheme)
 	}
 }
 
+// readEncryptionKey returns the master key for server-side encryption, given
+// either as a base64-encoded string or a path to a file containing one. It
+// returns nil if neither is given.
+func readEncryptionKey(key, keyFile string) ([]byte, error) {
+	if key != "" && keyFile != "" {
+		return nil, errors.New("--encryption-key and --encryption-key-file are exclusive")
+	}
+	if keyFile != "" {
+		b, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		key = string(b)
+	}
+	if key == "" {
+		return nil, nil
+	}
+	return encryption.ParseMasterKey(key)
+}
+
+// maintenanceLease is the name of the lease held by the flexhub replica that
+// runs periodic maintenance.
+const maintenanceLease = "maintenance"
+
+// runMaintenance runs periodic maintenance until ctx is canceled. When
+// multiple flexhub replicas share a database, only the one holding the
+// maintenance lease runs it.
+func runMaintenance(ctx context.Context, meta *database.MetaStore, flexletTimeout, taskTimeout time.Duration) {
+	const interval = 10 * time.Second
+	leader := false
+	for {
+		acquired, err := meta.AcquireLease(ctx, maintenanceLease, 3*interval)
+		if err != nil {
+			log.Printf("WARNING: %v", err)
+		}
+		if acquired != leader {
+			if acquired {
+				log.Print("INFO: Acquired the maintenance lease")
+			} else {
+				log.Print("INFO: Lost the maintenance lease")
+			}
+			leader = acquired
+		}
+		if leader {
+			if err := meta.Maintain(ctx, flexletTimeout, taskTimeout); err != nil {
+				log.Printf("WARNING: Table maintainance failed: %v", err)
+			}
+		}
+		if err := ctxutil.Sleep(ctx, interval); err != nil {
+			return
+		}
+	}
+}
+
 func run(c *cli.Context) error {
 	ctx := c.Context
 	port := c.Int("port")
 	dbURL := c.String("db")
 	fsURL := c.String("fs")
 	password := c.String("password")
-	topicID := c.String("publish")
+	publishURL := c.String("publish")
+	webhookSecret := c.String("webhook-secret")
+	flexletTimeout := c.Duration("flexlet-timeout")
+	taskTimeout := c.Duration("task-timeout")
+	eventPollInterval := c.Duration("event-poll-interval")
+	publicURL := c.String("public-url")
+	signingKey := []byte(c.String("url-signing-key"))
+	encryptionKey := c.String("encryption-key")
+	encryptionKeyFile := c.String("encryption-key-file")
+	s3Creds := filestorage.S3Credentials{
+		AccessKeyID:     c.String("s3-access-key-id"),
+		SecretAccessKey: c.String("s3-secret-access-key"),
+	}
+
+	if flexletTimeout <= 0 || taskTimeout <= 0 {
+		return errors.New("timeouts must be positive")
+	}
+	if eventPollInterval <= 0 {
+		return errors.New("--event-poll-interval must be positive")
+	}
+
+	masterKey, err := readEncryptionKey(encryptionKey, encryptionKeyFile)
+	if err != nil {
+		return err
+	}
+
+	// URLs signed by one flexhub process must be accepted by the others and
+	// by later processes, so a random signing key is unacceptable whenever
+	// flexhub serves files itself.
+	if parsed, err := url.Parse(fsURL); err == nil && len(signingKey) == 0 && (masterKey != nil || parsed.Scheme == "file") {
+		return errors.New("--url-signing-key is required with file:// storage or encryption")
+	}
 
 	db, err := sql.Open("mysql", dbURL)
 	if err != nil {
@@ -67,40 +160,52 @@ func run(c *cli.Context) error {
 	}
 	defer db.Close()
 
-	meta := database.NewMetaStore(db)
+	bus := eventbus.New()
+	meta := database.NewMetaStore(db, bus)
 	if err := meta.InitTables(ctx); err != nil {
 		return err
 	}
-	if err := meta.Maintain(ctx); err != nil {
-		return err
-	}
 
-	var publisher *pubsub.Publisher
-	if topicID != "" {
-		publisher, err = pubsub.NewPublisher(ctx, topicID)
+	if publishURL != "" {
+		publisher, err := pubsub.NewPublisher(ctx, publishURL)
 		if err != nil {
 			return err
 		}
 		defer publisher.Close()
+		go eventbus.Forward(ctx, bus, publisher)
 	}
 
-	go func() {
-		for {
-			if err := ctxutil.Sleep(ctx, 10*time.Second); err != nil {
-				return
-			}
-			if err := meta.Maintain(ctx); err != nil {
-				log.Printf("WARNING: Table maintainance failed: %v", err)
-			}
+	go runMaintenance(ctx, meta, flexletTimeout, taskTimeout)
+	go meta.RelayJobEvents(ctx, eventPollInterval)
+
+	go webhook.NewDispatcher(meta, webhookSecret).Run(ctx, bus)
+
+	if publicURL == "" {
+		publicURL = fmt.Sprintf("http://localhost:%d/", port)
+	}
+	if len(signingKey) == 0 {
+		log.Print("WARNING: --url-signing-key is not set; using a random key that other replicas and restarted processes do not share")
+		signingKey = make([]byte, 32)
+		if _, err := rand.Read(signingKey); err != nil {
+			return err
 		}
-	}()
+	}
 
-	fs, err := newFileSystem(ctx, fsURL)
+	fs, err := newFileSystem(ctx, fsURL, publicURL, signingKey, s3Creds)
 	if err != nil {
 		return err
 	}
 
-	return server.Run(ctx, port, meta, fs, password, publisher)
+	var enc *encryption.Encryptor
+	if masterKey != nil {
+		enc, err = encryption.New(meta, fs, masterKey, publicURL, signingKey)
+		if err != nil {
+			return err
+		}
+		log.Print("INFO: Server-side encryption is enabled")
+	}
+
+	return server.Run(ctx, port, meta, bus, fs, enc, password)
 }
 
 func main() {
@@ -118,9 +223,19 @@ func main() {
 		Flags: []cli.Flag{
 			&cli.IntFlag{Name: "port", Value: defaultPort, Usage: "TCP port to listen on"},
 			&cli.StringFlag{Name: "db", Required: true, Usage: `DB URL (ex. "username:password@tcp(hostname:port)/database?parseTime=true")`},
-			&cli.StringFlag{Name: "fs", Required: true, Usage: "File storage URL"},
+			&cli.StringFlag{Name: "fs", Required: true, Usage: "File storage URL (gs://BUCKET/DIR/, s3://BUCKET/DIR/[?endpoint=URL&region=REGION&path_style=true], http://HOST:PORT/ or file:///DIR)"},
+			&cli.StringFlag{Name: "s3-access-key-id", EnvVars: []string{"FLEX_S3_ACCESS_KEY_ID"}, Usage: "Access key ID for s3:// file storage; the default AWS credential chain is used if unset"},
+			&cli.StringFlag{Name: "s3-secret-access-key", EnvVars: []string{"FLEX_S3_SECRET_ACCESS_KEY"}, Usage: "Secret access key for s3:// file storage"},
+			&cli.StringFlag{Name: "public-url", Usage: "URL at which flexlets and clients reach this flexhub, used for URLs of files stored with file:// and decrypted files (default: http://localhost:PORT/)"},
+			&cli.StringFlag{Name: "url-signing-key", EnvVars: []string{"FLEX_URL_SIGNING_KEY"}, Usage: "Secret key to sign file URLs with, shared by all replicas; required with file:// storage or encryption"},
+			&cli.StringFlag{Name: "encryption-key", EnvVars: []string{"FLEX_ENCRYPTION_KEY"}, Usage: "Base64-encoded 32-byte master key to encrypt stored packages and outputs with; encryption is disabled if unset"},
+			&cli.StringFlag{Name: "encryption-key-file", Usage: "Path to a file containing a master key in the format of --encryption-key"},
 			&cli.StringFlag{Name: "password", Usage: "Protect services with a password"},
-			&cli.StringFlag{Name: "publish", Usage: "PubSub topic ID to publish job events to"},
+			&cli.StringFlag{Name: "publish", Usage: "URL to publish job events to (gcppubsub://PROJECT/TOPIC, nats://HOST:PORT/SUBJECT, redis://HOST:PORT/KEY, http(s)://...); a bare ID is a Cloud Pub/Sub topic"},
+			&cli.StringFlag{Name: "webhook-secret", Usage: "Secret key to sign webhook requests with HMAC-SHA256"},
+			&cli.DurationFlag{Name: "flexlet-timeout", Value: time.Minute, Usage: "Duration without heartbeats after which a flexlet is considered offline"},
+			&cli.DurationFlag{Name: "task-timeout", Value: time.Minute, Usage: "Duration without heartbeats after which a running task is considered lost and its job is retried"},
+			&cli.DurationFlag{Name: "event-poll-interval", Value: time.Second, Usage: "Interval to poll the DB for job events from other flexhub replicas"},
 		},
 		Action: run,
 	}
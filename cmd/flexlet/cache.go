@@ -0,0 +1,73 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/nya3jp/flex/cmd/flexlet/internal/filecache"
+)
+
+var cmdCache = &cli.Command{
+	Name:  "cache",
+	Usage: "Manages the package cache",
+	Subcommands: []*cli.Command{
+		cmdCacheLs,
+		cmdCachePrune,
+	},
+}
+
+var cmdCacheLs = &cli.Command{
+	Name:  "ls",
+	Usage: "Lists cache entries, least recently used first",
+	Action: func(c *cli.Context) error {
+		entries, err := newCacheManager(c).List()
+		if err != nil {
+			return err
+		}
+		var total int64
+		for _, e := range entries {
+			fmt.Printf("%s\t%d\t%s\n", e.Name, e.Size, e.LastAccess.Format(time.RFC3339))
+			total += e.Size
+		}
+		fmt.Printf("Total: %d entries, %d bytes\n", len(entries), total)
+		return nil
+	},
+}
+
+var cmdCachePrune = &cli.Command{
+	Name:  "prune",
+	Usage: "Evicts least recently used cache entries",
+	Flags: []cli.Flag{
+		&cli.Int64Flag{Name: "max-bytes", Usage: "Evicts entries until the cache size is at most this size. Zero evicts all entries, though running tasks can keep reading ones they have opened"},
+	},
+	Action: func(c *cli.Context) error {
+		evicted, err := newCacheManager(c).Prune(c.Int64("max-bytes"))
+		var total int64
+		for _, e := range evicted {
+			total += e.Size
+		}
+		fmt.Printf("Evicted %d entries, %d bytes\n", len(evicted), total)
+		return err
+	},
+}
+
+func newCacheManager(c *cli.Context) *filecache.Manager {
+	return filecache.NewManager(filepath.Join(c.String("storedir"), "cache"), 0)
+}
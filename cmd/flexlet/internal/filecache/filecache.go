@@ -12,82 +12,316 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+// Package filecache manages the cache of files downloaded by flexlets.
+//
+// Each cache entry is a file named after the SHA-256 hash of its key. Entries
+// are written to temporary files first and renamed into place, so an entry
+// file is always complete. A writer holds a flock(2) lock on a lock file next
+// to the entry so that concurrent openers wait for it instead of downloading
+// the same file again. Lock files are left in place until pruning finds them
+// old. The modification time of an entry records its last
+// access, since access times are often not updated by file systems.
+//
+// Evicting an entry just unlinks its file. Readers that have already opened
+// the entry can keep reading it.
 package filecache
 
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"io"
+	"io/ioutil"
+	"log"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"syscall"
+	"time"
+)
+
+// Entry describes a cache entry.
+type Entry struct {
+	// Name is the name of the entry file, i.e. the hex-encoded SHA-256 hash
+	// of the key.
+	Name       string
+	Size       int64
+	LastAccess time.Time
+}
+
+// Stats is statistics of a cache.
+type Stats struct {
+	Entries   int
+	Bytes     int64
+	MaxBytes  int64
+	Hits      int64
+	Misses    int64
+	Evictions int64
+}
+
+const (
+	tempInfix  = ".tmp."
+	lockSuffix = ".lock"
+
+	// staleFileAge is the age of temporary files and lock files after which
+	// they are considered no longer in use.
+	staleFileAge = time.Hour
 )
 
 type Manager struct {
-	dir string
+	dir      string
+	maxBytes int64
+
+	pruneMu sync.Mutex
+
+	mu        sync.Mutex
+	hits      int64
+	misses    int64
+	evictions int64
 }
 
-func NewManager(dir string) *Manager {
-	return &Manager{dir}
+// NewManager returns a manager of the cache in dir. Once the total size of
+// the cache exceeds maxBytes, least recently used entries are evicted. If
+// maxBytes is 0, the cache size is unlimited.
+func NewManager(dir string, maxBytes int64) *Manager {
+	return &Manager{dir: dir, maxBytes: maxBytes}
 }
 
 func (m *Manager) Open(key string, create func(w io.Writer) error) (io.ReadCloser, error) {
-	cachePath := filepath.Join(m.dir, sha256sum(key))
-
-	lock, err := os.OpenFile(cachePath, os.O_RDWR|os.O_CREATE, 0600)
+	f, created, err := m.open(key, create)
 	if err != nil {
 		return nil, err
 	}
+
+	m.mu.Lock()
+	if created {
+		m.misses++
+	} else {
+		m.hits++
+	}
+	m.mu.Unlock()
+
+	if created && m.maxBytes > 0 {
+		if _, err := m.Prune(m.maxBytes); err != nil {
+			log.Printf("WARNING: Pruning the cache failed: %v", err)
+		}
+	}
+	return f, nil
+}
+
+func (m *Manager) open(key string, create func(w io.Writer) error) (f *os.File, created bool, err error) {
+	name := sha256sum(key)
+	cachePath := filepath.Join(m.dir, name)
+
+	if f, err := openEntry(cachePath); !errors.Is(err, os.ErrNotExist) {
+		return f, false, err
+	}
+
+	// Wait for others creating the same entry. The lock only avoids
+	// redundant downloads; concurrent creations are still safe since
+	// entries are renamed into place atomically. Lock files are not removed
+	// here since others may be waiting for them; Prune removes old ones.
+	lockPath := cachePath + lockSuffix
+	lock, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, false, err
+	}
 	defer lock.Close()
 
-	dupFile := func() (*os.File, error) {
-		nfd, err := syscall.Dup(int(lock.Fd()))
+	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_EX); err != nil {
+		return nil, false, err
+	}
+	defer syscall.Flock(int(lock.Fd()), syscall.LOCK_UN)
+
+	if f, err := openEntry(cachePath); !errors.Is(err, os.ErrNotExist) {
+		return f, false, err
+	}
+
+	tmp, err := ioutil.TempFile(m.dir, name+tempInfix)
+	if err != nil {
+		return nil, false, err
+	}
+	defer func() {
 		if err != nil {
-			return nil, err
+			tmp.Close()
+			os.Remove(tmp.Name())
 		}
-		f := os.NewFile(uintptr(nfd), lock.Name())
-		if _, err := f.Seek(0, io.SeekStart); err != nil {
-			f.Close()
-			return nil, err
-		}
-		return f, nil
+	}()
+
+	if err := create(tmp); err != nil {
+		return nil, false, err
+	}
+	if err := tmp.Sync(); err != nil {
+		return nil, false, err
+	}
+	if err := os.Rename(tmp.Name(), cachePath); err != nil {
+		return nil, false, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, false, err
 	}
+	return tmp, true, nil
+}
 
-	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_SH); err != nil {
+// openEntry opens an existing entry and marks it accessed.
+func openEntry(path string) (*os.File, error) {
+	f, err := os.Open(path)
+	if err != nil {
 		return nil, err
 	}
-	defer syscall.Flock(int(lock.Fd()), syscall.LOCK_UN)
-
-	if pos, err := lock.Seek(0, io.SeekEnd); err != nil {
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil && !errors.Is(err, os.ErrNotExist) {
+		f.Close()
 		return nil, err
-	} else if pos > 0 {
-		// TODO: Deal with 0-byte case.
-		return dupFile()
 	}
+	return f, nil
+}
 
-	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_EX); err != nil {
+// Remove evicts the entry of key if it exists.
+func (m *Manager) Remove(key string) error {
+	ok, err := m.evict(sha256sum(key))
+	if err != nil {
+		return err
+	}
+	if ok {
+		m.mu.Lock()
+		m.evictions++
+		m.mu.Unlock()
+	}
+	return nil
+}
+
+// List returns cache entries, least recently used first.
+func (m *Manager) List() ([]*Entry, error) {
+	fis, err := ioutil.ReadDir(m.dir)
+	if err != nil {
 		return nil, err
 	}
 
-	if pos, err := lock.Seek(0, io.SeekEnd); err != nil {
+	var entries []*Entry
+	for _, fi := range fis {
+		if !fi.Mode().IsRegular() || !isEntryName(fi.Name()) {
+			continue
+		}
+		entries = append(entries, &Entry{
+			Name:       fi.Name(),
+			Size:       fi.Size(),
+			LastAccess: fi.ModTime(),
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].LastAccess.Before(entries[j].LastAccess)
+	})
+	return entries, nil
+}
+
+// Prune evicts least recently used entries until the total size of the cache
+// is at most maxBytes. It also removes temporary files left behind by crashed
+// processes and old lock files. It returns evicted entries.
+func (m *Manager) Prune(maxBytes int64) ([]*Entry, error) {
+	m.pruneMu.Lock()
+	defer m.pruneMu.Unlock()
+
+	if err := m.removeStaleFiles(); err != nil {
 		return nil, err
-	} else if pos > 0 {
-		// TODO: Deal with 0-byte case.
-		return dupFile()
 	}
 
-	w, err := dupFile()
+	entries, err := m.List()
 	if err != nil {
 		return nil, err
 	}
-	defer w.Close()
 
-	if err := create(w); err != nil {
-		lock.Truncate(0)
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+	}
+
+	var evicted []*Entry
+	for _, e := range entries {
+		if total <= maxBytes {
+			break
+		}
+		ok, err := m.evict(e.Name)
+		if err != nil {
+			return evicted, err
+		}
+		if !ok {
+			continue
+		}
+		total -= e.Size
+		evicted = append(evicted, e)
+	}
+
+	m.mu.Lock()
+	m.evictions += int64(len(evicted))
+	m.mu.Unlock()
+	return evicted, nil
+}
+
+// evict removes an entry. It returns whether the entry existed.
+func (m *Manager) evict(name string) (bool, error) {
+	if err := os.Remove(filepath.Join(m.dir, name)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// removeStaleFiles removes temporary files left behind by crashed processes
+// and lock files not created recently.
+func (m *Manager) removeStaleFiles() error {
+	fis, err := ioutil.ReadDir(m.dir)
+	if err != nil {
+		return err
+	}
+	for _, fi := range fis {
+		name := fi.Name()
+		if !strings.Contains(name, tempInfix) && !strings.HasSuffix(name, lockSuffix) {
+			continue
+		}
+		if time.Since(fi.ModTime()) < staleFileAge {
+			continue
+		}
+		if err := os.Remove(filepath.Join(m.dir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stats returns statistics of the cache.
+func (m *Manager) Stats() (*Stats, error) {
+	entries, err := m.List()
+	if err != nil {
 		return nil, err
 	}
 
-	return dupFile()
+	m.mu.Lock()
+	stats := &Stats{
+		Entries:   len(entries),
+		MaxBytes:  m.maxBytes,
+		Hits:      m.hits,
+		Misses:    m.misses,
+		Evictions: m.evictions,
+	}
+	m.mu.Unlock()
+
+	for _, e := range entries {
+		stats.Bytes += e.Size
+	}
+	return stats, nil
+}
+
+func isEntryName(name string) bool {
+	if len(name) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(name)
+	return err == nil
 }
 
 func sha256sum(data string) string {
@@ -0,0 +1,197 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filecache_test
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nya3jp/flex/cmd/flexlet/internal/filecache"
+)
+
+func openString(t *testing.T, m *filecache.Manager, key, data string) string {
+	t.Helper()
+	f, err := m.Open(key, func(w io.Writer) error {
+		_, err := io.WriteString(w, data)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Open(%q): %v", key, err)
+	}
+	defer f.Close()
+	b, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("Reading %q: %v", key, err)
+	}
+	return string(b)
+}
+
+// countNonLockFiles returns the number of files other than lock files, which
+// are left in the cache directory.
+func countNonLockFiles(fis []os.FileInfo) int {
+	n := 0
+	for _, fi := range fis {
+		if !strings.HasSuffix(fi.Name(), ".lock") {
+			n++
+		}
+	}
+	return n
+}
+
+func TestManager_Open(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	m := filecache.NewManager(dir, 0)
+
+	if got := openString(t, m, "a", "foo"); got != "foo" {
+		t.Errorf("Open(a) = %q; want %q", got, "foo")
+	}
+	// A cached entry is returned as is.
+	if got := openString(t, m, "a", "bar"); got != "foo" {
+		t.Errorf("Open(a) = %q; want %q", got, "foo")
+	}
+
+	// A failed creation leaves no entry behind.
+	if _, err := m.Open("b", func(w io.Writer) error {
+		io.WriteString(w, "partial")
+		return errors.New("failed")
+	}); err == nil {
+		t.Error("Open(b) succeeded unexpectedly")
+	}
+	if fis, err := ioutil.ReadDir(dir); err != nil {
+		t.Fatal(err)
+	} else if n := countNonLockFiles(fis); n != 1 {
+		t.Errorf("Cache directory has %d files after a failed creation; want 1", n)
+	}
+	if got := openString(t, m, "b", "baz"); got != "baz" {
+		t.Errorf("Open(b) = %q; want %q", got, "baz")
+	}
+
+	stats, err := m.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := filecache.Stats{Entries: 2, Bytes: 6, Hits: 1, Misses: 2}
+	if *stats != want {
+		t.Errorf("Stats() = %+v; want %+v", *stats, want)
+	}
+
+	// An empty entry is valid.
+	if got := openString(t, m, "c", ""); got != "" {
+		t.Errorf("Open(c) = %q; want %q", got, "")
+	}
+	if got := openString(t, m, "c", "qux"); got != "" {
+		t.Errorf("Open(c) = %q; want %q", got, "")
+	}
+
+	// A removed entry is created again.
+	if err := m.Remove("a"); err != nil {
+		t.Fatalf("Remove(a): %v", err)
+	}
+	if got := openString(t, m, "a", "bar"); got != "bar" {
+		t.Errorf("Open(a) = %q; want %q", got, "bar")
+	}
+}
+
+func TestManager_Prune(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	m := filecache.NewManager(dir, 25)
+
+	data := strings.Repeat("x", 10)
+	for _, key := range []string{"a", "b"} {
+		openString(t, m, key, data)
+	}
+
+	// Hold b open, which does not prevent its eviction.
+	fb, err := m.Open("b", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fb.Close()
+
+	// Access a later so that b becomes least recently used.
+	time.Sleep(10 * time.Millisecond)
+	openString(t, m, "a", "")
+
+	// Adding c exceeds the limit and evicts b.
+	openString(t, m, "c", data)
+
+	if b, err := io.ReadAll(fb); err != nil || string(b) != data {
+		t.Errorf("Reading evicted b = %q, %v; want %q", string(b), err, data)
+	}
+	if got := openString(t, m, "a", ""); got != data {
+		t.Errorf("Open(a) = %q; want %q", got, data)
+	}
+	if got := openString(t, m, "b", "new"); got != "new" {
+		t.Errorf("Open(b) = %q; want %q", got, "new")
+	}
+
+	stats, err := m.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Evictions != 1 {
+		t.Errorf("Evictions = %d; want 1", stats.Evictions)
+	}
+
+	evicted, err := m.Prune(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(evicted) != 3 {
+		t.Errorf("Prune(0) evicted %d entries; want 3", len(evicted))
+	}
+	if entries, err := m.List(); err != nil || len(entries) != 0 {
+		t.Errorf("List() = %d entries, %v; want none", len(entries), err)
+	}
+
+	// Lock files are removed once they are old.
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fis) == 0 {
+		t.Fatal("Lock files were removed too early")
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	for _, fi := range fis {
+		if err := os.Chtimes(filepath.Join(dir, fi.Name()), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := m.Prune(0); err != nil {
+		t.Fatal(err)
+	}
+	if fis, err := ioutil.ReadDir(dir); err != nil {
+		t.Fatal(err)
+	} else if len(fis) != 0 {
+		t.Errorf("Cache directory has %d files after pruning old lock files; want 0", len(fis))
+	}
+}
@@ -18,12 +18,14 @@ import (
 	"context"
 	"errors"
 	"log"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/cmd/flexlet/internal/hostinfo"
 	"github.com/nya3jp/flex/cmd/flexlet/internal/run"
 	"github.com/nya3jp/flex/internal/concurrent"
 	"github.com/nya3jp/flex/internal/ctxutil"
@@ -32,46 +34,102 @@ import (
 
 var ErrNoPendingTask = errors.New("no pending task")
 
-func Run(ctx context.Context, cl flexletpb.FlexletServiceClient, runner *run.Runner, name string, cores int) error {
+var errLeaseLost = errors.New("lease lost")
+
+// Run takes and runs tasks, up to cores at a time, until ctx is canceled or
+// draining completes. Draining starts when drain is closed or flexhub requests
+// it. A draining flexlet stops taking new tasks and waits for running tasks up
+// to drainTimeout. Tasks that could not finish are returned to flexhub for
+// retry.
+func Run(ctx context.Context, cl flexletpb.FlexletServiceClient, runner *run.Runner, mon *hostinfo.Monitor, name string, cores int, drain <-chan struct{}, drainTimeout time.Duration) error {
 	limiter := concurrent.NewLimiter(cores)
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	go runFlexletUpdater(ctx, cl, &flex.Flexlet{Name: name, Spec: &flex.FlexletSpec{Cores: int32(cores)}})
+	d := newDrainer()
+	go func() {
+		select {
+		case <-drain:
+			d.Drain()
+		case <-ctx.Done():
+		}
+	}()
+
+	flexlet := &flex.Flexlet{Name: name, Spec: &flex.FlexletSpec{Cores: int32(cores)}}
+	go runFlexletUpdater(ctx, cl, flexlet, d, mon)
 
 	log.Printf("INFO: Flexlet start")
 
+	// taskCtx is canceled when draining times out.
+	taskCtx, taskCancel := context.WithCancel(ctx)
+	defer taskCancel()
+
+	// waitCtx is canceled when draining starts.
+	waitCtx, waitCancel := context.WithCancel(ctx)
+	defer waitCancel()
+	go func() {
+		select {
+		case <-d.Done():
+			waitCancel()
+		case <-waitCtx.Done():
+		}
+	}()
+
+	var wg sync.WaitGroup
 	for {
-		if err := limiter.Take(ctx); err != nil {
-			return err
+		if err := limiter.Take(waitCtx); err != nil {
+			break
 		}
 
-		task, err := waitTaskWithRetry(ctx, cl, name)
+		task, err := waitTaskWithRetry(waitCtx, cl, name)
 		if err != nil {
 			limiter.Done()
-			return err
+			break
 		}
 
+		wg.Add(1)
 		go func() {
+			defer wg.Done()
 			defer limiter.Done()
+			runTask(taskCtx, cl, runner, task)
+		}()
+	}
 
-			ctx, cancel := context.WithCancel(ctx)
-			defer cancel()
+	if err := ctx.Err(); err != nil {
+		wg.Wait()
+		return err
+	}
 
-			go runTaskUpdater(ctx, cl, task.GetRef())
+	log.Printf("INFO: Draining: waiting for running tasks up to %v", drainTimeout)
 
-			log.Printf("INFO: Start task %s for job %d", task.GetRef().GetTaskId(), task.GetRef().GetJobId())
-			result := runner.RunTask(ctx, task.GetSpec())
-			log.Printf("INFO: End task %s for job %d", task.GetRef().GetTaskId(), task.GetRef().GetJobId())
-			if _, err := cl.FinishTask(ctx, &flexletpb.FinishTaskRequest{Ref: task.GetRef(), Result: result}); err != nil {
-				log.Printf("WARNING: FinishTask failed: %v", err)
-			}
-		}()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(drainTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+	case <-timer.C:
+		log.Printf("WARNING: Draining timed out; aborting running tasks")
+		taskCancel()
+		<-done
+	case <-ctx.Done():
+		<-done
 	}
+	err := ctx.Err()
+	cancel()
+
+	reportOffline(cl, flexlet)
+	log.Printf("INFO: Flexlet drained")
+	return err
 }
 
-func RunOneOff(ctx context.Context, cl flexletpb.FlexletServiceClient, runner *run.Runner, name string, cores int) (*flexletpb.Task, *flex.TaskResult, error) {
+func RunOneOff(ctx context.Context, cl flexletpb.FlexletServiceClient, runner *run.Runner, mon *hostinfo.Monitor, name string, cores int) (*flexletpb.Task, *flex.TaskResult, error) {
 	task, err := takeTask(ctx, cl, name)
 	if s, ok := status.FromError(err); ok && s.Code() == codes.NotFound {
 		return nil, nil, ErrNoPendingTask
@@ -83,16 +141,167 @@ func RunOneOff(ctx context.Context, cl flexletpb.FlexletServiceClient, runner *r
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	go runFlexletUpdater(ctx, cl, &flex.Flexlet{Name: name, Spec: &flex.FlexletSpec{Cores: int32(cores)}})
-	go runTaskUpdater(ctx, cl, task.GetRef())
+	go runFlexletUpdater(ctx, cl, &flex.Flexlet{Name: name, Spec: &flex.FlexletSpec{Cores: int32(cores)}}, newDrainer(), mon)
+
+	result := runTask(ctx, cl, runner, task)
+	return task, result, nil
+}
+
+// DrainSummary summarizes tasks run by RunDrain.
+type DrainSummary struct {
+	Jobs []*DrainedJob `json:"jobs"`
+}
+
+type DrainedJob struct {
+	JobID    int64   `json:"job_id"`
+	TaskID   string  `json:"task_id"`
+	ExitCode int32   `json:"exit_code"`
+	Message  string  `json:"message,omitempty"`
+	Seconds  float64 `json:"seconds"`
+}
+
+// RunDrain takes and runs tasks, up to cores at a time, until no pending task
+// is left, deadline passes, drain is closed or flexhub requests draining.
+// After draining starts, running tasks are allowed to finish until deadline.
+// Tasks still running at deadline are aborted and returned to flexhub for
+// retry. It waits for running tasks to finish and marks the flexlet offline
+// before returning, so callers must not run RunDrain concurrently with the
+// same name.
+func RunDrain(ctx context.Context, cl flexletpb.FlexletServiceClient, runner *run.Runner, mon *hostinfo.Monitor, name string, cores int, deadline time.Time, drain <-chan struct{}) (*DrainSummary, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	d := newDrainer()
+	go func() {
+		select {
+		case <-drain:
+			d.Drain()
+		case <-ctx.Done():
+		}
+	}()
+
+	flexlet := &flex.Flexlet{Name: name, Spec: &flex.FlexletSpec{Cores: int32(cores)}}
+	go runFlexletUpdater(ctx, cl, flexlet, d, mon)
+
+	log.Printf("INFO: Drain start")
+
+	// taskCtx is canceled when deadline passes.
+	taskCtx, taskCancel := context.WithDeadline(ctx, deadline)
+	defer taskCancel()
+
+	// takeCtx is canceled also when draining starts.
+	takeCtx, takeCancel := context.WithCancel(taskCtx)
+	defer takeCancel()
+	go func() {
+		select {
+		case <-d.Done():
+			takeCancel()
+		case <-takeCtx.Done():
+		}
+	}()
+
+	limiter := concurrent.NewLimiter(cores)
+	summary := &DrainSummary{Jobs: []*DrainedJob{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	err := func() error {
+		for !d.Draining() {
+			if err := limiter.Take(takeCtx); err != nil {
+				return ctx.Err()
+			}
+
+			task, err := takeTask(takeCtx, cl, name)
+			if s, ok := status.FromError(err); ok && s.Code() == codes.NotFound {
+				limiter.Done()
+				return nil
+			}
+			if err != nil {
+				limiter.Done()
+				if takeCtx.Err() != nil {
+					return ctx.Err()
+				}
+				return err
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer limiter.Done()
+
+				result := runTask(taskCtx, cl, runner, task)
+
+				mu.Lock()
+				defer mu.Unlock()
+				summary.Jobs = append(summary.Jobs, &DrainedJob{
+					JobID:    task.GetRef().GetJobId(),
+					TaskID:   task.GetRef().GetTaskId(),
+					ExitCode: result.GetExitCode(),
+					Message:  result.GetMessage(),
+					Seconds:  result.GetTime().AsDuration().Seconds(),
+				})
+			}()
+		}
+		return nil
+	}()
+	wg.Wait()
+	cancel()
+
+	log.Printf("INFO: Drain end: ran %d tasks", len(summary.Jobs))
+
+	// Mark the flexlet offline so that its cores are not counted as idle.
+	reportOffline(cl, flexlet)
+	return summary, err
+}
+
+func runTask(ctx context.Context, cl flexletpb.FlexletServiceClient, runner *run.Runner, task *flexletpb.Task) *flex.TaskResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// leaseLost is closed when flexhub reports that the task no longer owns
+	// its job, e.g. because the job was requeued to another flexlet while we
+	// were unreachable. The task is then aborted and its outputs discarded.
+	leaseLost := make(chan struct{})
+	go func() {
+		if err := runTaskUpdater(ctx, cl, task.GetRef()); errors.Is(err, errLeaseLost) {
+			close(leaseLost)
+			cancel()
+		}
+	}()
 
 	log.Printf("INFO: Start task %s for job %d", task.GetRef().GetTaskId(), task.GetRef().GetJobId())
 	result := runner.RunTask(ctx, task.GetSpec())
 	log.Printf("INFO: End task %s for job %d", task.GetRef().GetTaskId(), task.GetRef().GetJobId())
-	if _, err := cl.FinishTask(ctx, &flexletpb.FinishTaskRequest{Ref: task.GetRef(), Result: result}); err != nil {
+
+	select {
+	case <-leaseLost:
+		log.Printf("WARNING: Task %s for job %d lost its lease; result discarded", task.GetRef().GetTaskId(), task.GetRef().GetJobId())
+		return result
+	default:
+	}
+
+	if ctx.Err() != nil {
+		// The task was aborted. Return the job to flexhub so that it is
+		// retried without waiting for the task to time out.
+		finishCtx, finishCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer finishCancel()
+		finishTask(finishCtx, cl, &flexletpb.FinishTaskRequest{Ref: task.GetRef(), Result: result, NeedRetry: true})
+		return result
+	}
+
+	finishTask(ctx, cl, &flexletpb.FinishTaskRequest{Ref: task.GetRef(), Result: result})
+	return result
+}
+
+func finishTask(ctx context.Context, cl flexletpb.FlexletServiceClient, req *flexletpb.FinishTaskRequest) {
+	res, err := cl.FinishTask(ctx, req)
+	if err != nil {
 		log.Printf("WARNING: FinishTask failed: %v", err)
+		return
+	}
+	if res.GetLeaseLost() {
+		log.Printf("WARNING: Task %s for job %d lost its lease; result discarded by flexhub", req.GetRef().GetTaskId(), req.GetRef().GetJobId())
 	}
-	return task, result, nil
 }
 
 func waitTaskWithRetry(ctx context.Context, cl flexletpb.FlexletServiceClient, flexletName string) (*flexletpb.Task, error) {
@@ -132,28 +341,93 @@ func takeTask(ctx context.Context, cl flexletpb.FlexletServiceClient, flexletNam
 	return res.GetTask(), nil
 }
 
-func runFlexletUpdater(ctx context.Context, cl flexletpb.FlexletServiceClient, flexlet *flex.Flexlet) error {
+func runFlexletUpdater(ctx context.Context, cl flexletpb.FlexletServiceClient, flexlet *flex.Flexlet, d *drainer, mon *hostinfo.Monitor) error {
+	drainCh := d.Done()
 	for {
+		state := flex.FlexletState_ONLINE
+		if d.Draining() {
+			state = flex.FlexletState_DRAINING
+			drainCh = nil
+		}
 		status := &flex.FlexletStatus{
 			Flexlet: flexlet,
-			State:   flex.FlexletState_ONLINE,
+			State:   state,
+			Host:    mon.HostInfo(),
 		}
-		if _, err := cl.UpdateFlexlet(ctx, &flexletpb.UpdateFlexletRequest{Status: status}); err != nil && ctx.Err() == nil {
+		if err := mon.Health(); err != nil {
+			status.Unhealthy = true
+			status.HealthError = err.Error()
+		}
+		res, err := cl.UpdateFlexlet(ctx, &flexletpb.UpdateFlexletRequest{Status: status})
+		if err != nil && ctx.Err() == nil {
 			log.Printf("WARNING: UpdateTasklet failed: %v", err)
 		}
-		if err := ctxutil.Sleep(ctx, 10*time.Second); err != nil {
-			return err
+		if res.GetDrain() && !d.Draining() {
+			log.Printf("INFO: Draining requested by flexhub")
+			d.Drain()
+		}
+		// Report draining as soon as it starts.
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Second):
+		case <-drainCh:
 		}
 	}
 }
 
+func reportOffline(cl flexletpb.FlexletServiceClient, flexlet *flex.Flexlet) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	status := &flex.FlexletStatus{
+		Flexlet: flexlet,
+		State:   flex.FlexletState_OFFLINE,
+	}
+	if _, err := cl.UpdateFlexlet(ctx, &flexletpb.UpdateFlexletRequest{Status: status}); err != nil {
+		log.Printf("WARNING: UpdateFlexlet failed: %v", err)
+	}
+}
+
+// runTaskUpdater sends heartbeats of a running task until ctx is canceled. It
+// returns errLeaseLost if flexhub reports that the task lost its lease.
 func runTaskUpdater(ctx context.Context, cl flexletpb.FlexletServiceClient, ref *flexletpb.TaskRef) error {
 	for {
-		if _, err := cl.UpdateTask(ctx, &flexletpb.UpdateTaskRequest{Ref: ref}); err != nil && ctx.Err() == nil {
+		res, err := cl.UpdateTask(ctx, &flexletpb.UpdateTaskRequest{Ref: ref})
+		if err != nil && ctx.Err() == nil {
 			log.Printf("WARNING: UpdateTask failed: %v", err)
 		}
+		if res.GetLeaseLost() {
+			return errLeaseLost
+		}
 		if err := ctxutil.Sleep(ctx, 10*time.Second); err != nil {
 			return err
 		}
 	}
 }
+
+// drainer tracks whether a flexlet is draining.
+type drainer struct {
+	once sync.Once
+	ch   chan struct{}
+}
+
+func newDrainer() *drainer {
+	return &drainer{ch: make(chan struct{})}
+}
+
+func (d *drainer) Drain() {
+	d.once.Do(func() { close(d.ch) })
+}
+
+func (d *drainer) Done() <-chan struct{} {
+	return d.ch
+}
+
+func (d *drainer) Draining() bool {
+	select {
+	case <-d.ch:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flexlet_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/cmd/flexlet/internal/flexlet"
+	"github.com/nya3jp/flex/cmd/flexlet/internal/hostinfo"
+	"github.com/nya3jp/flex/cmd/flexlet/internal/run"
+	"github.com/nya3jp/flex/internal/flexletpb"
+)
+
+// fakeHub is a fake flexhub that hands out a single task and can simulate a
+// network partition by reporting that the task lost its lease.
+type fakeHub struct {
+	task      *flexletpb.Task
+	leaseLost bool
+
+	mu       sync.Mutex
+	takes    int
+	finishes []*flexletpb.FinishTaskRequest
+}
+
+func (h *fakeHub) TakeTask(ctx context.Context, req *flexletpb.TakeTaskRequest, opts ...grpc.CallOption) (*flexletpb.TakeTaskResponse, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.takes++
+	return &flexletpb.TakeTaskResponse{Task: h.task}, nil
+}
+
+func (h *fakeHub) UpdateTask(ctx context.Context, req *flexletpb.UpdateTaskRequest, opts ...grpc.CallOption) (*flexletpb.UpdateTaskResponse, error) {
+	return &flexletpb.UpdateTaskResponse{LeaseLost: h.leaseLost}, nil
+}
+
+func (h *fakeHub) FinishTask(ctx context.Context, req *flexletpb.FinishTaskRequest, opts ...grpc.CallOption) (*flexletpb.FinishTaskResponse, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.finishes = append(h.finishes, req)
+	return &flexletpb.FinishTaskResponse{LeaseLost: h.leaseLost}, nil
+}
+
+func (h *fakeHub) UpdateFlexlet(ctx context.Context, req *flexletpb.UpdateFlexletRequest, opts ...grpc.CallOption) (*flexletpb.UpdateFlexletResponse, error) {
+	return &flexletpb.UpdateFlexletResponse{}, nil
+}
+
+func (h *fakeHub) Takes() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.takes
+}
+
+func (h *fakeHub) Finishes() []*flexletpb.FinishTaskRequest {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]*flexletpb.FinishTaskRequest(nil), h.finishes...)
+}
+
+func TestRunOneOff_LeaseLost(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	runner, err := run.New(tempDir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mon := hostinfo.NewMonitor(tempDir, "", runner.Cache())
+
+	var mu sync.Mutex
+	uploads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		uploads++
+	}))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		name      string
+		args      []string
+		leaseLost bool
+	}{
+		{name: "kept", args: []string{"true"}},
+		// The flexlet is partitioned away and the job is requeued to another
+		// flexlet. The orphaned process must be killed immediately.
+		{name: "lost", args: []string{"sleep", "60"}, leaseLost: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mu.Lock()
+			uploads = 0
+			mu.Unlock()
+
+			hub := &fakeHub{
+				task: &flexletpb.Task{
+					Ref: &flexletpb.TaskRef{TaskId: "task", JobId: 42},
+					Spec: &flexletpb.TaskSpec{
+						Command: &flex.JobCommand{Args: tc.args},
+						Outputs: &flexletpb.TaskOutputs{
+							Stdout: &flex.FileLocation{CanonicalUrl: server.URL + "/stdout", PresignedUrl: server.URL + "/stdout"},
+							Stderr: &flex.FileLocation{CanonicalUrl: server.URL + "/stderr", PresignedUrl: server.URL + "/stderr"},
+						},
+						Limits: &flex.JobLimits{Time: durationpb.New(time.Minute)},
+					},
+				},
+				leaseLost: tc.leaseLost,
+			}
+
+			start := time.Now()
+			if _, _, err := flexlet.RunOneOff(context.Background(), hub, runner, mon, "flexlet", 1); err != nil {
+				t.Fatalf("RunOneOff: %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > 30*time.Second {
+				t.Errorf("RunOneOff took %v; want the task to be killed", elapsed)
+			}
+
+			mu.Lock()
+			gotUploads := uploads
+			mu.Unlock()
+			finishes := hub.Finishes()
+
+			if tc.leaseLost {
+				if gotUploads != 0 {
+					t.Errorf("Got %d uploads; want outputs discarded", gotUploads)
+				}
+				if len(finishes) != 0 {
+					t.Errorf("FinishTask called %d times; want no calls", len(finishes))
+				}
+			} else {
+				if gotUploads != 2 {
+					t.Errorf("Got %d uploads; want 2", gotUploads)
+				}
+				if len(finishes) != 1 || finishes[0].GetNeedRetry() {
+					t.Errorf("FinishTask calls = %v; want one call without retry", finishes)
+				}
+			}
+		})
+	}
+}
+
+func TestRunDrain_Deadline(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	runner, err := run.New(tempDir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mon := hostinfo.NewMonitor(tempDir, "", runner.Cache())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	hub := &fakeHub{
+		task: &flexletpb.Task{
+			Ref: &flexletpb.TaskRef{TaskId: "task", JobId: 42},
+			Spec: &flexletpb.TaskSpec{
+				Command: &flex.JobCommand{Args: []string{"sleep", "60"}},
+				Outputs: &flexletpb.TaskOutputs{
+					Stdout: &flex.FileLocation{CanonicalUrl: server.URL + "/stdout", PresignedUrl: server.URL + "/stdout"},
+					Stderr: &flex.FileLocation{CanonicalUrl: server.URL + "/stderr", PresignedUrl: server.URL + "/stderr"},
+				},
+				Limits: &flex.JobLimits{Time: durationpb.New(time.Minute)},
+			},
+		},
+	}
+
+	// A task taken before the deadline must not run past it.
+	start := time.Now()
+	summary, err := flexlet.RunDrain(context.Background(), hub, runner, mon, "flexlet", 1, start.Add(time.Second), nil)
+	if err != nil {
+		t.Fatalf("RunDrain: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 30*time.Second {
+		t.Errorf("RunDrain took %v; want the task to be aborted at the deadline", elapsed)
+	}
+	if n := len(summary.Jobs); n != 1 {
+		t.Errorf("RunDrain ran %d tasks; want 1", n)
+	}
+
+	finishes := hub.Finishes()
+	if len(finishes) != 1 {
+		t.Fatalf("Got %d FinishTask calls; want 1", len(finishes))
+	}
+	if !finishes[0].GetNeedRetry() {
+		t.Error("FinishTask: NeedRetry = false; want true")
+	}
+}
+
+func TestRunDrain_Drain(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	runner, err := run.New(tempDir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mon := hostinfo.NewMonitor(tempDir, "", runner.Cache())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	hub := &fakeHub{
+		task: &flexletpb.Task{
+			Ref: &flexletpb.TaskRef{TaskId: "task", JobId: 42},
+			Spec: &flexletpb.TaskSpec{
+				Command: &flex.JobCommand{Args: []string{"sleep", "1"}},
+				Outputs: &flexletpb.TaskOutputs{
+					Stdout: &flex.FileLocation{CanonicalUrl: server.URL + "/stdout", PresignedUrl: server.URL + "/stdout"},
+					Stderr: &flex.FileLocation{CanonicalUrl: server.URL + "/stderr", PresignedUrl: server.URL + "/stderr"},
+				},
+				Limits: &flex.JobLimits{Time: durationpb.New(time.Minute)},
+			},
+		},
+	}
+
+	// Start draining while the first task is running. The task finishes,
+	// but no more tasks are taken even though the queue is never empty.
+	drain := make(chan struct{})
+	time.AfterFunc(100*time.Millisecond, func() { close(drain) })
+
+	start := time.Now()
+	summary, err := flexlet.RunDrain(context.Background(), hub, runner, mon, "flexlet", 1, start.Add(time.Minute), drain)
+	if err != nil {
+		t.Fatalf("RunDrain: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 30*time.Second {
+		t.Errorf("RunDrain took %v; want it to stop on draining", elapsed)
+	}
+	if n := hub.Takes(); n != 1 {
+		t.Errorf("Got %d TakeTask calls; want 1", n)
+	}
+	if n := len(summary.Jobs); n != 1 {
+		t.Errorf("RunDrain ran %d tasks; want 1", n)
+	}
+
+	finishes := hub.Finishes()
+	if len(finishes) != 1 {
+		t.Fatalf("Got %d FinishTask calls; want 1", len(finishes))
+	}
+	if finishes[0].GetNeedRetry() {
+		t.Error("FinishTask: NeedRetry = true; want the task to finish")
+	}
+}
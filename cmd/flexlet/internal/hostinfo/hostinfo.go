@@ -0,0 +1,159 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostinfo
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os/exec"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/cmd/flexlet/internal/filecache"
+)
+
+// healthCheckTimeout is the maximum duration a health check command may run.
+const healthCheckTimeout = time.Minute
+
+// Monitor collects host information and runs health checks reported in
+// flexlet heartbeats.
+type Monitor struct {
+	storeDir    string
+	healthCheck string
+	cache       *filecache.Manager
+	started     time.Time
+
+	mu        sync.Mutex
+	healthErr error
+}
+
+// NewMonitor creates a Monitor. healthCheck is a shell command whose failure
+// marks the flexlet unhealthy. It can be empty to disable health checks.
+// cache is the package cache to report statistics of.
+func NewMonitor(storeDir, healthCheck string, cache *filecache.Manager) *Monitor {
+	return &Monitor{
+		storeDir:    storeDir,
+		healthCheck: healthCheck,
+		cache:       cache,
+		started:     time.Now(),
+	}
+}
+
+// Run runs health checks periodically until ctx is canceled.
+func (m *Monitor) Run(ctx context.Context, interval time.Duration) error {
+	if m.healthCheck == "" {
+		return nil
+	}
+	for {
+		err := m.runHealthCheck(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		m.mu.Lock()
+		prevErr := m.healthErr
+		m.healthErr = err
+		m.mu.Unlock()
+
+		if err != nil && prevErr == nil {
+			log.Printf("WARNING: Health check failed: %v", err)
+		} else if err == nil && prevErr != nil {
+			log.Printf("INFO: Health check passed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (m *Monitor) runHealthCheck(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "/bin/sh", "-c", m.healthCheck).CombinedOutput()
+	if err != nil {
+		msg := strings.TrimSpace(string(out))
+		if len(msg) > 256 {
+			msg = msg[len(msg)-256:]
+		}
+		if msg == "" {
+			return err
+		}
+		return fmt.Errorf("%w: %s", err, msg)
+	}
+	return nil
+}
+
+// Health returns the error of the last health check.
+func (m *Monitor) Health() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.healthErr
+}
+
+// HostInfo collects the current host information. Errors are logged and
+// corresponding fields are left unset.
+func (m *Monitor) HostInfo() *flex.FlexletHostInfo {
+	info := &flex.FlexletHostInfo{
+		Version: version(),
+		Os:      runtime.GOOS + "/" + runtime.GOARCH,
+		Uptime:  durationpb.New(time.Since(m.started)),
+	}
+
+	if err := fillSysInfo(info); err != nil {
+		log.Printf("WARNING: Failed to get system info: %v", err)
+	}
+
+	var fs unix.Statfs_t
+	if err := unix.Statfs(m.storeDir, &fs); err != nil {
+		log.Printf("WARNING: statfs: %v", err)
+	} else {
+		info.DiskFreeBytes = int64(fs.Bavail) * int64(fs.Bsize)
+	}
+
+	stats, err := m.cache.Stats()
+	if err != nil {
+		log.Printf("WARNING: Failed to get cache stats: %v", err)
+	} else {
+		info.CacheBytes = stats.Bytes
+		info.Cache = &flex.FlexletCacheStats{
+			Entries:   int32(stats.Entries),
+			MaxBytes:  stats.MaxBytes,
+			Hits:      stats.Hits,
+			Misses:    stats.Misses,
+			Evictions: stats.Evictions,
+		}
+	}
+	return info
+}
+
+func version() string {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	return bi.Main.Version
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostinfo
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/nya3jp/flex"
+)
+
+func fillSysInfo(info *flex.FlexletHostInfo) error {
+	var uts unix.Utsname
+	if err := unix.Uname(&uts); err != nil {
+		return err
+	}
+	info.Kernel = unix.ByteSliceToString(uts.Release[:])
+
+	var si unix.Sysinfo_t
+	if err := unix.Sysinfo(&si); err != nil {
+		return err
+	}
+	for _, load := range si.Loads {
+		info.LoadAverage = append(info.LoadAverage, float64(load)/float64(1<<unix.SI_LOAD_SHIFT))
+	}
+	unit := int64(si.Unit)
+	info.TotalMemoryBytes = int64(si.Totalram) * unit
+	info.FreeMemoryBytes = int64(si.Freeram) * unit
+
+	// MemAvailable in /proc/meminfo is a better estimate of free memory as
+	// it includes reclaimable caches.
+	if avail, err := memAvailable(); err == nil {
+		info.FreeMemoryBytes = avail
+	}
+	return nil
+}
+
+func memAvailable() (int64, error) {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) >= 2 && fields[0] == "MemAvailable:" {
+			kb, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			return kb * 1024, nil
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return 0, err
+	}
+	return 0, os.ErrNotExist
+}
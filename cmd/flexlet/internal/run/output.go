@@ -0,0 +1,194 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package run
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// cappedWriter writes job output to a file, keeping it within a size limit.
+//
+// Once more than max bytes are written, it keeps the first half of max bytes
+// and the last half. The file region after the first half is used as a ring
+// buffer of the last half, which finish rearranges and joins to the first
+// half with a truncation marker. The file therefore never grows beyond max
+// bytes while the job runs.
+type cappedWriter struct {
+	f          *os.File
+	max        int64 // 0 means no limit
+	head       int64
+	written    int64
+	overflow   func()
+	overflowed bool
+}
+
+// newCappedWriter returns a writer to f limited to max bytes. overflow is
+// called once when the limit is exceeded.
+func newCappedWriter(f *os.File, max int64, overflow func()) *cappedWriter {
+	return &cappedWriter{
+		f:        f,
+		max:      max,
+		head:     max / 2,
+		overflow: overflow,
+	}
+}
+
+func (w *cappedWriter) Write(p []byte) (int, error) {
+	n := len(p)
+
+	// Write as much as fits in the file as is.
+	if w.max <= 0 || w.written < w.max {
+		k := int64(len(p))
+		if w.max > 0 && w.written+k > w.max {
+			k = w.max - w.written
+		}
+		if _, err := w.f.WriteAt(p[:k], w.written); err != nil {
+			return 0, err
+		}
+		w.written += k
+		p = p[k:]
+		if len(p) == 0 {
+			return n, nil
+		}
+	}
+
+	if !w.overflowed {
+		w.overflowed = true
+		if w.overflow != nil {
+			w.overflow()
+		}
+	}
+
+	// Only the last tail bytes of p can survive.
+	tail := w.max - w.head
+	if int64(len(p)) > tail {
+		skip := int64(len(p)) - tail
+		w.written += skip
+		p = p[skip:]
+	}
+	for len(p) > 0 {
+		off := (w.written - w.head) % tail
+		chunk := p
+		if int64(len(chunk)) > tail-off {
+			chunk = chunk[:tail-off]
+		}
+		if _, err := w.f.WriteAt(chunk, w.head+off); err != nil {
+			return 0, err
+		}
+		w.written += int64(len(chunk))
+		p = p[len(chunk):]
+	}
+	return n, nil
+}
+
+// size returns the number of bytes written to w, including truncated ones.
+func (w *cappedWriter) size() int64 {
+	return w.written
+}
+
+// truncated returns whether the output has been truncated.
+func (w *cappedWriter) truncated() bool {
+	return w.overflowed
+}
+
+// finish rearranges the file so that it contains the first and last halves of
+// the output joined with a truncation marker. It must be called after all
+// writes.
+func (w *cappedWriter) finish() error {
+	if !w.overflowed {
+		return nil
+	}
+
+	tail := w.max - w.head
+	start := (w.written - w.head) % tail
+
+	// Copy the ring buffer out in order, then append it after the marker.
+	tmp, err := os.CreateTemp("", "flexlet.output.")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := io.Copy(tmp, io.NewSectionReader(w.f, w.head+start, tail-start)); err != nil {
+		return err
+	}
+	if _, err := io.Copy(tmp, io.NewSectionReader(w.f, w.head, start)); err != nil {
+		return err
+	}
+
+	if err := w.f.Truncate(w.head); err != nil {
+		return err
+	}
+	marker := fmt.Sprintf("\n[flex: output truncated; %d bytes omitted]\n", w.written-w.max)
+	if _, err := w.f.WriteAt([]byte(marker), w.head); err != nil {
+		return err
+	}
+	if _, err := w.f.Seek(w.head+int64(len(marker)), io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, err = io.Copy(w.f, tmp)
+	return err
+}
+
+// outputPipe copies output of a child process to a writer. Unlike passing
+// a writer to exec.Cmd directly, waiting for the copy to finish can time out
+// when a process that escaped the process group keeps the pipe open.
+type outputPipe struct {
+	r, w *os.File
+	done chan struct{}
+	err  error
+}
+
+func newOutputPipe(dst io.Writer) (*outputPipe, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	p := &outputPipe{r: r, w: w, done: make(chan struct{})}
+	go func() {
+		defer close(p.done)
+		_, p.err = io.Copy(dst, r)
+	}()
+	return p, nil
+}
+
+// closeWriter closes the write end of the pipe. It should be called once the
+// child process has started.
+func (p *outputPipe) closeWriter() {
+	p.w.Close()
+}
+
+// wait waits for the copy to finish for up to timeout, and closes the pipe.
+func (p *outputPipe) wait(timeout time.Duration) error {
+	p.w.Close()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-p.done:
+	case <-timer.C:
+		p.r.Close()
+		<-p.done
+		return nil
+	}
+	p.r.Close()
+	return p.err
+}
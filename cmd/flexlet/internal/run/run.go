@@ -15,6 +15,7 @@
 package run
 
 import (
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
@@ -34,7 +35,10 @@ import (
 
 	"github.com/nya3jp/flex"
 	"github.com/nya3jp/flex/cmd/flexlet/internal/filecache"
+	"github.com/nya3jp/flex/internal/envelope"
 	"github.com/nya3jp/flex/internal/flexletpb"
+	"github.com/nya3jp/flex/internal/hashutil"
+	"github.com/nya3jp/flex/internal/joblog"
 )
 
 type Runner struct {
@@ -42,7 +46,9 @@ type Runner struct {
 	cache    *filecache.Manager
 }
 
-func New(storeDir string) (*Runner, error) {
+// New creates a Runner storing files under storeDir. maxCacheBytes limits the
+// size of the package cache; 0 means no limit.
+func New(storeDir string, maxCacheBytes int64) (*Runner, error) {
 	if err := os.MkdirAll(storeDir, 0700); err != nil {
 		return nil, err
 	}
@@ -55,13 +61,18 @@ func New(storeDir string) (*Runner, error) {
 		}
 	}
 
-	cache := filecache.NewManager(cacheDir)
+	cache := filecache.NewManager(cacheDir, maxCacheBytes)
 	return &Runner{
 		tasksDir: tasksDir,
 		cache:    cache,
 	}, nil
 }
 
+// Cache returns the package cache used by r.
+func (r *Runner) Cache() *filecache.Manager {
+	return r.cache
+}
+
 func (r *Runner) RunTask(ctx context.Context, spec *flexletpb.TaskSpec) *flex.TaskResult {
 	taskDir, err := ioutil.TempDir(r.tasksDir, "")
 	if err != nil {
@@ -108,21 +119,84 @@ func (r *Runner) RunTask(ctx context.Context, spec *flexletpb.TaskSpec) *flex.Ta
 	}
 	defer stderr.Close()
 
+	limits := spec.GetLimits()
+
+	// Kill the job on output overflow if requested. Use a separate context so
+	// that it is not mistaken for an aborted task.
+	execCtx, cancelExec := context.WithCancel(ctx)
+	defer cancelExec()
+	var overflow func()
+	if limits.GetKillOnOutputOverflow() {
+		overflow = cancelExec
+	}
+	stdoutWriter := newCappedWriter(stdout, limits.GetMaxOutputBytes(), overflow)
+	stderrWriter := newCappedWriter(stderr, limits.GetMaxOutputBytes(), overflow)
+	cappedWriters := []*cappedWriter{stdoutWriter, stderrWriter}
+
+	var stdoutSink, stderrSink io.Writer = stdoutWriter, stderrWriter
+	var logStreams []io.WriteCloser
+	var logFile *os.File
+	if spec.GetOutputs().GetLog() != nil {
+		logFile, err = os.Create(filepath.Join(taskDir, "log.txt"))
+		if err != nil {
+			return &flex.TaskResult{
+				ExitCode: -1,
+				Message:  fmt.Sprintf("failed to prepare task log: %v", err),
+			}
+		}
+		defer logFile.Close()
+
+		// The log holds both stdout and stderr, so allow it twice the size of
+		// each. It never kills the job by itself.
+		logWriter := newCappedWriter(logFile, 2*limits.GetMaxOutputBytes(), nil)
+		cappedWriters = append(cappedWriters, logWriter)
+		logger := joblog.NewLogger(logWriter)
+		stdoutLog := logger.Stream(joblog.Stdout)
+		stderrLog := logger.Stream(joblog.Stderr)
+		logStreams = []io.WriteCloser{stdoutLog, stderrLog}
+		stdoutSink = io.MultiWriter(stdoutWriter, stdoutLog)
+		stderrSink = io.MultiWriter(stderrWriter, stderrLog)
+	}
+
 	start := time.Now()
-	code, execErr := execCmd(ctx, outDir, execDir, spec.GetCommand(), stdout, stderr, spec.GetLimits())
+	code, execErr := execCmd(execCtx, outDir, execDir, spec.GetCommand(), stdoutSink, stderrSink, limits)
 	dur := time.Since(start)
 
-	if err := uploadOutputs(ctx, spec.GetOutputs(), stdout, stderr); err != nil {
+	for _, s := range logStreams {
+		if err := s.Close(); err != nil {
+			log.Printf("WARNING: Writing the task log failed: %v", err)
+		}
+	}
+	for _, w := range cappedWriters {
+		if err := w.finish(); err != nil {
+			log.Printf("WARNING: Truncating outputs failed: %v", err)
+		}
+	}
+	truncated := stdoutWriter.truncated() || stderrWriter.truncated()
+	encoding := outputEncoding(spec.GetOutputs())
+
+	if ctx.Err() != nil {
+		// The task was aborted, e.g. because it lost its lease. Do not upload
+		// outputs since they might overwrite those of another attempt.
+		log.Printf("INFO: Discarding outputs of an aborted task")
+	} else if err := uploadOutputs(ctx, spec.GetOutputs(), encoding, stdout, stderr, logFile); err != nil {
 		log.Printf("WARNING: Uploading outputs failed: %v", err)
 	}
 
 	result := &flex.TaskResult{
-		ExitCode: int32(code),
-		Time:     durationpb.New(dur),
+		ExitCode:        int32(code),
+		Time:            durationpb.New(dur),
+		StdoutBytes:     stdoutWriter.size(),
+		StderrBytes:     stderrWriter.size(),
+		OutputTruncated: truncated,
+		OutputEncoding:  encoding,
 	}
-	if execErr != nil {
+	switch {
+	case truncated && limits.GetKillOnOutputOverflow():
+		result.Message = fmt.Sprintf("killed: output exceeded %d bytes", limits.GetMaxOutputBytes())
+	case execErr != nil:
 		result.Message = execErr.Error()
-	} else {
+	default:
 		result.Message = "success"
 	}
 	return result
@@ -146,51 +220,191 @@ func preparePackage(ctx context.Context, execDir string, pkg *flexletpb.TaskPack
 		}
 	}
 
-	f, err := openLocation(ctx, pkg.GetLocation(), cache)
+	f, err := openPackage(ctx, pkg, cache)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
 
+	// Packages are cached encrypted, and decrypted on every use.
+	var r io.Reader = f
+	if key := pkg.GetEncryptionKey(); len(key) > 0 {
+		r, err = envelope.NewReader(f, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Verify the package while extracting it too, since a cached package
+	// might have been corrupted after it was downloaded.
+	h := hashutil.NewTeeHasher(ioutil.Discard, hashutil.NewStdHash())
+
 	cmd := exec.CommandContext(ctx, "tar", "xz")
 	cmd.Dir = extractDir
-	cmd.Stdin = f
-	if err := cmd.Run(); err != nil {
+	cmd.Stdin = io.TeeReader(r, h)
+	runErr := cmd.Run()
+
+	if hash := pkg.GetHash(); hash != "" {
+		if err := verifyHash(h, r, hash); err != nil {
+			if err := cache.Remove(pkg.GetLocation().GetCanonicalUrl()); err != nil {
+				log.Printf("WARNING: Evicting a corrupted package failed: %v", err)
+			}
+			return fmt.Errorf("package %s is corrupted: %w", hash, err)
+		}
+	}
+	return runErr
+}
+
+// openPackage opens a package from the cache, downloading it if needed.
+// Downloaded packages are verified against their hash before being cached.
+func openPackage(ctx context.Context, pkg *flexletpb.TaskPackage, cache *filecache.Manager) (io.ReadCloser, error) {
+	loc := pkg.GetLocation()
+	return cache.Open(loc.GetCanonicalUrl(), func(w io.Writer) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, loc.GetPresignedUrl(), nil)
+		if err != nil {
+			return err
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusOK {
+			return fmt.Errorf("downloading %s: %s", loc.GetCanonicalUrl(), res.Status)
+		}
+
+		hash := pkg.GetHash()
+		if hash == "" {
+			_, err = io.Copy(w, res.Body)
+			return err
+		}
+
+		// The hash is of the decrypted content, while packages are cached as
+		// downloaded.
+		var r io.Reader = io.TeeReader(res.Body, w)
+		if key := pkg.GetEncryptionKey(); len(key) > 0 {
+			r, err = envelope.NewReader(r, key)
+			if err != nil {
+				return err
+			}
+		}
+		h := hashutil.NewTeeHasher(ioutil.Discard, hashutil.NewStdHash())
+		if err := verifyHash(h, r, hash); err != nil {
+			return fmt.Errorf("downloading %s: %w", loc.GetCanonicalUrl(), err)
+		}
+		// Cache the rest of the file, if any.
+		_, err = io.Copy(w, res.Body)
+		return err
+	})
+}
+
+// verifyHash reads the rest of r into h, and checks that the hash of all the
+// data written to h matches hash.
+func verifyHash(h *hashutil.TeeHasher, r io.Reader, hash string) error {
+	if _, err := io.Copy(h, r); err != nil {
 		return err
 	}
+	if got := h.SumString(); got != hash {
+		return fmt.Errorf("hash mismatch: got %s, want %s", got, hash)
+	}
 	return nil
 }
 
-func uploadOutputs(ctx context.Context, outputs *flexletpb.TaskOutputs, stdout, stderr *os.File) error {
+// outputEncoding returns the content encoding to compress outputs with. It
+// returns an empty string if the requested encoding is not supported.
+func outputEncoding(outputs *flexletpb.TaskOutputs) string {
+	if outputs.GetEncoding() == "gzip" {
+		return "gzip"
+	}
+	return ""
+}
+
+// uploadOutputs uploads outputs of a task. logFile is nil if the task log
+// was not recorded.
+func uploadOutputs(ctx context.Context, outputs *flexletpb.TaskOutputs, encoding string, stdout, stderr, logFile *os.File) error {
 	var firstErr error
-	if err := putLocation(ctx, outputs.GetStdout(), stdout); err != nil && firstErr == nil {
+	if err := uploadOutput(ctx, outputs.GetStdout(), encoding, outputs.GetStdoutEncryptionKey(), stdout); err != nil && firstErr == nil {
 		firstErr = err
 	}
-	if err := putLocation(ctx, outputs.GetStderr(), stderr); err != nil && firstErr == nil {
+	if err := uploadOutput(ctx, outputs.GetStderr(), encoding, outputs.GetStderrEncryptionKey(), stderr); err != nil && firstErr == nil {
 		firstErr = err
 	}
+	if logFile != nil {
+		if err := uploadOutput(ctx, outputs.GetLog(), encoding, outputs.GetLogEncryptionKey(), logFile); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
 	return firstErr
 }
 
-func openLocation(ctx context.Context, loc *flex.FileLocation, cache *filecache.Manager) (io.ReadCloser, error) {
-	return cache.Open(loc.GetCanonicalUrl(), func(w io.Writer) error {
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, loc.GetPresignedUrl(), nil)
+func uploadOutput(ctx context.Context, loc *flex.FileLocation, encoding string, key []byte, f *os.File) error {
+	if loc == nil {
+		return nil
+	}
+	if encoding == "gzip" {
+		gf, err := transformFile(f, ".gz", func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriter(w), nil
+		})
 		if err != nil {
 			return err
 		}
-
-		res, err := http.DefaultClient.Do(req)
+		defer os.Remove(gf.Name())
+		defer gf.Close()
+		f = gf
+	}
+	if len(key) > 0 {
+		ef, err := transformFile(f, ".enc", func(w io.Writer) (io.WriteCloser, error) {
+			return envelope.NewWriter(w, key)
+		})
 		if err != nil {
 			return err
 		}
-		defer res.Body.Close()
+		defer os.Remove(ef.Name())
+		defer ef.Close()
+		f = ef
+	}
+	// Let storages serve unencrypted outputs with Content-Encoding so that
+	// clients decode them transparently.
+	contentEncoding := encoding
+	if len(key) > 0 {
+		contentEncoding = ""
+	}
+	return putLocation(ctx, loc, contentEncoding, f)
+}
 
-		_, err = io.Copy(w, res.Body)
-		return err
-	})
+// transformFile writes f through a writer returned by newWriter into a new
+// file next to it, whose name has suffix appended.
+func transformFile(f *os.File, suffix string, newWriter func(w io.Writer) (io.WriteCloser, error)) (*os.File, error) {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	tf, err := os.Create(f.Name() + suffix)
+	if err != nil {
+		return nil, err
+	}
+	if err := func() error {
+		w, err := newWriter(tf)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(w, f); err != nil {
+			return err
+		}
+		return w.Close()
+	}(); err != nil {
+		tf.Close()
+		os.Remove(tf.Name())
+		return nil, err
+	}
+	return tf, nil
 }
 
-func putLocation(ctx context.Context, loc *flex.FileLocation, f io.ReadSeeker) error {
+// putLocation uploads f to loc. contentEncoding is sent as the Content-Encoding
+// header of HTTP uploads if it is not empty.
+func putLocation(ctx context.Context, loc *flex.FileLocation, contentEncoding string, f io.ReadSeeker) error {
 	if loc == nil {
 		return nil
 	}
@@ -216,7 +430,7 @@ func putLocation(ctx context.Context, loc *flex.FileLocation, f io.ReadSeeker) e
 			return copyErr
 		}
 		return closeErr
-	case "gs", "s3", "http":
+	case "gs", "s3", "http", "https":
 		size, err := f.Seek(0, io.SeekEnd)
 		if err != nil {
 			return err
@@ -230,6 +444,9 @@ func putLocation(ctx context.Context, loc *flex.FileLocation, f io.ReadSeeker) e
 			return err
 		}
 		req.ContentLength = size
+		if contentEncoding != "" {
+			req.Header.Set("Content-Encoding", contentEncoding)
+		}
 		if size == 0 {
 			// Avoid 501 Not Implemented on size=0.
 			req.Body = nil
@@ -261,13 +478,27 @@ func execCmd(ctx context.Context, outDir, execDir string, cmd *flex.JobCommand,
 		return -1, errors.New("command is empty")
 	}
 
+	stdoutPipe, err := newOutputPipe(stdout)
+	if err != nil {
+		return -1, err
+	}
+	defer stdoutPipe.wait(graceTime)
+	stderrPipe, err := newOutputPipe(stderr)
+	if err != nil {
+		return -1, err
+	}
+	defer stderrPipe.wait(graceTime)
+
 	c := exec.CommandContext(ctx, args[0], args[1:]...)
 	c.Dir = execDir
-	c.Stdout = stdout
-	c.Stderr = stderr
-	c.Env = append(os.Environ(), "OUT_DIR="+outDir)
+	c.Stdout = stdoutPipe.w
+	c.Stderr = stderrPipe.w
+	c.Env = append(append(os.Environ(), cmd.GetEnv()...), "OUT_DIR="+outDir)
 	c.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
-	if err := c.Start(); err != nil {
+	err = c.Start()
+	stdoutPipe.closeWriter()
+	stderrPipe.closeWriter()
+	if err != nil {
 		return -1, err
 	}
 	defer unix.Kill(-c.Process.Pid, unix.SIGKILL)
@@ -18,6 +18,9 @@ import (
 	"archive/tar"
 	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -30,12 +33,15 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"golang.org/x/sys/unix"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/nya3jp/flex"
 	"github.com/nya3jp/flex/cmd/flexlet/internal/run"
+	"github.com/nya3jp/flex/internal/envelope"
 	"github.com/nya3jp/flex/internal/flexletpb"
+	"github.com/nya3jp/flex/internal/joblog"
 )
 
 func TestRunner_RunTask(t *testing.T) {
@@ -45,7 +51,7 @@ func TestRunner_RunTask(t *testing.T) {
 	}
 	defer os.RemoveAll(tempDir)
 
-	runner, err := run.New(tempDir)
+	runner, err := run.New(tempDir, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -111,7 +117,7 @@ func TestRunner_RunTask_Inputs(t *testing.T) {
 	}
 	defer os.RemoveAll(tempDir)
 
-	runner, err := run.New(tempDir)
+	runner, err := run.New(tempDir, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -177,6 +183,98 @@ func TestRunner_RunTask_Inputs(t *testing.T) {
 	}
 }
 
+func TestRunner_RunTask_PackageHash(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	runner, err := run.New(tempDir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	webDir := filepath.Join(tempDir, "web")
+	if err := os.Mkdir(webDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	pkgPath := filepath.Join(webDir, "pkg.tar.gz")
+	writeTarGz(t, pkgPath, []string{"file1"})
+	b, err := os.ReadFile(pkgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(b)
+	hash := hex.EncodeToString(sum[:])
+
+	server := httptest.NewServer(http.FileServer(http.Dir(webDir)))
+	defer server.Close()
+
+	runTask := func(hash string) *flex.TaskResult {
+		return runner.RunTask(context.Background(), &flexletpb.TaskSpec{
+			Command: &flex.JobCommand{Args: []string{"test", "-f", "file1"}},
+			Inputs: &flexletpb.TaskInputs{
+				Packages: []*flexletpb.TaskPackage{{
+					Location: &flex.FileLocation{
+						CanonicalUrl: server.URL + "/pkg.tar.gz",
+						PresignedUrl: server.URL + "/pkg.tar.gz",
+					},
+					Hash: hash,
+				}},
+			},
+			Limits: &flex.JobLimits{Time: durationpb.New(time.Minute)},
+		})
+	}
+
+	cacheDir := filepath.Join(tempDir, "cache")
+	cacheFiles := func() []string {
+		fis, err := ioutil.ReadDir(cacheDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, fi := range fis {
+			// Lock files are left in the cache.
+			if strings.HasSuffix(fi.Name(), ".lock") {
+				continue
+			}
+			names = append(names, fi.Name())
+		}
+		return names
+	}
+
+	// A package not matching its hash is rejected and not cached.
+	badHash := strings.Repeat("0", 64)
+	if res := runTask(badHash); !strings.Contains(res.GetMessage(), "hash mismatch") {
+		t.Errorf("RunTask with a wrong hash: got %q, want a hash mismatch", res.GetMessage())
+	}
+	if names := cacheFiles(); len(names) != 0 {
+		t.Errorf("Cache has files after a hash mismatch: %q", names)
+	}
+
+	if res := runTask(hash); res.GetMessage() != "success" {
+		t.Fatalf("RunTask failed: %s", res.GetMessage())
+	}
+	names := cacheFiles()
+	if len(names) != 1 {
+		t.Fatalf("Cache has files %q; want one entry", names)
+	}
+
+	// A corrupted cache entry is evicted.
+	writeTarGz(t, filepath.Join(cacheDir, names[0]), []string{"file2"})
+	if res := runTask(hash); !strings.Contains(res.GetMessage(), "corrupted") {
+		t.Errorf("RunTask with a corrupted cache: got %q, want corruption", res.GetMessage())
+	}
+	if names := cacheFiles(); len(names) != 0 {
+		t.Errorf("Cache has files after corruption: %q", names)
+	}
+	if res := runTask(hash); res.GetMessage() != "success" {
+		t.Errorf("RunTask after eviction failed: %s", res.GetMessage())
+	}
+}
+
 func TestRunner_RunTask_Outputs(t *testing.T) {
 	tempDir, err := ioutil.TempDir("", "")
 	if err != nil {
@@ -184,7 +282,7 @@ func TestRunner_RunTask_Outputs(t *testing.T) {
 	}
 	defer os.RemoveAll(tempDir)
 
-	runner, err := run.New(tempDir)
+	runner, err := run.New(tempDir, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -226,6 +324,357 @@ func TestRunner_RunTask_Outputs(t *testing.T) {
 	}
 }
 
+func TestRunner_RunTask_CompressedOutputs(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	runner, err := run.New(tempDir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, err := os.CreateTemp(tempDir, "stdout.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdout.Close()
+
+	spec := &flexletpb.TaskSpec{
+		Command: &flex.JobCommand{Args: []string{"echo", "foo"}},
+		Outputs: &flexletpb.TaskOutputs{
+			Stdout: &flex.FileLocation{
+				CanonicalUrl: "file://" + stdout.Name(),
+				PresignedUrl: "file://" + stdout.Name(),
+			},
+			Encoding: "gzip",
+		},
+		Limits: &flex.JobLimits{Time: durationpb.New(time.Minute)},
+	}
+
+	res := runner.RunTask(context.Background(), spec)
+	if got := res.GetOutputEncoding(); got != "gzip" {
+		t.Errorf("OutputEncoding = %q; want %q", got, "gzip")
+	}
+
+	r, err := gzip.NewReader(stdout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err := io.ReadAll(r); err != nil {
+		t.Errorf("Decompressing stdout: %v", err)
+	} else if string(b) != "foo\n" {
+		t.Errorf("Unexpected stdout: got %q, want %q", string(b), "foo\n")
+	}
+}
+
+func TestRunner_RunTask_CompressedOutputs_HTTP(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	runner, err := run.New(tempDir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var contentEncoding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentEncoding = r.Header.Get("Content-Encoding")
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	spec := &flexletpb.TaskSpec{
+		Command: &flex.JobCommand{Args: []string{"echo", "foo"}},
+		Outputs: &flexletpb.TaskOutputs{
+			Stdout: &flex.FileLocation{
+				CanonicalUrl: server.URL + "/stdout",
+				PresignedUrl: server.URL + "/stdout",
+			},
+			Encoding: "gzip",
+		},
+		Limits: &flex.JobLimits{Time: durationpb.New(time.Minute)},
+	}
+
+	res := runner.RunTask(context.Background(), spec)
+	if res.GetMessage() != "success" {
+		t.Fatalf("RunTask failed: %s", res.GetMessage())
+	}
+	if contentEncoding != "gzip" {
+		t.Errorf("Content-Encoding = %q; want %q", contentEncoding, "gzip")
+	}
+}
+
+func TestRunner_RunTask_Log(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	runner, err := run.New(tempDir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logFile, err := os.CreateTemp(tempDir, "log.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logFile.Close()
+
+	spec := &flexletpb.TaskSpec{
+		Command: &flex.JobCommand{
+			Args: []string{"sh", "-c", "echo foo; sleep 0.1; echo bar >&2; sleep 0.1; printf baz"},
+		},
+		Outputs: &flexletpb.TaskOutputs{
+			Log: &flex.FileLocation{
+				CanonicalUrl: "file://" + logFile.Name(),
+				PresignedUrl: "file://" + logFile.Name(),
+			},
+		},
+		Limits: &flex.JobLimits{Time: durationpb.New(time.Minute)},
+	}
+
+	start := time.Now()
+	if res := runner.RunTask(context.Background(), spec); res.GetMessage() != "success" {
+		t.Fatalf("RunTask failed: %s", res.GetMessage())
+	}
+	end := time.Now()
+
+	b, err := io.ReadAll(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []joblog.Record
+	for _, line := range strings.SplitAfter(string(b), "\n") {
+		if line == "" {
+			continue
+		}
+		rec, err := joblog.Parse(line)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", line, err)
+		}
+		if rec.Time.Before(start) || rec.Time.After(end) {
+			t.Errorf("Record %q has time out of the task run", line)
+		}
+		got = append(got, joblog.Record{Stream: rec.Stream, Line: rec.Line})
+	}
+	want := []joblog.Record{
+		{Stream: joblog.Stdout, Line: "foo"},
+		{Stream: joblog.Stderr, Line: "bar"},
+		{Stream: joblog.Stdout, Line: "baz"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Log mismatch (-got +want):\n%s", diff)
+	}
+}
+
+func TestRunner_RunTask_OutputLimit(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	runner, err := run.New(tempDir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sb strings.Builder
+	for i := 1; i <= 10000; i++ {
+		fmt.Fprintf(&sb, "%d\n", i)
+	}
+	seq := sb.String()
+
+	for _, tc := range []struct {
+		name       string
+		cmd        string
+		limits     *flex.JobLimits
+		wantStdout string
+		wantResult *flex.TaskResult
+	}{
+		{
+			name:       "within limit",
+			cmd:        "seq 1 10000",
+			limits:     &flex.JobLimits{Time: durationpb.New(time.Minute), MaxOutputBytes: int64(len(seq))},
+			wantStdout: seq,
+			wantResult: &flex.TaskResult{Message: "success", StdoutBytes: int64(len(seq))},
+		},
+		{
+			name:       "truncated",
+			cmd:        "seq 1 10000",
+			limits:     &flex.JobLimits{Time: durationpb.New(time.Minute), MaxOutputBytes: 100},
+			wantStdout: seq[:50] + fmt.Sprintf("\n[flex: output truncated; %d bytes omitted]\n", len(seq)-100) + seq[len(seq)-50:],
+			wantResult: &flex.TaskResult{Message: "success", StdoutBytes: int64(len(seq)), OutputTruncated: true},
+		},
+		{
+			name:   "killed",
+			cmd:    "echo foo >&2; yes",
+			limits: &flex.JobLimits{Time: durationpb.New(time.Minute), MaxOutputBytes: 100, KillOnOutputOverflow: true},
+			wantResult: &flex.TaskResult{
+				ExitCode:        128 + int32(unix.SIGKILL),
+				Message:         "killed: output exceeded 100 bytes",
+				StderrBytes:     4,
+				OutputTruncated: true,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout, err := os.CreateTemp(tempDir, "stdout.")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer stdout.Close()
+
+			spec := &flexletpb.TaskSpec{
+				Command: &flex.JobCommand{Args: []string{"sh", "-c", tc.cmd}},
+				Outputs: &flexletpb.TaskOutputs{
+					Stdout: &flex.FileLocation{
+						CanonicalUrl: "file://" + stdout.Name(),
+						PresignedUrl: "file://" + stdout.Name(),
+					},
+				},
+				Limits: tc.limits,
+			}
+
+			got := runner.RunTask(context.Background(), spec)
+			ignore := []protoreflect.Name{"time"}
+			if tc.wantStdout == "" {
+				// The size of killed output varies.
+				ignore = append(ignore, "stdout_bytes")
+			}
+			if diff := cmp.Diff(got, tc.wantResult, protocmp.Transform(), protocmp.IgnoreFields(&flex.TaskResult{}, ignore...)); diff != "" {
+				t.Errorf("TaskResult mismatch (-got +want):\n%s", diff)
+			}
+
+			out, _ := io.ReadAll(stdout)
+			if tc.wantStdout != "" {
+				if diff := cmp.Diff(string(out), tc.wantStdout); diff != "" {
+					t.Errorf("Stdout mismatch (-got +want):\n%s", diff)
+				}
+			} else if !strings.Contains(string(out), "[flex: output truncated;") || len(out) > 200 {
+				t.Errorf("Stdout is not truncated: %q", string(out))
+			}
+		})
+	}
+}
+
+func TestRunner_RunTask_Encrypted(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	runner, err := run.New(tempDir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkgKey, err := envelope.NewKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdoutKey, err := envelope.NewKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	webDir := filepath.Join(tempDir, "web")
+	if err := os.Mkdir(webDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	plainPath := filepath.Join(tempDir, "pkg.tar.gz")
+	writeTarGz(t, plainPath, []string{"file1"})
+	encryptFile(t, plainPath, filepath.Join(webDir, "pkg.tar.gz.enc"), pkgKey)
+	plain, err := os.ReadFile(plainPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkgSum := sha256.Sum256(plain)
+
+	server := httptest.NewServer(http.FileServer(http.Dir(webDir)))
+	defer server.Close()
+
+	stdout, err := os.CreateTemp(tempDir, "stdout.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdout.Close()
+
+	spec := &flexletpb.TaskSpec{
+		Command: &flex.JobCommand{Args: []string{"sh", "-e", "-c", "find . | sort"}},
+		Inputs: &flexletpb.TaskInputs{
+			Packages: []*flexletpb.TaskPackage{
+				{
+					Location: &flex.FileLocation{
+						CanonicalUrl: server.URL + "/pkg.tar.gz.enc",
+						PresignedUrl: server.URL + "/pkg.tar.gz.enc",
+					},
+					EncryptionKey: pkgKey,
+					Hash:          hex.EncodeToString(pkgSum[:]),
+				},
+			},
+		},
+		Outputs: &flexletpb.TaskOutputs{
+			Stdout: &flex.FileLocation{
+				CanonicalUrl: "file://" + stdout.Name(),
+				PresignedUrl: "file://" + stdout.Name(),
+			},
+			StdoutEncryptionKey: stdoutKey,
+		},
+		Limits: &flex.JobLimits{Time: durationpb.New(time.Minute)},
+	}
+
+	if res := runner.RunTask(context.Background(), spec); res.GetExitCode() != 0 {
+		t.Fatalf("RunTask failed: %s", res.GetMessage())
+	}
+
+	r, err := envelope.NewReader(stdout, stdoutKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Decrypting stdout: %v", err)
+	}
+	const want = ".\n./file1\n"
+	if diff := cmp.Diff(string(out), want); diff != "" {
+		t.Errorf("Files mismatch (-got +want):\n%s", diff)
+	}
+}
+
+func encryptFile(t *testing.T, src, dst string, key []byte) {
+	data, err := ioutil.ReadFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Create(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	w, err := envelope.NewWriter(f, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func writeTarGz(t *testing.T, name string, files []string) {
 	f, err := os.Create(name)
 	if err != nil {
@@ -16,6 +16,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -25,18 +26,21 @@ import (
 	"os/signal"
 	"path/filepath"
 	"runtime"
+	"sync"
+	"time"
 
 	"github.com/urfave/cli/v2"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sys/unix"
 
 	"github.com/nya3jp/flex/cmd/flexlet/internal/flexlet"
+	"github.com/nya3jp/flex/cmd/flexlet/internal/hostinfo"
 	"github.com/nya3jp/flex/cmd/flexlet/internal/run"
 	"github.com/nya3jp/flex/internal/flexletpb"
 	"github.com/nya3jp/flex/internal/grpcutil"
 )
 
-func runInPullMode(ctx context.Context, cl flexletpb.FlexletServiceClient, runner *run.Runner, name string, cores, replicas int) error {
+func runInPullMode(ctx context.Context, cl flexletpb.FlexletServiceClient, runner *run.Runner, mon *hostinfo.Monitor, name string, cores, replicas int, drain <-chan struct{}, drainTimeout time.Duration) error {
 	grp, ctx := errgroup.WithContext(ctx)
 	for i := 0; i < replicas; i++ {
 		replicaName := name
@@ -44,20 +48,55 @@ func runInPullMode(ctx context.Context, cl flexletpb.FlexletServiceClient, runne
 			replicaName += fmt.Sprintf(".%d", i)
 		}
 		grp.Go(func() error {
-			return flexlet.Run(ctx, cl, runner, replicaName, cores)
+			return flexlet.Run(ctx, cl, runner, mon, replicaName, cores, drain, drainTimeout)
 		})
 	}
 	return grp.Wait()
 }
 
-func runInPushMode(ctx context.Context, cl flexletpb.FlexletServiceClient, runner *run.Runner, name string) error {
+func runInPushMode(ctx context.Context, cl flexletpb.FlexletServiceClient, runner *run.Runner, mon *hostinfo.Monitor, name string, cores int, pushDrain bool, pushDrainTimeout time.Duration, drain <-chan struct{}, drainTimeout time.Duration) error {
+	// reqCtx is canceled when draining times out, which aborts running tasks
+	// and returns them to flexhub.
+	reqCtx, reqCancel := context.WithCancel(ctx)
+	defer reqCancel()
+
+	// drainMu serializes drains since they share the flexlet name.
+	var drainMu sync.Mutex
+	var reqs sync.WaitGroup
+
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			http.Error(w, "unsupported method", http.StatusBadRequest)
 			return
 		}
-		task, _, err := flexlet.RunOneOff(ctx, cl, runner, name, -1)
+
+		reqs.Add(1)
+		defer reqs.Done()
+
+		if pushDrain {
+			// Waiting for another drain counts toward the timeout.
+			deadline := time.Now().Add(pushDrainTimeout)
+			drainMu.Lock()
+			defer drainMu.Unlock()
+			if isClosed(drain) {
+				http.Error(w, "flexlet is draining", http.StatusServiceUnavailable)
+				return
+			}
+			summary, err := flexlet.RunDrain(reqCtx, cl, runner, mon, name, cores, deadline, drain)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(summary)
+			return
+		}
+		if isClosed(drain) {
+			http.Error(w, "flexlet is draining", http.StatusServiceUnavailable)
+			return
+		}
+		task, _, err := flexlet.RunOneOff(reqCtx, cl, runner, mon, name, -1)
 		if errors.Is(err, flexlet.ErrNoPendingTask) {
 			io.WriteString(w, err.Error())
 			return
@@ -68,13 +107,64 @@ func runInPushMode(ctx context.Context, cl flexletpb.FlexletServiceClient, runne
 		}
 		fmt.Fprintf(w, "ok: %d", task.GetRef().GetJobId())
 	})
-	return http.ListenAndServe(":"+os.Getenv("PORT"), mux)
+
+	server := &http.Server{Addr: ":" + os.Getenv("PORT"), Handler: mux}
+
+	// On draining, stop accepting requests and wait for running ones up to
+	// drainTimeout.
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-drain:
+			log.Printf("INFO: Draining: waiting for running requests up to %v", drainTimeout)
+			shutdownCtx, shutdownCancel := context.WithTimeout(ctx, drainTimeout)
+			defer shutdownCancel()
+			if err := server.Shutdown(shutdownCtx); err != nil {
+				log.Printf("WARNING: Draining timed out; aborting running tasks")
+				reqCancel()
+				server.Close()
+			}
+		case <-ctx.Done():
+			server.Close()
+		}
+		reqs.Wait()
+	}()
+
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	<-stopped
+	return nil
+}
+
+// isClosed returns whether ch is closed.
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
 }
 
 func main() {
-	ctx, cancel := signal.NotifyContext(context.Background(), unix.SIGINT, unix.SIGTERM)
+	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// The first signal starts draining, and the second one aborts.
+	drain := make(chan struct{})
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, unix.SIGINT, unix.SIGTERM)
+	go func() {
+		<-sigCh
+		log.Printf("INFO: Draining; send the signal again to abort")
+		close(drain)
+		<-sigCh
+		log.Printf("INFO: Aborting")
+		cancel()
+	}()
+
 	if err := func() error {
 		hostName, err := os.Hostname()
 		if err != nil {
@@ -92,12 +182,21 @@ func main() {
 			Flags: []cli.Flag{
 				&cli.StringFlag{Name: "name", Value: hostName, Usage: "Flexlet name"},
 				&cli.IntFlag{Name: "cores", Value: runtime.NumCPU(), Usage: "Number of available cores"},
-				&cli.StringFlag{Name: "hub", Required: true, Usage: "Flexhub URL"},
+				&cli.StringFlag{Name: "hub", Usage: "Flexhub URL (required)"},
 				&cli.StringFlag{Name: "storedir", Value: filepath.Join(homeDir, ".cache/flexlet"), Usage: "Storage directory path"},
 				&cli.StringFlag{Name: "password", Usage: "Sets a Flexlet service password"},
 				&cli.BoolFlag{Name: "push", Usage: "Run in push mode"},
+				&cli.BoolFlag{Name: "push-drain", Usage: "In push mode, run tasks on each request until the queue is empty, up to --cores at a time"},
+				&cli.DurationFlag{Name: "push-drain-timeout", Value: 4 * time.Minute, Usage: "In push drain mode, abort tasks and return them for retry after this duration since a request"},
+				&cli.StringFlag{Name: "health-check", Usage: "Shell command to check the health of the host; the flexlet takes no task while it fails"},
+				&cli.DurationFlag{Name: "health-check-interval", Value: 30 * time.Second, Usage: "Interval of health checks"},
+				&cli.DurationFlag{Name: "drain-timeout", Value: 5 * time.Minute, Usage: "Maximum duration to wait for running tasks on draining"},
+				&cli.Int64Flag{Name: "max-cache-bytes", Usage: "Maximum size of the package cache; least recently used packages are evicted beyond it. Zero means no limit"},
 				&cli.IntFlag{Name: "replicas-for-load-testing", Value: 1, Hidden: true},
 			},
+			Commands: []*cli.Command{
+				cmdCache,
+			},
 			Action: func(c *cli.Context) error {
 				name := c.String("name")
 				cores := c.Int("cores")
@@ -105,13 +204,28 @@ func main() {
 				storeDir := c.String("storedir")
 				password := c.String("password")
 				push := c.Bool("push")
+				pushDrain := c.Bool("push-drain")
+				pushDrainTimeout := c.Duration("push-drain-timeout")
+				healthCheck := c.String("health-check")
+				healthCheckInterval := c.Duration("health-check-interval")
+				drainTimeout := c.Duration("drain-timeout")
 				replicas := c.Int("replicas-for-load-testing")
+				maxCacheBytes := c.Int64("max-cache-bytes")
 
-				runner, err := run.New(storeDir)
+				// --hub is not marked required so that subcommands can run
+				// without it.
+				if hubURL == "" {
+					return errors.New("--hub is required")
+				}
+
+				runner, err := run.New(storeDir, maxCacheBytes)
 				if err != nil {
 					return err
 				}
 
+				mon := hostinfo.NewMonitor(storeDir, healthCheck, runner.Cache())
+				go mon.Run(ctx, healthCheckInterval)
+
 				cc, err := grpcutil.DialContext(ctx, hubURL, password)
 				if err != nil {
 					return err
@@ -119,9 +233,9 @@ func main() {
 				cl := flexletpb.NewFlexletServiceClient(cc)
 
 				if push {
-					return runInPushMode(ctx, cl, runner, name)
+					return runInPushMode(ctx, cl, runner, mon, name, cores, pushDrain, pushDrainTimeout, drain, drainTimeout)
 				}
-				return runInPullMode(ctx, cl, runner, name, cores, replicas)
+				return runInPullMode(ctx, cl, runner, mon, name, cores, replicas, drain, drainTimeout)
 			},
 		}
 		return app.RunContext(ctx, os.Args)
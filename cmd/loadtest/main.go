@@ -20,11 +20,15 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"time"
 
 	"github.com/urfave/cli/v2"
 	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/internal/ctxutil"
 	"github.com/nya3jp/flex/internal/grpcutil"
 )
 
@@ -47,20 +51,20 @@ func run(ctx context.Context, cl flex.FlexServiceClient, args []string, jobs int
 
 	log.Printf("Submitted %d jobs; waiting for their results", jobs)
 
+	pending := make(map[int64]struct{})
 	for _, jobId := range jobIds {
-		for {
-			res, err := cl.GetJob(ctx, &flex.GetJobRequest{Id: jobId})
-			if err != nil {
-				return fmt.Errorf("failed to poll job %d: %w", jobId, err)
-			}
-			state := res.GetJob().GetState()
-			if state != flex.JobState_FINISHED {
-				continue
+		pending[jobId] = struct{}{}
+	}
+
+	for len(pending) > 0 {
+		if err := watchJobs(ctx, cl, jobIds, pending); err != nil {
+			if code := status.Code(err); ctx.Err() != nil || (code != codes.Unavailable && code != codes.Canceled) {
+				return fmt.Errorf("failed to watch jobs: %w", err)
 			}
-			if res.GetJob().GetResult().GetExitCode() != 0 {
-				return fmt.Errorf("job %d failed: %s", jobId, res.GetJob().GetResult().GetMessage())
+			log.Printf("WARNING: Watching jobs failed; retrying: %v", err)
+			if err := ctxutil.Sleep(ctx, time.Second); err != nil {
+				return err
 			}
-			break
 		}
 	}
 
@@ -69,6 +73,35 @@ func run(ctx context.Context, cl flex.FlexServiceClient, args []string, jobs int
 	return nil
 }
 
+// watchJobs watches jobs until all jobs in pending finish. Finished jobs are
+// removed from pending.
+func watchJobs(ctx context.Context, cl flex.FlexServiceClient, jobIds []int64, pending map[int64]struct{}) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := cl.WatchJobs(ctx, &flex.WatchJobsRequest{Ids: jobIds})
+	if err != nil {
+		return err
+	}
+
+	for len(pending) > 0 {
+		res, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		job := res.GetJob()
+		if job.GetState() != flex.JobState_FINISHED {
+			continue
+		}
+		jobId := job.GetJob().GetId()
+		if job.GetResult().GetExitCode() != 0 {
+			return fmt.Errorf("job %d failed: %s", jobId, job.GetResult().GetMessage())
+		}
+		delete(pending, jobId)
+	}
+	return nil
+}
+
 func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), unix.SIGINT, unix.SIGTERM)
 	defer cancel()
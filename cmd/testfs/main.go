@@ -16,94 +16,28 @@ package main
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
-	"io"
 	"log"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
-	"path/filepath"
-	"strconv"
 
 	"github.com/urfave/cli/v2"
 	"golang.org/x/sys/unix"
 
-	"github.com/nya3jp/flex/internal/hashutil"
+	"github.com/nya3jp/flex/internal/fileserver"
 )
 
 type handler struct {
-	tmpDir string
+	srv *fileserver.Server
 }
 
 func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if r.Method == http.MethodGet || r.Method == http.MethodHead {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 	}
-
-	hash := hex.EncodeToString(hashutil.NewStdHash().Sum([]byte(r.URL.Path)))
-	path := filepath.Join(h.tmpDir, hash)
-	if err := func() error {
-		switch r.Method {
-		case http.MethodGet:
-			f, err := os.Open(path)
-			if os.IsNotExist(err) {
-				http.Error(w, "File not found", http.StatusNotFound)
-				return nil
-			}
-			if err != nil {
-				return err
-			}
-			defer f.Close()
-
-			fi, err := f.Stat()
-			if err != nil {
-				return err
-			}
-
-			w.Header().Set("Content-Type", "application/octet-stream")
-			w.Header().Set("Content-Length", strconv.FormatInt(fi.Size(), 10))
-			_, err = io.CopyN(w, f, fi.Size())
-			return err
-
-		case http.MethodHead:
-			fi, err := os.Stat(path)
-			if os.IsNotExist(err) {
-				w.WriteHeader(http.StatusNotFound)
-				return nil
-			}
-			if err != nil {
-				return err
-			}
-			w.Header().Set("Content-Type", "application/octet-stream")
-			w.Header().Set("Content-Length", strconv.FormatInt(fi.Size(), 10))
-			w.WriteHeader(http.StatusOK)
-			return nil
-
-		case http.MethodPut:
-			f, err := os.Create(path)
-			if err != nil {
-				return err
-			}
-			_, firstErr := io.Copy(f, r.Body)
-			if err := f.Close(); err != nil && firstErr == nil {
-				firstErr = err
-			}
-
-			if firstErr != nil {
-				return firstErr
-			}
-			w.WriteHeader(http.StatusNoContent)
-			return nil
-
-		default:
-			http.Error(w, "method not supported", http.StatusBadRequest)
-			return nil
-		}
-	}(); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
+	h.srv.ServeHTTP(w, r)
 }
 
 func main() {
@@ -115,20 +49,37 @@ func main() {
 		Usage: "Test File Storage Server",
 		Flags: []cli.Flag{
 			&cli.IntFlag{Name: "port", Value: 8081, Usage: "TCP port to listen on"},
+			&cli.StringFlag{Name: "dir", Usage: "Directory to store files in; a temporary directory removed on exit is used if unset"},
+			&cli.StringFlag{Name: "url-signing-key", EnvVars: []string{"FLEX_URL_SIGNING_KEY"}, Usage: "Secret key shared with flexhub to verify signed URLs with; requests are not authorized if unset"},
 		},
 		Action: func(c *cli.Context) error {
 			ctx := c.Context
 			port := c.Int("port")
-
-			tmpDir, err := os.MkdirTemp("", "testfs.")
-			if err != nil {
-				return err
+			dir := c.String("dir")
+			signingKey := []byte(c.String("url-signing-key"))
+
+			if dir == "" {
+				tmpDir, err := os.MkdirTemp("", "testfs.")
+				if err != nil {
+					return err
+				}
+				defer os.RemoveAll(tmpDir)
+				dir = tmpDir
+			} else {
+				if err := os.MkdirAll(dir, 0700); err != nil {
+					return err
+				}
+				if err := fileserver.RemoveTempFiles(dir); err != nil {
+					return err
+				}
+			}
+			if len(signingKey) == 0 {
+				log.Print("WARNING: --url-signing-key is not set; requests are not authorized")
 			}
-			defer os.RemoveAll(tmpDir)
 
 			server := http.Server{
 				Addr:        fmt.Sprintf("0.0.0.0:%d", port),
-				Handler:     &handler{tmpDir},
+				Handler:     &handler{srv: fileserver.New(dir, "/", signingKey)},
 				BaseContext: func(net.Listener) context.Context { return ctx },
 			}
 			log.Printf("INFO: Listening at %s", server.Addr)
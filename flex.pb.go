@@ -88,11 +88,61 @@ func (JobState) EnumDescriptor() ([]byte, []int) {
 	return file_flex_proto_rawDescGZIP(), []int{0}
 }
 
+type WebhookDeliveryState int32
+
+const (
+	WebhookDeliveryState_DELIVERY_PENDING   WebhookDeliveryState = 0
+	WebhookDeliveryState_DELIVERY_SUCCEEDED WebhookDeliveryState = 1
+	WebhookDeliveryState_DELIVERY_FAILED    WebhookDeliveryState = 2
+)
+
+// Enum value maps for WebhookDeliveryState.
+var (
+	WebhookDeliveryState_name = map[int32]string{
+		0: "DELIVERY_PENDING",
+		1: "DELIVERY_SUCCEEDED",
+		2: "DELIVERY_FAILED",
+	}
+	WebhookDeliveryState_value = map[string]int32{
+		"DELIVERY_PENDING":   0,
+		"DELIVERY_SUCCEEDED": 1,
+		"DELIVERY_FAILED":    2,
+	}
+)
+
+func (x WebhookDeliveryState) Enum() *WebhookDeliveryState {
+	p := new(WebhookDeliveryState)
+	*p = x
+	return p
+}
+
+func (x WebhookDeliveryState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookDeliveryState) Descriptor() protoreflect.EnumDescriptor {
+	return file_flex_proto_enumTypes[1].Descriptor()
+}
+
+func (WebhookDeliveryState) Type() protoreflect.EnumType {
+	return &file_flex_proto_enumTypes[1]
+}
+
+func (x WebhookDeliveryState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookDeliveryState.Descriptor instead.
+func (WebhookDeliveryState) EnumDescriptor() ([]byte, []int) {
+	return file_flex_proto_rawDescGZIP(), []int{1}
+}
+
 type FlexletState int32
 
 const (
-	FlexletState_OFFLINE FlexletState = 0
-	FlexletState_ONLINE  FlexletState = 1
+	FlexletState_OFFLINE  FlexletState = 0
+	FlexletState_ONLINE   FlexletState = 1
+	FlexletState_DRAINING FlexletState = 2
 )
 
 // Enum value maps for FlexletState.
@@ -100,10 +150,12 @@ var (
 	FlexletState_name = map[int32]string{
 		0: "OFFLINE",
 		1: "ONLINE",
+		2: "DRAINING",
 	}
 	FlexletState_value = map[string]int32{
-		"OFFLINE": 0,
-		"ONLINE":  1,
+		"OFFLINE":  0,
+		"ONLINE":   1,
+		"DRAINING": 2,
 	}
 )
 
@@ -118,11 +170,11 @@ func (x FlexletState) String() string {
 }
 
 func (FlexletState) Descriptor() protoreflect.EnumDescriptor {
-	return file_flex_proto_enumTypes[1].Descriptor()
+	return file_flex_proto_enumTypes[2].Descriptor()
 }
 
 func (FlexletState) Type() protoreflect.EnumType {
-	return &file_flex_proto_enumTypes[1]
+	return &file_flex_proto_enumTypes[2]
 }
 
 func (x FlexletState) Number() protoreflect.EnumNumber {
@@ -131,7 +183,7 @@ func (x FlexletState) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use FlexletState.Descriptor instead.
 func (FlexletState) EnumDescriptor() ([]byte, []int) {
-	return file_flex_proto_rawDescGZIP(), []int{1}
+	return file_flex_proto_rawDescGZIP(), []int{2}
 }
 
 type Job struct {
@@ -194,11 +246,12 @@ type JobSpec struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Command     *JobCommand     `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
-	Inputs      *JobInputs      `protobuf:"bytes,2,opt,name=inputs,proto3" json:"inputs,omitempty"`
-	Limits      *JobLimits      `protobuf:"bytes,3,opt,name=limits,proto3" json:"limits,omitempty"`
-	Constraints *JobConstraints `protobuf:"bytes,4,opt,name=constraints,proto3" json:"constraints,omitempty"`
-	Annotations *JobAnnotations `protobuf:"bytes,5,opt,name=annotations,proto3" json:"annotations,omitempty"`
+	Command       *JobCommand       `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Inputs        *JobInputs        `protobuf:"bytes,2,opt,name=inputs,proto3" json:"inputs,omitempty"`
+	Limits        *JobLimits        `protobuf:"bytes,3,opt,name=limits,proto3" json:"limits,omitempty"`
+	Constraints   *JobConstraints   `protobuf:"bytes,4,opt,name=constraints,proto3" json:"constraints,omitempty"`
+	Annotations   *JobAnnotations   `protobuf:"bytes,5,opt,name=annotations,proto3" json:"annotations,omitempty"`
+	Notifications *JobNotifications `protobuf:"bytes,6,opt,name=notifications,proto3" json:"notifications,omitempty"`
 }
 
 func (x *JobSpec) Reset() {
@@ -268,6 +321,86 @@ func (x *JobSpec) GetAnnotations() *JobAnnotations {
 	return nil
 }
 
+func (x *JobSpec) GetNotifications() *JobNotifications {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+type JobSpecOverrides struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Command *JobCommand `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	// limits are merged into those of the original job: only non-zero fields
+	// override.
+	Limits      *JobLimits      `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
+	Constraints *JobConstraints `protobuf:"bytes,3,opt,name=constraints,proto3" json:"constraints,omitempty"`
+	Annotations *JobAnnotations `protobuf:"bytes,4,opt,name=annotations,proto3" json:"annotations,omitempty"`
+}
+
+func (x *JobSpecOverrides) Reset() {
+	*x = JobSpecOverrides{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobSpecOverrides) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobSpecOverrides) ProtoMessage() {}
+
+func (x *JobSpecOverrides) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobSpecOverrides.ProtoReflect.Descriptor instead.
+func (*JobSpecOverrides) Descriptor() ([]byte, []int) {
+	return file_flex_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *JobSpecOverrides) GetCommand() *JobCommand {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *JobSpecOverrides) GetLimits() *JobLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *JobSpecOverrides) GetConstraints() *JobConstraints {
+	if x != nil {
+		return x.Constraints
+	}
+	return nil
+}
+
+func (x *JobSpecOverrides) GetAnnotations() *JobAnnotations {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
 type JobInputs struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -279,7 +412,7 @@ type JobInputs struct {
 func (x *JobInputs) Reset() {
 	*x = JobInputs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_proto_msgTypes[2]
+		mi := &file_flex_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -292,7 +425,7 @@ func (x *JobInputs) String() string {
 func (*JobInputs) ProtoMessage() {}
 
 func (x *JobInputs) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_proto_msgTypes[2]
+	mi := &file_flex_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -305,7 +438,7 @@ func (x *JobInputs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobInputs.ProtoReflect.Descriptor instead.
 func (*JobInputs) Descriptor() ([]byte, []int) {
-	return file_flex_proto_rawDescGZIP(), []int{2}
+	return file_flex_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *JobInputs) GetPackages() []*JobPackage {
@@ -328,7 +461,7 @@ type JobPackage struct {
 func (x *JobPackage) Reset() {
 	*x = JobPackage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_proto_msgTypes[3]
+		mi := &file_flex_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -341,7 +474,7 @@ func (x *JobPackage) String() string {
 func (*JobPackage) ProtoMessage() {}
 
 func (x *JobPackage) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_proto_msgTypes[3]
+	mi := &file_flex_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -354,7 +487,7 @@ func (x *JobPackage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobPackage.ProtoReflect.Descriptor instead.
 func (*JobPackage) Descriptor() ([]byte, []int) {
-	return file_flex_proto_rawDescGZIP(), []int{3}
+	return file_flex_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *JobPackage) GetHash() string {
@@ -389,7 +522,7 @@ type JobConstraints struct {
 func (x *JobConstraints) Reset() {
 	*x = JobConstraints{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_proto_msgTypes[4]
+		mi := &file_flex_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -402,7 +535,7 @@ func (x *JobConstraints) String() string {
 func (*JobConstraints) ProtoMessage() {}
 
 func (x *JobConstraints) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_proto_msgTypes[4]
+	mi := &file_flex_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -415,7 +548,7 @@ func (x *JobConstraints) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobConstraints.ProtoReflect.Descriptor instead.
 func (*JobConstraints) Descriptor() ([]byte, []int) {
-	return file_flex_proto_rawDescGZIP(), []int{4}
+	return file_flex_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *JobConstraints) GetPriority() int32 {
@@ -436,7 +569,7 @@ type JobAnnotations struct {
 func (x *JobAnnotations) Reset() {
 	*x = JobAnnotations{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_proto_msgTypes[5]
+		mi := &file_flex_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -449,7 +582,7 @@ func (x *JobAnnotations) String() string {
 func (*JobAnnotations) ProtoMessage() {}
 
 func (x *JobAnnotations) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_proto_msgTypes[5]
+	mi := &file_flex_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -462,7 +595,7 @@ func (x *JobAnnotations) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobAnnotations.ProtoReflect.Descriptor instead.
 func (*JobAnnotations) Descriptor() ([]byte, []int) {
-	return file_flex_proto_rawDescGZIP(), []int{5}
+	return file_flex_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *JobAnnotations) GetLabels() []string {
@@ -485,12 +618,14 @@ type JobStatus struct {
 	Created     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created,proto3" json:"created,omitempty"`
 	Started     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=started,proto3" json:"started,omitempty"`
 	Finished    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=finished,proto3" json:"finished,omitempty"`
+	ClonedFrom  int64                  `protobuf:"varint,9,opt,name=cloned_from,json=clonedFrom,proto3" json:"cloned_from,omitempty"`
+	ArrayId     int64                  `protobuf:"varint,10,opt,name=array_id,json=arrayId,proto3" json:"array_id,omitempty"`
 }
 
 func (x *JobStatus) Reset() {
 	*x = JobStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_proto_msgTypes[6]
+		mi := &file_flex_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -503,7 +638,7 @@ func (x *JobStatus) String() string {
 func (*JobStatus) ProtoMessage() {}
 
 func (x *JobStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_proto_msgTypes[6]
+	mi := &file_flex_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -516,7 +651,7 @@ func (x *JobStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobStatus.ProtoReflect.Descriptor instead.
 func (*JobStatus) Descriptor() ([]byte, []int) {
-	return file_flex_proto_rawDescGZIP(), []int{6}
+	return file_flex_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *JobStatus) GetJob() *Job {
@@ -575,32 +710,47 @@ func (x *JobStatus) GetFinished() *timestamppb.Timestamp {
 	return nil
 }
 
-type Package struct {
+func (x *JobStatus) GetClonedFrom() int64 {
+	if x != nil {
+		return x.ClonedFrom
+	}
+	return 0
+}
+
+func (x *JobStatus) GetArrayId() int64 {
+	if x != nil {
+		return x.ArrayId
+	}
+	return 0
+}
+
+type JobFilter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Hash string       `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	Spec *PackageSpec `protobuf:"bytes,2,opt,name=spec,proto3" json:"spec,omitempty"`
+	State   JobState `protobuf:"varint,1,opt,name=state,proto3,enum=flex.JobState" json:"state,omitempty"`
+	Label   string   `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	ArrayId int64    `protobuf:"varint,3,opt,name=array_id,json=arrayId,proto3" json:"array_id,omitempty"`
 }
 
-func (x *Package) Reset() {
-	*x = Package{}
+func (x *JobFilter) Reset() {
+	*x = JobFilter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_proto_msgTypes[7]
+		mi := &file_flex_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Package) String() string {
+func (x *JobFilter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Package) ProtoMessage() {}
+func (*JobFilter) ProtoMessage() {}
 
-func (x *Package) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_proto_msgTypes[7]
+func (x *JobFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -611,74 +761,44 @@ func (x *Package) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Package.ProtoReflect.Descriptor instead.
-func (*Package) Descriptor() ([]byte, []int) {
-	return file_flex_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use JobFilter.ProtoReflect.Descriptor instead.
+func (*JobFilter) Descriptor() ([]byte, []int) {
+	return file_flex_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Package) GetHash() string {
+func (x *JobFilter) GetState() JobState {
 	if x != nil {
-		return x.Hash
+		return x.State
 	}
-	return ""
+	return JobState_UNSPECIFIED
 }
 
-func (x *Package) GetSpec() *PackageSpec {
+func (x *JobFilter) GetLabel() string {
 	if x != nil {
-		return x.Spec
-	}
-	return nil
-}
-
-type PackageSpec struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-}
-
-func (x *PackageSpec) Reset() {
-	*x = PackageSpec{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_proto_msgTypes[8]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+		return x.Label
 	}
+	return ""
 }
 
-func (x *PackageSpec) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*PackageSpec) ProtoMessage() {}
-
-func (x *PackageSpec) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_proto_msgTypes[8]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *JobFilter) GetArrayId() int64 {
+	if x != nil {
+		return x.ArrayId
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use PackageSpec.ProtoReflect.Descriptor instead.
-func (*PackageSpec) Descriptor() ([]byte, []int) {
-	return file_flex_proto_rawDescGZIP(), []int{8}
+	return 0
 }
 
-type Tag struct {
+type JobArray struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Hash string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Start  int32    `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End    int32    `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	Params []string `protobuf:"bytes,3,rep,name=params,proto3" json:"params,omitempty"`
 }
 
-func (x *Tag) Reset() {
-	*x = Tag{}
+func (x *JobArray) Reset() {
+	*x = JobArray{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_flex_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -686,13 +806,13 @@ func (x *Tag) Reset() {
 	}
 }
 
-func (x *Tag) String() string {
+func (x *JobArray) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Tag) ProtoMessage() {}
+func (*JobArray) ProtoMessage() {}
 
-func (x *Tag) ProtoReflect() protoreflect.Message {
+func (x *JobArray) ProtoReflect() protoreflect.Message {
 	mi := &file_flex_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -704,37 +824,46 @@ func (x *Tag) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
-func (*Tag) Descriptor() ([]byte, []int) {
+// Deprecated: Use JobArray.ProtoReflect.Descriptor instead.
+func (*JobArray) Descriptor() ([]byte, []int) {
 	return file_flex_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *Tag) GetName() string {
+func (x *JobArray) GetStart() int32 {
 	if x != nil {
-		return x.Name
+		return x.Start
 	}
-	return ""
+	return 0
 }
 
-func (x *Tag) GetHash() string {
+func (x *JobArray) GetEnd() int32 {
 	if x != nil {
-		return x.Hash
+		return x.End
 	}
-	return ""
+	return 0
 }
 
-type FlexletStatus struct {
+func (x *JobArray) GetParams() []string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type JobArrayStatus struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Flexlet     *Flexlet     `protobuf:"bytes,1,opt,name=flexlet,proto3" json:"flexlet,omitempty"`
-	State       FlexletState `protobuf:"varint,2,opt,name=state,proto3,enum=flex.FlexletState" json:"state,omitempty"`
-	CurrentJobs []*Job       `protobuf:"bytes,3,rep,name=current_jobs,json=currentJobs,proto3" json:"current_jobs,omitempty"`
+	Id      int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Spec    *JobSpec               `protobuf:"bytes,2,opt,name=spec,proto3" json:"spec,omitempty"`
+	Array   *JobArray              `protobuf:"bytes,3,opt,name=array,proto3" json:"array,omitempty"`
+	Stats   *JobStats              `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`
+	Created *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created,proto3" json:"created,omitempty"`
 }
 
-func (x *FlexletStatus) Reset() {
-	*x = FlexletStatus{}
+func (x *JobArrayStatus) Reset() {
+	*x = JobArrayStatus{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_flex_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -742,13 +871,13 @@ func (x *FlexletStatus) Reset() {
 	}
 }
 
-func (x *FlexletStatus) String() string {
+func (x *JobArrayStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FlexletStatus) ProtoMessage() {}
+func (*JobArrayStatus) ProtoMessage() {}
 
-func (x *FlexletStatus) ProtoReflect() protoreflect.Message {
+func (x *JobArrayStatus) ProtoReflect() protoreflect.Message {
 	mi := &file_flex_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -760,43 +889,58 @@ func (x *FlexletStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FlexletStatus.ProtoReflect.Descriptor instead.
-func (*FlexletStatus) Descriptor() ([]byte, []int) {
+// Deprecated: Use JobArrayStatus.ProtoReflect.Descriptor instead.
+func (*JobArrayStatus) Descriptor() ([]byte, []int) {
 	return file_flex_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *FlexletStatus) GetFlexlet() *Flexlet {
+func (x *JobArrayStatus) GetId() int64 {
 	if x != nil {
-		return x.Flexlet
+		return x.Id
+	}
+	return 0
+}
+
+func (x *JobArrayStatus) GetSpec() *JobSpec {
+	if x != nil {
+		return x.Spec
 	}
 	return nil
 }
 
-func (x *FlexletStatus) GetState() FlexletState {
+func (x *JobArrayStatus) GetArray() *JobArray {
 	if x != nil {
-		return x.State
+		return x.Array
 	}
-	return FlexletState_OFFLINE
+	return nil
 }
 
-func (x *FlexletStatus) GetCurrentJobs() []*Job {
+func (x *JobArrayStatus) GetStats() *JobStats {
 	if x != nil {
-		return x.CurrentJobs
+		return x.Stats
 	}
 	return nil
 }
 
-type Flexlet struct {
+func (x *JobArrayStatus) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+type BulkJobResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Spec *FlexletSpec `protobuf:"bytes,2,opt,name=spec,proto3" json:"spec,omitempty"`
+	Id    int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ok    bool   `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
 }
 
-func (x *Flexlet) Reset() {
-	*x = Flexlet{}
+func (x *BulkJobResult) Reset() {
+	*x = BulkJobResult{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_flex_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -804,13 +948,13 @@ func (x *Flexlet) Reset() {
 	}
 }
 
-func (x *Flexlet) String() string {
+func (x *BulkJobResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Flexlet) ProtoMessage() {}
+func (*BulkJobResult) ProtoMessage() {}
 
-func (x *Flexlet) ProtoReflect() protoreflect.Message {
+func (x *BulkJobResult) ProtoReflect() protoreflect.Message {
 	mi := &file_flex_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -822,70 +966,993 @@ func (x *Flexlet) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Flexlet.ProtoReflect.Descriptor instead.
-func (*Flexlet) Descriptor() ([]byte, []int) {
+// Deprecated: Use BulkJobResult.ProtoReflect.Descriptor instead.
+func (*BulkJobResult) Descriptor() ([]byte, []int) {
 	return file_flex_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *Flexlet) GetName() string {
+func (x *BulkJobResult) GetId() int64 {
 	if x != nil {
-		return x.Name
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BulkJobResult) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *BulkJobResult) GetError() string {
+	if x != nil {
+		return x.Error
 	}
 	return ""
 }
 
-func (x *Flexlet) GetSpec() *FlexletSpec {
+type Package struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash string       `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Spec *PackageSpec `protobuf:"bytes,2,opt,name=spec,proto3" json:"spec,omitempty"`
+}
+
+func (x *Package) Reset() {
+	*x = Package{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Package) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Package) ProtoMessage() {}
+
+func (x *Package) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Package.ProtoReflect.Descriptor instead.
+func (*Package) Descriptor() ([]byte, []int) {
+	return file_flex_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Package) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *Package) GetSpec() *PackageSpec {
 	if x != nil {
 		return x.Spec
 	}
 	return nil
 }
 
-type FlexletSpec struct {
+type PackageSpec struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+}
 
-	Cores int32 `protobuf:"varint,1,opt,name=cores,proto3" json:"cores,omitempty"`
+func (x *PackageSpec) Reset() {
+	*x = PackageSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PackageSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageSpec) ProtoMessage() {}
+
+func (x *PackageSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageSpec.ProtoReflect.Descriptor instead.
+func (*PackageSpec) Descriptor() ([]byte, []int) {
+	return file_flex_proto_rawDescGZIP(), []int{13}
+}
+
+type Tag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Hash string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_flex_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tag) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type FlexletStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Flexlet     *Flexlet               `protobuf:"bytes,1,opt,name=flexlet,proto3" json:"flexlet,omitempty"`
+	State       FlexletState           `protobuf:"varint,2,opt,name=state,proto3,enum=flex.FlexletState" json:"state,omitempty"`
+	CurrentJobs []*Job                 `protobuf:"bytes,3,rep,name=current_jobs,json=currentJobs,proto3" json:"current_jobs,omitempty"`
+	Cordoned    bool                   `protobuf:"varint,4,opt,name=cordoned,proto3" json:"cordoned,omitempty"`
+	LastUpdate  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_update,json=lastUpdate,proto3" json:"last_update,omitempty"`
+	Host        *FlexletHostInfo       `protobuf:"bytes,6,opt,name=host,proto3" json:"host,omitempty"`
+	Unhealthy   bool                   `protobuf:"varint,7,opt,name=unhealthy,proto3" json:"unhealthy,omitempty"`
+	HealthError string                 `protobuf:"bytes,8,opt,name=health_error,json=healthError,proto3" json:"health_error,omitempty"`
+	LostTasks   int32                  `protobuf:"varint,9,opt,name=lost_tasks,json=lostTasks,proto3" json:"lost_tasks,omitempty"`
+}
+
+func (x *FlexletStatus) Reset() {
+	*x = FlexletStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlexletStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlexletStatus) ProtoMessage() {}
+
+func (x *FlexletStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlexletStatus.ProtoReflect.Descriptor instead.
+func (*FlexletStatus) Descriptor() ([]byte, []int) {
+	return file_flex_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FlexletStatus) GetFlexlet() *Flexlet {
+	if x != nil {
+		return x.Flexlet
+	}
+	return nil
+}
+
+func (x *FlexletStatus) GetState() FlexletState {
+	if x != nil {
+		return x.State
+	}
+	return FlexletState_OFFLINE
+}
+
+func (x *FlexletStatus) GetCurrentJobs() []*Job {
+	if x != nil {
+		return x.CurrentJobs
+	}
+	return nil
+}
+
+func (x *FlexletStatus) GetCordoned() bool {
+	if x != nil {
+		return x.Cordoned
+	}
+	return false
+}
+
+func (x *FlexletStatus) GetLastUpdate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdate
+	}
+	return nil
+}
+
+func (x *FlexletStatus) GetHost() *FlexletHostInfo {
+	if x != nil {
+		return x.Host
+	}
+	return nil
+}
+
+func (x *FlexletStatus) GetUnhealthy() bool {
+	if x != nil {
+		return x.Unhealthy
+	}
+	return false
+}
+
+func (x *FlexletStatus) GetHealthError() string {
+	if x != nil {
+		return x.HealthError
+	}
+	return ""
+}
+
+func (x *FlexletStatus) GetLostTasks() int32 {
+	if x != nil {
+		return x.LostTasks
+	}
+	return 0
+}
+
+type Flexlet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Spec *FlexletSpec `protobuf:"bytes,2,opt,name=spec,proto3" json:"spec,omitempty"`
+}
+
+func (x *Flexlet) Reset() {
+	*x = Flexlet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Flexlet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Flexlet) ProtoMessage() {}
+
+func (x *Flexlet) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Flexlet.ProtoReflect.Descriptor instead.
+func (*Flexlet) Descriptor() ([]byte, []int) {
+	return file_flex_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Flexlet) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Flexlet) GetSpec() *FlexletSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+type FlexletSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cores int32 `protobuf:"varint,1,opt,name=cores,proto3" json:"cores,omitempty"`
+}
+
+func (x *FlexletSpec) Reset() {
+	*x = FlexletSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlexletSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlexletSpec) ProtoMessage() {}
+
+func (x *FlexletSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlexletSpec.ProtoReflect.Descriptor instead.
+func (*FlexletSpec) Descriptor() ([]byte, []int) {
+	return file_flex_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FlexletSpec) GetCores() int32 {
+	if x != nil {
+		return x.Cores
+	}
+	return 0
+}
+
+type FlexletHostInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version          string               `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Os               string               `protobuf:"bytes,2,opt,name=os,proto3" json:"os,omitempty"`
+	Kernel           string               `protobuf:"bytes,3,opt,name=kernel,proto3" json:"kernel,omitempty"`
+	TotalMemoryBytes int64                `protobuf:"varint,4,opt,name=total_memory_bytes,json=totalMemoryBytes,proto3" json:"total_memory_bytes,omitempty"`
+	FreeMemoryBytes  int64                `protobuf:"varint,5,opt,name=free_memory_bytes,json=freeMemoryBytes,proto3" json:"free_memory_bytes,omitempty"`
+	DiskFreeBytes    int64                `protobuf:"varint,6,opt,name=disk_free_bytes,json=diskFreeBytes,proto3" json:"disk_free_bytes,omitempty"`
+	CacheBytes       int64                `protobuf:"varint,7,opt,name=cache_bytes,json=cacheBytes,proto3" json:"cache_bytes,omitempty"`
+	LoadAverage      []float64            `protobuf:"fixed64,8,rep,packed,name=load_average,json=loadAverage,proto3" json:"load_average,omitempty"`
+	Uptime           *durationpb.Duration `protobuf:"bytes,9,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	Cache            *FlexletCacheStats   `protobuf:"bytes,10,opt,name=cache,proto3" json:"cache,omitempty"`
+}
+
+func (x *FlexletHostInfo) Reset() {
+	*x = FlexletHostInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlexletHostInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlexletHostInfo) ProtoMessage() {}
+
+func (x *FlexletHostInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlexletHostInfo.ProtoReflect.Descriptor instead.
+func (*FlexletHostInfo) Descriptor() ([]byte, []int) {
+	return file_flex_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *FlexletHostInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *FlexletHostInfo) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *FlexletHostInfo) GetKernel() string {
+	if x != nil {
+		return x.Kernel
+	}
+	return ""
+}
+
+func (x *FlexletHostInfo) GetTotalMemoryBytes() int64 {
+	if x != nil {
+		return x.TotalMemoryBytes
+	}
+	return 0
+}
+
+func (x *FlexletHostInfo) GetFreeMemoryBytes() int64 {
+	if x != nil {
+		return x.FreeMemoryBytes
+	}
+	return 0
+}
+
+func (x *FlexletHostInfo) GetDiskFreeBytes() int64 {
+	if x != nil {
+		return x.DiskFreeBytes
+	}
+	return 0
+}
+
+func (x *FlexletHostInfo) GetCacheBytes() int64 {
+	if x != nil {
+		return x.CacheBytes
+	}
+	return 0
+}
+
+func (x *FlexletHostInfo) GetLoadAverage() []float64 {
+	if x != nil {
+		return x.LoadAverage
+	}
+	return nil
+}
+
+func (x *FlexletHostInfo) GetUptime() *durationpb.Duration {
+	if x != nil {
+		return x.Uptime
+	}
+	return nil
+}
+
+func (x *FlexletHostInfo) GetCache() *FlexletCacheStats {
+	if x != nil {
+		return x.Cache
+	}
+	return nil
+}
+
+type FlexletCacheStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries int32 `protobuf:"varint,1,opt,name=entries,proto3" json:"entries,omitempty"`
+	// max_bytes is the maximum size of the cache. 0 means no limit.
+	MaxBytes  int64 `protobuf:"varint,2,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	Hits      int64 `protobuf:"varint,3,opt,name=hits,proto3" json:"hits,omitempty"`
+	Misses    int64 `protobuf:"varint,4,opt,name=misses,proto3" json:"misses,omitempty"`
+	Evictions int64 `protobuf:"varint,5,opt,name=evictions,proto3" json:"evictions,omitempty"`
+}
+
+func (x *FlexletCacheStats) Reset() {
+	*x = FlexletCacheStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlexletCacheStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlexletCacheStats) ProtoMessage() {}
+
+func (x *FlexletCacheStats) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlexletCacheStats.ProtoReflect.Descriptor instead.
+func (*FlexletCacheStats) Descriptor() ([]byte, []int) {
+	return file_flex_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FlexletCacheStats) GetEntries() int32 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+func (x *FlexletCacheStats) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+func (x *FlexletCacheStats) GetHits() int64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *FlexletCacheStats) GetMisses() int64 {
+	if x != nil {
+		return x.Misses
+	}
+	return 0
+}
+
+func (x *FlexletCacheStats) GetEvictions() int64 {
+	if x != nil {
+		return x.Evictions
+	}
+	return 0
+}
+
+type FlexletTask struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TaskId   string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	JobId    int64                  `protobuf:"varint,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Result   *TaskResult            `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	Started  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=started,proto3" json:"started,omitempty"`
+	Finished *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=finished,proto3" json:"finished,omitempty"`
+	Lost     bool                   `protobuf:"varint,6,opt,name=lost,proto3" json:"lost,omitempty"`
+}
+
+func (x *FlexletTask) Reset() {
+	*x = FlexletTask{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlexletTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlexletTask) ProtoMessage() {}
+
+func (x *FlexletTask) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlexletTask.ProtoReflect.Descriptor instead.
+func (*FlexletTask) Descriptor() ([]byte, []int) {
+	return file_flex_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *FlexletTask) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *FlexletTask) GetJobId() int64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *FlexletTask) GetResult() *TaskResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *FlexletTask) GetStarted() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Started
+	}
+	return nil
+}
+
+func (x *FlexletTask) GetFinished() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Finished
+	}
+	return nil
+}
+
+func (x *FlexletTask) GetLost() bool {
+	if x != nil {
+		return x.Lost
+	}
+	return false
+}
+
+type FlexletTaskStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FinishedTasks int32 `protobuf:"varint,1,opt,name=finished_tasks,json=finishedTasks,proto3" json:"finished_tasks,omitempty"`
+	FailedTasks   int32 `protobuf:"varint,2,opt,name=failed_tasks,json=failedTasks,proto3" json:"failed_tasks,omitempty"`
+	LostTasks     int32 `protobuf:"varint,3,opt,name=lost_tasks,json=lostTasks,proto3" json:"lost_tasks,omitempty"`
+}
+
+func (x *FlexletTaskStats) Reset() {
+	*x = FlexletTaskStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FlexletTaskStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlexletTaskStats) ProtoMessage() {}
+
+func (x *FlexletTaskStats) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlexletTaskStats.ProtoReflect.Descriptor instead.
+func (*FlexletTaskStats) Descriptor() ([]byte, []int) {
+	return file_flex_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *FlexletTaskStats) GetFinishedTasks() int32 {
+	if x != nil {
+		return x.FinishedTasks
+	}
+	return 0
+}
+
+func (x *FlexletTaskStats) GetFailedTasks() int32 {
+	if x != nil {
+		return x.FailedTasks
+	}
+	return 0
+}
+
+func (x *FlexletTaskStats) GetLostTasks() int32 {
+	if x != nil {
+		return x.LostTasks
+	}
+	return 0
+}
+
+type JobEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId       int64                  `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	State       JobState               `protobuf:"varint,2,opt,name=state,proto3,enum=flex.JobState" json:"state,omitempty"`
+	Labels      []string               `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty"`
+	Constraints *JobConstraints        `protobuf:"bytes,4,opt,name=constraints,proto3" json:"constraints,omitempty"`
+	ArrayId     int64                  `protobuf:"varint,5,opt,name=array_id,json=arrayId,proto3" json:"array_id,omitempty"`
+	Time        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *JobEvent) Reset() {
+	*x = JobEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobEvent) ProtoMessage() {}
+
+func (x *JobEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobEvent.ProtoReflect.Descriptor instead.
+func (*JobEvent) Descriptor() ([]byte, []int) {
+	return file_flex_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *JobEvent) GetJobId() int64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *JobEvent) GetState() JobState {
+	if x != nil {
+		return x.State
+	}
+	return JobState_UNSPECIFIED
+}
+
+func (x *JobEvent) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *JobEvent) GetConstraints() *JobConstraints {
+	if x != nil {
+		return x.Constraints
+	}
+	return nil
+}
+
+func (x *JobEvent) GetArrayId() int64 {
+	if x != nil {
+		return x.ArrayId
+	}
+	return 0
+}
+
+func (x *JobEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type JobNotifications struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Webhooks []string `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+}
+
+func (x *JobNotifications) Reset() {
+	*x = JobNotifications{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobNotifications) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobNotifications) ProtoMessage() {}
+
+func (x *JobNotifications) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobNotifications.ProtoReflect.Descriptor instead.
+func (*JobNotifications) Descriptor() ([]byte, []int) {
+	return file_flex_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *JobNotifications) GetWebhooks() []string {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+type WebhookDelivery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	JobId       int64                  `protobuf:"varint,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Url         string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Event       JobState               `protobuf:"varint,4,opt,name=event,proto3,enum=flex.JobState" json:"event,omitempty"`
+	State       WebhookDeliveryState   `protobuf:"varint,5,opt,name=state,proto3,enum=flex.WebhookDeliveryState" json:"state,omitempty"`
+	Attempts    int32                  `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	StatusCode  int32                  `protobuf:"varint,7,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Error       string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	Created     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created,proto3" json:"created,omitempty"`
+	LastAttempt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_attempt,json=lastAttempt,proto3" json:"last_attempt,omitempty"`
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_flex_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *WebhookDelivery) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetJobId() int64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEvent() JobState {
+	if x != nil {
+		return x.Event
+	}
+	return JobState_UNSPECIFIED
 }
 
-func (x *FlexletSpec) Reset() {
-	*x = FlexletSpec{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_proto_msgTypes[12]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *WebhookDelivery) GetState() WebhookDeliveryState {
+	if x != nil {
+		return x.State
 	}
+	return WebhookDeliveryState_DELIVERY_PENDING
 }
 
-func (x *FlexletSpec) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
 }
 
-func (*FlexletSpec) ProtoMessage() {}
+func (x *WebhookDelivery) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
 
-func (x *FlexletSpec) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_proto_msgTypes[12]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *WebhookDelivery) GetError() string {
+	if x != nil {
+		return x.Error
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use FlexletSpec.ProtoReflect.Descriptor instead.
-func (*FlexletSpec) Descriptor() ([]byte, []int) {
-	return file_flex_proto_rawDescGZIP(), []int{12}
+func (x *WebhookDelivery) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
 }
 
-func (x *FlexletSpec) GetCores() int32 {
+func (x *WebhookDelivery) GetLastAttempt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Cores
+		return x.LastAttempt
 	}
-	return 0
+	return nil
 }
 
 type JobCommand struct {
@@ -894,12 +1961,13 @@ type JobCommand struct {
 	unknownFields protoimpl.UnknownFields
 
 	Args []string `protobuf:"bytes,1,rep,name=args,proto3" json:"args,omitempty"`
+	Env  []string `protobuf:"bytes,2,rep,name=env,proto3" json:"env,omitempty"`
 }
 
 func (x *JobCommand) Reset() {
 	*x = JobCommand{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_proto_msgTypes[13]
+		mi := &file_flex_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -912,7 +1980,7 @@ func (x *JobCommand) String() string {
 func (*JobCommand) ProtoMessage() {}
 
 func (x *JobCommand) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_proto_msgTypes[13]
+	mi := &file_flex_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -925,7 +1993,7 @@ func (x *JobCommand) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobCommand.ProtoReflect.Descriptor instead.
 func (*JobCommand) Descriptor() ([]byte, []int) {
-	return file_flex_proto_rawDescGZIP(), []int{13}
+	return file_flex_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *JobCommand) GetArgs() []string {
@@ -935,18 +2003,32 @@ func (x *JobCommand) GetArgs() []string {
 	return nil
 }
 
+func (x *JobCommand) GetEnv() []string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
 type JobLimits struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Time *durationpb.Duration `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// max_output_bytes limits the size of each of stdout and stderr. If an
+	// output exceeds it, only its first and last halves are kept. Zero means
+	// no limit.
+	MaxOutputBytes int64 `protobuf:"varint,2,opt,name=max_output_bytes,json=maxOutputBytes,proto3" json:"max_output_bytes,omitempty"`
+	// kill_on_output_overflow kills the job when an output exceeds
+	// max_output_bytes.
+	KillOnOutputOverflow bool `protobuf:"varint,3,opt,name=kill_on_output_overflow,json=killOnOutputOverflow,proto3" json:"kill_on_output_overflow,omitempty"`
 }
 
 func (x *JobLimits) Reset() {
 	*x = JobLimits{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_proto_msgTypes[14]
+		mi := &file_flex_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -959,7 +2041,7 @@ func (x *JobLimits) String() string {
 func (*JobLimits) ProtoMessage() {}
 
 func (x *JobLimits) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_proto_msgTypes[14]
+	mi := &file_flex_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -972,7 +2054,7 @@ func (x *JobLimits) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobLimits.ProtoReflect.Descriptor instead.
 func (*JobLimits) Descriptor() ([]byte, []int) {
-	return file_flex_proto_rawDescGZIP(), []int{14}
+	return file_flex_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *JobLimits) GetTime() *durationpb.Duration {
@@ -982,6 +2064,20 @@ func (x *JobLimits) GetTime() *durationpb.Duration {
 	return nil
 }
 
+func (x *JobLimits) GetMaxOutputBytes() int64 {
+	if x != nil {
+		return x.MaxOutputBytes
+	}
+	return 0
+}
+
+func (x *JobLimits) GetKillOnOutputOverflow() bool {
+	if x != nil {
+		return x.KillOnOutputOverflow
+	}
+	return false
+}
+
 type TaskResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -990,12 +2086,22 @@ type TaskResult struct {
 	ExitCode int32                `protobuf:"varint,1,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
 	Message  string               `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	Time     *durationpb.Duration `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// stdout_bytes and stderr_bytes are the sizes of outputs written by the
+	// job, before truncation.
+	StdoutBytes int64 `protobuf:"varint,4,opt,name=stdout_bytes,json=stdoutBytes,proto3" json:"stdout_bytes,omitempty"`
+	StderrBytes int64 `protobuf:"varint,5,opt,name=stderr_bytes,json=stderrBytes,proto3" json:"stderr_bytes,omitempty"`
+	// output_truncated is set if stdout or stderr was truncated because it
+	// exceeded max_output_bytes.
+	OutputTruncated bool `protobuf:"varint,6,opt,name=output_truncated,json=outputTruncated,proto3" json:"output_truncated,omitempty"`
+	// output_encoding is the content encoding of stored outputs, e.g. "gzip".
+	// It is empty if outputs are stored as is.
+	OutputEncoding string `protobuf:"bytes,7,opt,name=output_encoding,json=outputEncoding,proto3" json:"output_encoding,omitempty"`
 }
 
 func (x *TaskResult) Reset() {
 	*x = TaskResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_proto_msgTypes[15]
+		mi := &file_flex_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1008,7 +2114,7 @@ func (x *TaskResult) String() string {
 func (*TaskResult) ProtoMessage() {}
 
 func (x *TaskResult) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_proto_msgTypes[15]
+	mi := &file_flex_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1021,7 +2127,7 @@ func (x *TaskResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TaskResult.ProtoReflect.Descriptor instead.
 func (*TaskResult) Descriptor() ([]byte, []int) {
-	return file_flex_proto_rawDescGZIP(), []int{15}
+	return file_flex_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *TaskResult) GetExitCode() int32 {
@@ -1045,6 +2151,34 @@ func (x *TaskResult) GetTime() *durationpb.Duration {
 	return nil
 }
 
+func (x *TaskResult) GetStdoutBytes() int64 {
+	if x != nil {
+		return x.StdoutBytes
+	}
+	return 0
+}
+
+func (x *TaskResult) GetStderrBytes() int64 {
+	if x != nil {
+		return x.StderrBytes
+	}
+	return 0
+}
+
+func (x *TaskResult) GetOutputTruncated() bool {
+	if x != nil {
+		return x.OutputTruncated
+	}
+	return false
+}
+
+func (x *TaskResult) GetOutputEncoding() string {
+	if x != nil {
+		return x.OutputEncoding
+	}
+	return ""
+}
+
 type FileLocation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1057,7 +2191,7 @@ type FileLocation struct {
 func (x *FileLocation) Reset() {
 	*x = FileLocation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_proto_msgTypes[16]
+		mi := &file_flex_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1070,7 +2204,7 @@ func (x *FileLocation) String() string {
 func (*FileLocation) ProtoMessage() {}
 
 func (x *FileLocation) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_proto_msgTypes[16]
+	mi := &file_flex_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1083,7 +2217,7 @@ func (x *FileLocation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FileLocation.ProtoReflect.Descriptor instead.
 func (*FileLocation) Descriptor() ([]byte, []int) {
-	return file_flex_proto_rawDescGZIP(), []int{16}
+	return file_flex_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *FileLocation) GetCanonicalUrl() string {
@@ -1112,7 +2246,7 @@ type Stats struct {
 func (x *Stats) Reset() {
 	*x = Stats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_proto_msgTypes[17]
+		mi := &file_flex_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1125,7 +2259,7 @@ func (x *Stats) String() string {
 func (*Stats) ProtoMessage() {}
 
 func (x *Stats) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_proto_msgTypes[17]
+	mi := &file_flex_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1138,7 +2272,7 @@ func (x *Stats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Stats.ProtoReflect.Descriptor instead.
 func (*Stats) Descriptor() ([]byte, []int) {
-	return file_flex_proto_rawDescGZIP(), []int{17}
+	return file_flex_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *Stats) GetJob() *JobStats {
@@ -1160,14 +2294,15 @@ type JobStats struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PendingJobs int32 `protobuf:"varint,1,opt,name=pending_jobs,json=pendingJobs,proto3" json:"pending_jobs,omitempty"`
-	RunningJobs int32 `protobuf:"varint,2,opt,name=running_jobs,json=runningJobs,proto3" json:"running_jobs,omitempty"`
+	PendingJobs  int32 `protobuf:"varint,1,opt,name=pending_jobs,json=pendingJobs,proto3" json:"pending_jobs,omitempty"`
+	RunningJobs  int32 `protobuf:"varint,2,opt,name=running_jobs,json=runningJobs,proto3" json:"running_jobs,omitempty"`
+	FinishedJobs int32 `protobuf:"varint,3,opt,name=finished_jobs,json=finishedJobs,proto3" json:"finished_jobs,omitempty"`
 }
 
 func (x *JobStats) Reset() {
 	*x = JobStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_proto_msgTypes[18]
+		mi := &file_flex_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1180,7 +2315,7 @@ func (x *JobStats) String() string {
 func (*JobStats) ProtoMessage() {}
 
 func (x *JobStats) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_proto_msgTypes[18]
+	mi := &file_flex_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1193,7 +2328,7 @@ func (x *JobStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JobStats.ProtoReflect.Descriptor instead.
 func (*JobStats) Descriptor() ([]byte, []int) {
-	return file_flex_proto_rawDescGZIP(), []int{18}
+	return file_flex_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *JobStats) GetPendingJobs() int32 {
@@ -1210,6 +2345,13 @@ func (x *JobStats) GetRunningJobs() int32 {
 	return 0
 }
 
+func (x *JobStats) GetFinishedJobs() int32 {
+	if x != nil {
+		return x.FinishedJobs
+	}
+	return 0
+}
+
 type FlexletStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1224,7 +2366,7 @@ type FlexletStats struct {
 func (x *FlexletStats) Reset() {
 	*x = FlexletStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_proto_msgTypes[19]
+		mi := &file_flex_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1237,7 +2379,7 @@ func (x *FlexletStats) String() string {
 func (*FlexletStats) ProtoMessage() {}
 
 func (x *FlexletStats) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_proto_msgTypes[19]
+	mi := &file_flex_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1250,7 +2392,7 @@ func (x *FlexletStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FlexletStats.ProtoReflect.Descriptor instead.
 func (*FlexletStats) Descriptor() ([]byte, []int) {
-	return file_flex_proto_rawDescGZIP(), []int{19}
+	return file_flex_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *FlexletStats) GetOnlineFlexlets() int32 {
@@ -1292,7 +2434,7 @@ var file_flex_proto_rawDesc = []byte{
 	0x6f, 0x74, 0x6f, 0x22, 0x38, 0x0a, 0x03, 0x4a, 0x6f, 0x62, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x21, 0x0a, 0x04, 0x73, 0x70,
 	0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e,
-	0x4a, 0x6f, 0x62, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x22, 0xf7, 0x01,
+	0x4a, 0x6f, 0x62, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x22, 0xb5, 0x02,
 	0x0a, 0x07, 0x4a, 0x6f, 0x62, 0x53, 0x70, 0x65, 0x63, 0x12, 0x2a, 0x0a, 0x07, 0x63, 0x6f, 0x6d,
 	0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66, 0x6c, 0x65,
 	0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x07, 0x63, 0x6f,
@@ -1308,116 +2450,301 @@ var file_flex_proto_rawDesc = []byte{
 	0x36, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x41,
 	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f,
-	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x39, 0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x49, 0x6e,
-	0x70, 0x75, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f,
-	0x62, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
-	0x65, 0x73, 0x22, 0x53, 0x0a, 0x0a, 0x4a, 0x6f, 0x62, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x68, 0x61, 0x73, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c,
-	0x6c, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73,
-	0x74, 0x61, 0x6c, 0x6c, 0x44, 0x69, 0x72, 0x22, 0x2c, 0x0a, 0x0e, 0x4a, 0x6f, 0x62, 0x43, 0x6f,
-	0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69,
-	0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x69,
-	0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x28, 0x0a, 0x0e, 0x4a, 0x6f, 0x62, 0x41, 0x6e, 0x6e, 0x6f,
-	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x22,
-	0xd8, 0x02, 0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a,
-	0x03, 0x6a, 0x6f, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x66, 0x6c, 0x65,
-	0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x24, 0x0a, 0x05, 0x73, 0x74,
-	0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x66, 0x6c, 0x65, 0x78,
-	0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x12, 0x17, 0x0a, 0x07, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x74, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x6c, 0x65,
-	0x78, 0x6c, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x66, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x06,
-	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66,
-	0x6c, 0x65, 0x78, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06,
-	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x07,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74,
-	0x65, 0x64, 0x12, 0x36, 0x0a, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x08,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3c, 0x0a, 0x0d, 0x6e, 0x6f, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0d, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xd7, 0x01, 0x0a, 0x10, 0x4a, 0x6f, 0x62, 0x53, 0x70, 0x65,
+	0x63, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x07, 0x63, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66, 0x6c,
+	0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x07, 0x63,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x27, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f,
+	0x62, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12,
+	0x36, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x43,
+	0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x73,
+	0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x66,
+	0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22,
+	0x39, 0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x08,
+	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x52, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x22, 0x53, 0x0a, 0x0a, 0x4a, 0x6f,
+	0x62, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x10, 0x0a, 0x03,
+	0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x1f,
+	0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x44, 0x69, 0x72, 0x22,
+	0x2c, 0x0a, 0x0e, 0x4a, 0x6f, 0x62, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x28, 0x0a,
+	0x0e, 0x4a, 0x6f, 0x62, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x22, 0x94, 0x03, 0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x09, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x03, 0x6a,
+	0x6f, 0x62, 0x12, 0x24, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x0e, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x61, 0x73, 0x6b,
+	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x73, 0x6b, 0x49,
+	0x64, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x54, 0x61, 0x73, 0x6b,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x34,
+	0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x08, 0x66, 0x69,
+	0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68,
+	0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6c, 0x6f, 0x6e, 0x65, 0x64, 0x5f, 0x66, 0x72, 0x6f,
+	0x6d, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x6c, 0x6f, 0x6e, 0x65, 0x64, 0x46,
+	0x72, 0x6f, 0x6d, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x72, 0x72, 0x61, 0x79, 0x5f, 0x69, 0x64, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x61, 0x72, 0x72, 0x61, 0x79, 0x49, 0x64, 0x22, 0x62,
+	0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x66, 0x6c, 0x65,
+	0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x72, 0x72, 0x61, 0x79,
+	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x61, 0x72, 0x72, 0x61, 0x79,
+	0x49, 0x64, 0x22, 0x4a, 0x0a, 0x08, 0x4a, 0x6f, 0x62, 0x41, 0x72, 0x72, 0x61, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0xc5,
+	0x01, 0x0a, 0x0e, 0x4a, 0x6f, 0x62, 0x41, 0x72, 0x72, 0x61, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x21, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04,
+	0x73, 0x70, 0x65, 0x63, 0x12, 0x24, 0x0a, 0x05, 0x61, 0x72, 0x72, 0x61, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x41, 0x72,
+	0x72, 0x61, 0x79, 0x52, 0x05, 0x61, 0x72, 0x72, 0x61, 0x79, 0x12, 0x24, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x66, 0x6c, 0x65, 0x78,
+	0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x34, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x22, 0x45, 0x0a, 0x0d, 0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f,
+	0x62, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x44, 0x0a,
+	0x07, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x25, 0x0a, 0x04,
+	0x73, 0x70, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x66, 0x6c, 0x65,
+	0x78, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x73,
+	0x70, 0x65, 0x63, 0x22, 0x0d, 0x0a, 0x0b, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x70,
+	0x65, 0x63, 0x22, 0x2d, 0x0a, 0x03, 0x54, 0x61, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73,
+	0x68, 0x22, 0xf4, 0x02, 0x0a, 0x0d, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x27, 0x0a, 0x07, 0x66, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x46, 0x6c, 0x65, 0x78,
+	0x6c, 0x65, 0x74, 0x52, 0x07, 0x66, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x12, 0x28, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x66, 0x6c,
+	0x65, 0x78, 0x2e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2c, 0x0a, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x5f, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x66,
+	0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x0b, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x4a, 0x6f, 0x62, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x63, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x65, 0x64,
+	0x12, 0x3b, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x29, 0x0a,
+	0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x66, 0x6c,
+	0x65, 0x78, 0x2e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x6e, 0x68, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x75, 0x6e, 0x68,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x68, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x6f, 0x73,
+	0x74, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6c,
+	0x6f, 0x73, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x22, 0x44, 0x0a, 0x07, 0x46, 0x6c, 0x65, 0x78,
+	0x6c, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x46, 0x6c, 0x65,
+	0x78, 0x6c, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x22, 0x23,
+	0x0a, 0x0b, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f,
+	0x72, 0x65, 0x73, 0x22, 0xfb, 0x02, 0x0a, 0x0f, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x48,
+	0x6f, 0x73, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x66, 0x72, 0x65, 0x65, 0x5f,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0f, 0x66, 0x72, 0x65, 0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x66, 0x72, 0x65, 0x65,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x64, 0x69,
+	0x73, 0x6b, 0x46, 0x72, 0x65, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x63,
+	0x61, 0x63, 0x68, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x63, 0x61, 0x63, 0x68, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c,
+	0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x03,
+	0x28, 0x01, 0x52, 0x0b, 0x6c, 0x6f, 0x61, 0x64, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12,
+	0x31, 0x0a, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x75, 0x70, 0x74, 0x69,
+	0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x63, 0x61, 0x63, 0x68,
+	0x65, 0x22, 0x94, 0x01, 0x0a, 0x11, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x69, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x68, 0x69,
+	0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x76,
+	0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65,
+	0x76, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xe9, 0x01, 0x0a, 0x0b, 0x46, 0x6c, 0x65,
+	0x78, 0x6c, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x61, 0x73, 0x6b,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x73, 0x6b, 0x49,
+	0x64, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e,
+	0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x34, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x08, 0x66, 0x69, 0x6e, 0x69,
+	0x73, 0x68, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04,
+	0x6c, 0x6f, 0x73, 0x74, 0x22, 0x7b, 0x0a, 0x10, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x54,
+	0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x69, 0x6e, 0x69,
+	0x73, 0x68, 0x65, 0x64, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0d, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12,
+	0x21, 0x0a, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x54, 0x61, 0x73,
+	0x6b, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x6f, 0x73, 0x74, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6c, 0x6f, 0x73, 0x74, 0x54, 0x61, 0x73, 0x6b,
+	0x73, 0x22, 0xe2, 0x01, 0x0a, 0x08, 0x4a, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x15,
+	0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x12, 0x36, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e,
+	0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e,
+	0x4a, 0x6f, 0x62, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x0b,
+	0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x61,
+	0x72, 0x72, 0x61, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x61,
+	0x72, 0x72, 0x61, 0x79, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x52, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x22, 0x44, 0x0a, 0x07, 0x50, 0x61,
-	0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x25, 0x0a, 0x04, 0x73, 0x70, 0x65,
-	0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x50,
-	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63,
-	0x22, 0x0d, 0x0a, 0x0b, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x70, 0x65, 0x63, 0x22,
-	0x2d, 0x0a, 0x03, 0x54, 0x61, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61,
-	0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x90,
-	0x01, 0x0a, 0x0d, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x12, 0x27, 0x0a, 0x07, 0x66, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0d, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74,
-	0x52, 0x07, 0x66, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61,
-	0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e,
-	0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74,
-	0x61, 0x74, 0x65, 0x12, 0x2c, 0x0a, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x6a,
-	0x6f, 0x62, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x66, 0x6c, 0x65, 0x78,
-	0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x0b, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4a, 0x6f, 0x62,
-	0x73, 0x22, 0x44, 0x0a, 0x07, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x25, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
-	0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x53, 0x70, 0x65,
-	0x63, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x22, 0x23, 0x0a, 0x0b, 0x46, 0x6c, 0x65, 0x78, 0x6c,
-	0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x22, 0x20, 0x0a, 0x0a,
-	0x4a, 0x6f, 0x62, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72,
-	0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x22, 0x3a,
-	0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x74,
-	0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x2e, 0x0a, 0x10, 0x4a, 0x6f, 0x62, 0x4e, 0x6f, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x65,
+	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x77, 0x65,
+	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x22, 0xea, 0x02, 0x0a, 0x0f, 0x57, 0x65, 0x62, 0x68, 0x6f,
+	0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f,
+	0x62, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x75, 0x72, 0x6c, 0x12, 0x24, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e,
+	0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x34,
+	0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x3d, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x61, 0x74, 0x74,
+	0x65, 0x6d, 0x70, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x74, 0x74, 0x65,
+	0x6d, 0x70, 0x74, 0x22, 0x32, 0x0a, 0x0a, 0x4a, 0x6f, 0x62, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x22, 0x9b, 0x01, 0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e,
+	0x6d, 0x61, 0x78, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x35,
+	0x0a, 0x17, 0x6b, 0x69, 0x6c, 0x6c, 0x5f, 0x6f, 0x6e, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x5f, 0x6f, 0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x14, 0x6b, 0x69, 0x6c, 0x6c, 0x4f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4f, 0x76, 0x65,
+	0x72, 0x66, 0x6c, 0x6f, 0x77, 0x22, 0x8c, 0x02, 0x0a, 0x0a, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x72, 0x0a, 0x0a, 0x54, 0x61,
-	0x73, 0x6b, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74,
-	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69,
-	0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
-	0x2d, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x58,
-	0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23,
-	0x0a, 0x0d, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x5f, 0x75, 0x72, 0x6c, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c,
-	0x55, 0x72, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64,
-	0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x73,
-	0x69, 0x67, 0x6e, 0x65, 0x64, 0x55, 0x72, 0x6c, 0x22, 0x57, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74,
-	0x73, 0x12, 0x20, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
-	0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x03,
-	0x6a, 0x6f, 0x62, 0x12, 0x2c, 0x0a, 0x07, 0x66, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x46, 0x6c, 0x65, 0x78,
-	0x6c, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x07, 0x66, 0x6c, 0x65, 0x78, 0x6c, 0x65,
-	0x74, 0x22, 0x50, 0x0a, 0x08, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x21, 0x0a,
-	0x0c, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4a, 0x6f, 0x62, 0x73,
-	0x12, 0x21, 0x0a, 0x0c, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x6a, 0x6f, 0x62, 0x73,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x4a,
-	0x6f, 0x62, 0x73, 0x22, 0xa0, 0x01, 0x0a, 0x0c, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x53,
-	0x74, 0x61, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x66,
-	0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6f,
-	0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x73, 0x12, 0x29, 0x0a,
-	0x10, 0x6f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x66, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74,
-	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x6f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65,
-	0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x75, 0x73, 0x79,
-	0x5f, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x62, 0x75,
-	0x73, 0x79, 0x43, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x64, 0x6c, 0x65, 0x5f,
-	0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x69, 0x64, 0x6c,
-	0x65, 0x43, 0x6f, 0x72, 0x65, 0x73, 0x2a, 0x43, 0x0a, 0x08, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
-	0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01,
-	0x12, 0x0b, 0x0a, 0x07, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x0c, 0x0a,
-	0x08, 0x46, 0x49, 0x4e, 0x49, 0x53, 0x48, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x27, 0x0a, 0x0c, 0x46,
-	0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x4f,
-	0x46, 0x46, 0x4c, 0x49, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4f, 0x4e, 0x4c, 0x49,
-	0x4e, 0x45, 0x10, 0x01, 0x42, 0x18, 0x5a, 0x16, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x6e, 0x79, 0x61, 0x33, 0x6a, 0x70, 0x2f, 0x66, 0x6c, 0x65, 0x78, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74,
+	0x64, 0x6f, 0x75, 0x74, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x12, 0x29, 0x0a, 0x10, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x74, 0x72, 0x75, 0x6e, 0x63,
+	0x61, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x54, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x45, 0x6e, 0x63, 0x6f,
+	0x64, 0x69, 0x6e, 0x67, 0x22, 0x58, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x65, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61,
+	0x6c, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x6e,
+	0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x55, 0x72, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x72, 0x65,
+	0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x70, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x55, 0x72, 0x6c, 0x22, 0x57,
+	0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x2c, 0x0a, 0x07, 0x66, 0x6c, 0x65,
+	0x78, 0x6c, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x6c, 0x65,
+	0x78, 0x2e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x07,
+	0x66, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x22, 0x75, 0x0a, 0x08, 0x4a, 0x6f, 0x62, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x6a,
+	0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e,
+	0x67, 0x5f, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x75,
+	0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x69, 0x6e,
+	0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0c, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x4a, 0x6f, 0x62, 0x73, 0x22, 0xa0,
+	0x01, 0x0a, 0x0c, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x27, 0x0a, 0x0f, 0x6f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x66, 0x6c, 0x65, 0x78, 0x6c, 0x65,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6f, 0x6e, 0x6c, 0x69, 0x6e, 0x65,
+	0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x6f, 0x66, 0x66, 0x6c,
+	0x69, 0x6e, 0x65, 0x5f, 0x66, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0f, 0x6f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x46, 0x6c, 0x65, 0x78, 0x6c,
+	0x65, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x75, 0x73, 0x79, 0x5f, 0x63, 0x6f, 0x72, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x62, 0x75, 0x73, 0x79, 0x43, 0x6f, 0x72,
+	0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x69, 0x64, 0x6c, 0x65, 0x43, 0x6f, 0x72, 0x65,
+	0x73, 0x2a, 0x43, 0x0a, 0x08, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0f, 0x0a,
+	0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b,
+	0x0a, 0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x52,
+	0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x49, 0x4e, 0x49,
+	0x53, 0x48, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x59, 0x0a, 0x14, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f,
+	0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x14,
+	0x0a, 0x10, 0x44, 0x45, 0x4c, 0x49, 0x56, 0x45, 0x52, 0x59, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49,
+	0x4e, 0x47, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x44, 0x45, 0x4c, 0x49, 0x56, 0x45, 0x52, 0x59,
+	0x5f, 0x53, 0x55, 0x43, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f,
+	0x44, 0x45, 0x4c, 0x49, 0x56, 0x45, 0x52, 0x59, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10,
+	0x02, 0x2a, 0x35, 0x0a, 0x0c, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x4f, 0x46, 0x46, 0x4c, 0x49, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x0a,
+	0x0a, 0x06, 0x4f, 0x4e, 0x4c, 0x49, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x52,
+	0x41, 0x49, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x42, 0x18, 0x5a, 0x16, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x79, 0x61, 0x33, 0x6a, 0x70, 0x2f, 0x66, 0x6c,
+	0x65, 0x78, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1432,62 +2759,99 @@ func file_flex_proto_rawDescGZIP() []byte {
 	return file_flex_proto_rawDescData
 }
 
-var file_flex_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_flex_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_flex_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_flex_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_flex_proto_goTypes = []interface{}{
 	(JobState)(0),                 // 0: flex.JobState
-	(FlexletState)(0),             // 1: flex.FlexletState
-	(*Job)(nil),                   // 2: flex.Job
-	(*JobSpec)(nil),               // 3: flex.JobSpec
-	(*JobInputs)(nil),             // 4: flex.JobInputs
-	(*JobPackage)(nil),            // 5: flex.JobPackage
-	(*JobConstraints)(nil),        // 6: flex.JobConstraints
-	(*JobAnnotations)(nil),        // 7: flex.JobAnnotations
-	(*JobStatus)(nil),             // 8: flex.JobStatus
-	(*Package)(nil),               // 9: flex.Package
-	(*PackageSpec)(nil),           // 10: flex.PackageSpec
-	(*Tag)(nil),                   // 11: flex.Tag
-	(*FlexletStatus)(nil),         // 12: flex.FlexletStatus
-	(*Flexlet)(nil),               // 13: flex.Flexlet
-	(*FlexletSpec)(nil),           // 14: flex.FlexletSpec
-	(*JobCommand)(nil),            // 15: flex.JobCommand
-	(*JobLimits)(nil),             // 16: flex.JobLimits
-	(*TaskResult)(nil),            // 17: flex.TaskResult
-	(*FileLocation)(nil),          // 18: flex.FileLocation
-	(*Stats)(nil),                 // 19: flex.Stats
-	(*JobStats)(nil),              // 20: flex.JobStats
-	(*FlexletStats)(nil),          // 21: flex.FlexletStats
-	(*timestamppb.Timestamp)(nil), // 22: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),   // 23: google.protobuf.Duration
+	(WebhookDeliveryState)(0),     // 1: flex.WebhookDeliveryState
+	(FlexletState)(0),             // 2: flex.FlexletState
+	(*Job)(nil),                   // 3: flex.Job
+	(*JobSpec)(nil),               // 4: flex.JobSpec
+	(*JobSpecOverrides)(nil),      // 5: flex.JobSpecOverrides
+	(*JobInputs)(nil),             // 6: flex.JobInputs
+	(*JobPackage)(nil),            // 7: flex.JobPackage
+	(*JobConstraints)(nil),        // 8: flex.JobConstraints
+	(*JobAnnotations)(nil),        // 9: flex.JobAnnotations
+	(*JobStatus)(nil),             // 10: flex.JobStatus
+	(*JobFilter)(nil),             // 11: flex.JobFilter
+	(*JobArray)(nil),              // 12: flex.JobArray
+	(*JobArrayStatus)(nil),        // 13: flex.JobArrayStatus
+	(*BulkJobResult)(nil),         // 14: flex.BulkJobResult
+	(*Package)(nil),               // 15: flex.Package
+	(*PackageSpec)(nil),           // 16: flex.PackageSpec
+	(*Tag)(nil),                   // 17: flex.Tag
+	(*FlexletStatus)(nil),         // 18: flex.FlexletStatus
+	(*Flexlet)(nil),               // 19: flex.Flexlet
+	(*FlexletSpec)(nil),           // 20: flex.FlexletSpec
+	(*FlexletHostInfo)(nil),       // 21: flex.FlexletHostInfo
+	(*FlexletCacheStats)(nil),     // 22: flex.FlexletCacheStats
+	(*FlexletTask)(nil),           // 23: flex.FlexletTask
+	(*FlexletTaskStats)(nil),      // 24: flex.FlexletTaskStats
+	(*JobEvent)(nil),              // 25: flex.JobEvent
+	(*JobNotifications)(nil),      // 26: flex.JobNotifications
+	(*WebhookDelivery)(nil),       // 27: flex.WebhookDelivery
+	(*JobCommand)(nil),            // 28: flex.JobCommand
+	(*JobLimits)(nil),             // 29: flex.JobLimits
+	(*TaskResult)(nil),            // 30: flex.TaskResult
+	(*FileLocation)(nil),          // 31: flex.FileLocation
+	(*Stats)(nil),                 // 32: flex.Stats
+	(*JobStats)(nil),              // 33: flex.JobStats
+	(*FlexletStats)(nil),          // 34: flex.FlexletStats
+	(*timestamppb.Timestamp)(nil), // 35: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 36: google.protobuf.Duration
 }
 var file_flex_proto_depIdxs = []int32{
-	3,  // 0: flex.Job.spec:type_name -> flex.JobSpec
-	15, // 1: flex.JobSpec.command:type_name -> flex.JobCommand
-	4,  // 2: flex.JobSpec.inputs:type_name -> flex.JobInputs
-	16, // 3: flex.JobSpec.limits:type_name -> flex.JobLimits
-	6,  // 4: flex.JobSpec.constraints:type_name -> flex.JobConstraints
-	7,  // 5: flex.JobSpec.annotations:type_name -> flex.JobAnnotations
-	5,  // 6: flex.JobInputs.packages:type_name -> flex.JobPackage
-	2,  // 7: flex.JobStatus.job:type_name -> flex.Job
-	0,  // 8: flex.JobStatus.state:type_name -> flex.JobState
-	17, // 9: flex.JobStatus.result:type_name -> flex.TaskResult
-	22, // 10: flex.JobStatus.created:type_name -> google.protobuf.Timestamp
-	22, // 11: flex.JobStatus.started:type_name -> google.protobuf.Timestamp
-	22, // 12: flex.JobStatus.finished:type_name -> google.protobuf.Timestamp
-	10, // 13: flex.Package.spec:type_name -> flex.PackageSpec
-	13, // 14: flex.FlexletStatus.flexlet:type_name -> flex.Flexlet
-	1,  // 15: flex.FlexletStatus.state:type_name -> flex.FlexletState
-	2,  // 16: flex.FlexletStatus.current_jobs:type_name -> flex.Job
-	14, // 17: flex.Flexlet.spec:type_name -> flex.FlexletSpec
-	23, // 18: flex.JobLimits.time:type_name -> google.protobuf.Duration
-	23, // 19: flex.TaskResult.time:type_name -> google.protobuf.Duration
-	20, // 20: flex.Stats.job:type_name -> flex.JobStats
-	21, // 21: flex.Stats.flexlet:type_name -> flex.FlexletStats
-	22, // [22:22] is the sub-list for method output_type
-	22, // [22:22] is the sub-list for method input_type
-	22, // [22:22] is the sub-list for extension type_name
-	22, // [22:22] is the sub-list for extension extendee
-	0,  // [0:22] is the sub-list for field type_name
+	4,  // 0: flex.Job.spec:type_name -> flex.JobSpec
+	28, // 1: flex.JobSpec.command:type_name -> flex.JobCommand
+	6,  // 2: flex.JobSpec.inputs:type_name -> flex.JobInputs
+	29, // 3: flex.JobSpec.limits:type_name -> flex.JobLimits
+	8,  // 4: flex.JobSpec.constraints:type_name -> flex.JobConstraints
+	9,  // 5: flex.JobSpec.annotations:type_name -> flex.JobAnnotations
+	26, // 6: flex.JobSpec.notifications:type_name -> flex.JobNotifications
+	28, // 7: flex.JobSpecOverrides.command:type_name -> flex.JobCommand
+	29, // 8: flex.JobSpecOverrides.limits:type_name -> flex.JobLimits
+	8,  // 9: flex.JobSpecOverrides.constraints:type_name -> flex.JobConstraints
+	9,  // 10: flex.JobSpecOverrides.annotations:type_name -> flex.JobAnnotations
+	7,  // 11: flex.JobInputs.packages:type_name -> flex.JobPackage
+	3,  // 12: flex.JobStatus.job:type_name -> flex.Job
+	0,  // 13: flex.JobStatus.state:type_name -> flex.JobState
+	30, // 14: flex.JobStatus.result:type_name -> flex.TaskResult
+	35, // 15: flex.JobStatus.created:type_name -> google.protobuf.Timestamp
+	35, // 16: flex.JobStatus.started:type_name -> google.protobuf.Timestamp
+	35, // 17: flex.JobStatus.finished:type_name -> google.protobuf.Timestamp
+	0,  // 18: flex.JobFilter.state:type_name -> flex.JobState
+	4,  // 19: flex.JobArrayStatus.spec:type_name -> flex.JobSpec
+	12, // 20: flex.JobArrayStatus.array:type_name -> flex.JobArray
+	33, // 21: flex.JobArrayStatus.stats:type_name -> flex.JobStats
+	35, // 22: flex.JobArrayStatus.created:type_name -> google.protobuf.Timestamp
+	16, // 23: flex.Package.spec:type_name -> flex.PackageSpec
+	19, // 24: flex.FlexletStatus.flexlet:type_name -> flex.Flexlet
+	2,  // 25: flex.FlexletStatus.state:type_name -> flex.FlexletState
+	3,  // 26: flex.FlexletStatus.current_jobs:type_name -> flex.Job
+	35, // 27: flex.FlexletStatus.last_update:type_name -> google.protobuf.Timestamp
+	21, // 28: flex.FlexletStatus.host:type_name -> flex.FlexletHostInfo
+	20, // 29: flex.Flexlet.spec:type_name -> flex.FlexletSpec
+	36, // 30: flex.FlexletHostInfo.uptime:type_name -> google.protobuf.Duration
+	22, // 31: flex.FlexletHostInfo.cache:type_name -> flex.FlexletCacheStats
+	30, // 32: flex.FlexletTask.result:type_name -> flex.TaskResult
+	35, // 33: flex.FlexletTask.started:type_name -> google.protobuf.Timestamp
+	35, // 34: flex.FlexletTask.finished:type_name -> google.protobuf.Timestamp
+	0,  // 35: flex.JobEvent.state:type_name -> flex.JobState
+	8,  // 36: flex.JobEvent.constraints:type_name -> flex.JobConstraints
+	35, // 37: flex.JobEvent.time:type_name -> google.protobuf.Timestamp
+	0,  // 38: flex.WebhookDelivery.event:type_name -> flex.JobState
+	1,  // 39: flex.WebhookDelivery.state:type_name -> flex.WebhookDeliveryState
+	35, // 40: flex.WebhookDelivery.created:type_name -> google.protobuf.Timestamp
+	35, // 41: flex.WebhookDelivery.last_attempt:type_name -> google.protobuf.Timestamp
+	36, // 42: flex.JobLimits.time:type_name -> google.protobuf.Duration
+	36, // 43: flex.TaskResult.time:type_name -> google.protobuf.Duration
+	33, // 44: flex.Stats.job:type_name -> flex.JobStats
+	34, // 45: flex.Stats.flexlet:type_name -> flex.FlexletStats
+	46, // [46:46] is the sub-list for method output_type
+	46, // [46:46] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_flex_proto_init() }
@@ -1521,7 +2885,7 @@ func file_flex_proto_init() {
 			}
 		}
 		file_flex_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobInputs); i {
+			switch v := v.(*JobSpecOverrides); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1533,7 +2897,7 @@ func file_flex_proto_init() {
 			}
 		}
 		file_flex_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobPackage); i {
+			switch v := v.(*JobInputs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1545,7 +2909,7 @@ func file_flex_proto_init() {
 			}
 		}
 		file_flex_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobConstraints); i {
+			switch v := v.(*JobPackage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1557,7 +2921,7 @@ func file_flex_proto_init() {
 			}
 		}
 		file_flex_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobAnnotations); i {
+			switch v := v.(*JobConstraints); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1569,7 +2933,7 @@ func file_flex_proto_init() {
 			}
 		}
 		file_flex_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobStatus); i {
+			switch v := v.(*JobAnnotations); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1581,7 +2945,7 @@ func file_flex_proto_init() {
 			}
 		}
 		file_flex_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Package); i {
+			switch v := v.(*JobStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1593,7 +2957,7 @@ func file_flex_proto_init() {
 			}
 		}
 		file_flex_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PackageSpec); i {
+			switch v := v.(*JobFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1605,7 +2969,7 @@ func file_flex_proto_init() {
 			}
 		}
 		file_flex_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Tag); i {
+			switch v := v.(*JobArray); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1617,7 +2981,7 @@ func file_flex_proto_init() {
 			}
 		}
 		file_flex_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FlexletStatus); i {
+			switch v := v.(*JobArrayStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1629,7 +2993,7 @@ func file_flex_proto_init() {
 			}
 		}
 		file_flex_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Flexlet); i {
+			switch v := v.(*BulkJobResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1641,7 +3005,7 @@ func file_flex_proto_init() {
 			}
 		}
 		file_flex_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FlexletSpec); i {
+			switch v := v.(*Package); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1653,7 +3017,7 @@ func file_flex_proto_init() {
 			}
 		}
 		file_flex_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobCommand); i {
+			switch v := v.(*PackageSpec); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1665,7 +3029,7 @@ func file_flex_proto_init() {
 			}
 		}
 		file_flex_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobLimits); i {
+			switch v := v.(*Tag); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1677,7 +3041,7 @@ func file_flex_proto_init() {
 			}
 		}
 		file_flex_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TaskResult); i {
+			switch v := v.(*FlexletStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1689,7 +3053,7 @@ func file_flex_proto_init() {
 			}
 		}
 		file_flex_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FileLocation); i {
+			switch v := v.(*Flexlet); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1701,7 +3065,7 @@ func file_flex_proto_init() {
 			}
 		}
 		file_flex_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Stats); i {
+			switch v := v.(*FlexletSpec); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1713,7 +3077,7 @@ func file_flex_proto_init() {
 			}
 		}
 		file_flex_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JobStats); i {
+			switch v := v.(*FlexletHostInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1725,6 +3089,150 @@ func file_flex_proto_init() {
 			}
 		}
 		file_flex_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FlexletCacheStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FlexletTask); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FlexletTaskStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobNotifications); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebhookDelivery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobCommand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobLimits); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileLocation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Stats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FlexletStats); i {
 			case 0:
 				return &v.state
@@ -1742,8 +3250,8 @@ func file_flex_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_flex_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   20,
+			NumEnums:      3,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -39,6 +39,10 @@ type GetJobOutputRequest_JobOutputType int32
 const (
 	GetJobOutputRequest_STDOUT GetJobOutputRequest_JobOutputType = 0
 	GetJobOutputRequest_STDERR GetJobOutputRequest_JobOutputType = 1
+	// LOG is the combined log of stdout and stderr, which consists of lines
+	// of the form "<timestamp> <stream> <line>" in the order they were
+	// written.
+	GetJobOutputRequest_LOG GetJobOutputRequest_JobOutputType = 2
 )
 
 // Enum value maps for GetJobOutputRequest_JobOutputType.
@@ -46,10 +50,12 @@ var (
 	GetJobOutputRequest_JobOutputType_name = map[int32]string{
 		0: "STDOUT",
 		1: "STDERR",
+		2: "LOG",
 	}
 	GetJobOutputRequest_JobOutputType_value = map[string]int32{
 		"STDOUT": 0,
 		"STDERR": 1,
+		"LOG":    2,
 	}
 )
 
@@ -77,7 +83,7 @@ func (x GetJobOutputRequest_JobOutputType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use GetJobOutputRequest_JobOutputType.Descriptor instead.
 func (GetJobOutputRequest_JobOutputType) EnumDescriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{6, 0}
+	return file_flex_service_proto_rawDescGZIP(), []int{8, 0}
 }
 
 type SubmitJobRequest struct {
@@ -85,7 +91,8 @@ type SubmitJobRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Spec *JobSpec `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+	Spec  *JobSpec  `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+	Array *JobArray `protobuf:"bytes,2,opt,name=array,proto3" json:"array,omitempty"`
 }
 
 func (x *SubmitJobRequest) Reset() {
@@ -127,12 +134,20 @@ func (x *SubmitJobRequest) GetSpec() *JobSpec {
 	return nil
 }
 
+func (x *SubmitJobRequest) GetArray() *JobArray {
+	if x != nil {
+		return x.Array
+	}
+	return nil
+}
+
 type SubmitJobResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id      int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ArrayId int64 `protobuf:"varint,2,opt,name=array_id,json=arrayId,proto3" json:"array_id,omitempty"`
 }
 
 func (x *SubmitJobResponse) Reset() {
@@ -174,6 +189,13 @@ func (x *SubmitJobResponse) GetId() int64 {
 	return 0
 }
 
+func (x *SubmitJobResponse) GetArrayId() int64 {
+	if x != nil {
+		return x.ArrayId
+	}
+	return 0
+}
+
 type CancelJobRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -259,6 +281,116 @@ func (*CancelJobResponse) Descriptor() ([]byte, []int) {
 	return file_flex_service_proto_rawDescGZIP(), []int{3}
 }
 
+type CloneJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          int64             `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Overrides   *JobSpecOverrides `protobuf:"bytes,2,opt,name=overrides,proto3" json:"overrides,omitempty"`
+	ResolveTags bool              `protobuf:"varint,3,opt,name=resolve_tags,json=resolveTags,proto3" json:"resolve_tags,omitempty"`
+}
+
+func (x *CloneJobRequest) Reset() {
+	*x = CloneJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloneJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneJobRequest) ProtoMessage() {}
+
+func (x *CloneJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneJobRequest.ProtoReflect.Descriptor instead.
+func (*CloneJobRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CloneJobRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CloneJobRequest) GetOverrides() *JobSpecOverrides {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
+func (x *CloneJobRequest) GetResolveTags() bool {
+	if x != nil {
+		return x.ResolveTags
+	}
+	return false
+}
+
+type CloneJobResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CloneJobResponse) Reset() {
+	*x = CloneJobResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloneJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneJobResponse) ProtoMessage() {}
+
+func (x *CloneJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneJobResponse.ProtoReflect.Descriptor instead.
+func (*CloneJobResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CloneJobResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
 type GetJobRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -270,7 +402,7 @@ type GetJobRequest struct {
 func (x *GetJobRequest) Reset() {
 	*x = GetJobRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[4]
+		mi := &file_flex_service_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -283,7 +415,7 @@ func (x *GetJobRequest) String() string {
 func (*GetJobRequest) ProtoMessage() {}
 
 func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[4]
+	mi := &file_flex_service_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -296,7 +428,7 @@ func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
 func (*GetJobRequest) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{4}
+	return file_flex_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetJobRequest) GetId() int64 {
@@ -317,7 +449,7 @@ type GetJobResponse struct {
 func (x *GetJobResponse) Reset() {
 	*x = GetJobResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[5]
+		mi := &file_flex_service_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -330,7 +462,7 @@ func (x *GetJobResponse) String() string {
 func (*GetJobResponse) ProtoMessage() {}
 
 func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[5]
+	mi := &file_flex_service_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -343,7 +475,7 @@ func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
 func (*GetJobResponse) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{5}
+	return file_flex_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetJobResponse) GetJob() *JobStatus {
@@ -365,7 +497,7 @@ type GetJobOutputRequest struct {
 func (x *GetJobOutputRequest) Reset() {
 	*x = GetJobOutputRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[6]
+		mi := &file_flex_service_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -378,7 +510,7 @@ func (x *GetJobOutputRequest) String() string {
 func (*GetJobOutputRequest) ProtoMessage() {}
 
 func (x *GetJobOutputRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[6]
+	mi := &file_flex_service_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -391,7 +523,7 @@ func (x *GetJobOutputRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetJobOutputRequest.ProtoReflect.Descriptor instead.
 func (*GetJobOutputRequest) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{6}
+	return file_flex_service_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetJobOutputRequest) GetId() int64 {
@@ -414,12 +546,15 @@ type GetJobOutputResponse struct {
 	unknownFields protoimpl.UnknownFields
 
 	Location *FileLocation `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	// content_encoding is the encoding of the file at location, e.g. "gzip".
+	// Clients should decode the file accordingly.
+	ContentEncoding string `protobuf:"bytes,2,opt,name=content_encoding,json=contentEncoding,proto3" json:"content_encoding,omitempty"`
 }
 
 func (x *GetJobOutputResponse) Reset() {
 	*x = GetJobOutputResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[7]
+		mi := &file_flex_service_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -432,7 +567,7 @@ func (x *GetJobOutputResponse) String() string {
 func (*GetJobOutputResponse) ProtoMessage() {}
 
 func (x *GetJobOutputResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[7]
+	mi := &file_flex_service_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -445,7 +580,7 @@ func (x *GetJobOutputResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetJobOutputResponse.ProtoReflect.Descriptor instead.
 func (*GetJobOutputResponse) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{7}
+	return file_flex_service_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetJobOutputResponse) GetLocation() *FileLocation {
@@ -455,6 +590,156 @@ func (x *GetJobOutputResponse) GetLocation() *FileLocation {
 	return nil
 }
 
+func (x *GetJobOutputResponse) GetContentEncoding() string {
+	if x != nil {
+		return x.ContentEncoding
+	}
+	return ""
+}
+
+type ReadJobOutputRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   int64                             `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type GetJobOutputRequest_JobOutputType `protobuf:"varint,2,opt,name=type,proto3,enum=flex.GetJobOutputRequest_JobOutputType" json:"type,omitempty"`
+	// offset is the byte offset to start reading from.
+	Offset int64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// length is the maximum number of bytes to read. If it is 0, the output is
+	// read until the end.
+	Length int64 `protobuf:"varint,4,opt,name=length,proto3" json:"length,omitempty"`
+	// accept_encodings lists content encodings the client can decode, e.g.
+	// "gzip". If the output is stored in one of them and neither offset nor
+	// length is set, it is streamed as stored. Otherwise it is decoded by
+	// flexhub, and offset and length apply to the decoded bytes.
+	AcceptEncodings []string `protobuf:"bytes,5,rep,name=accept_encodings,json=acceptEncodings,proto3" json:"accept_encodings,omitempty"`
+}
+
+func (x *ReadJobOutputRequest) Reset() {
+	*x = ReadJobOutputRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadJobOutputRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadJobOutputRequest) ProtoMessage() {}
+
+func (x *ReadJobOutputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadJobOutputRequest.ProtoReflect.Descriptor instead.
+func (*ReadJobOutputRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReadJobOutputRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ReadJobOutputRequest) GetType() GetJobOutputRequest_JobOutputType {
+	if x != nil {
+		return x.Type
+	}
+	return GetJobOutputRequest_STDOUT
+}
+
+func (x *ReadJobOutputRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ReadJobOutputRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *ReadJobOutputRequest) GetAcceptEncodings() []string {
+	if x != nil {
+		return x.AcceptEncodings
+	}
+	return nil
+}
+
+type ReadJobOutputResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// content_encoding is the encoding of data. It is set in the first
+	// response only.
+	ContentEncoding string `protobuf:"bytes,2,opt,name=content_encoding,json=contentEncoding,proto3" json:"content_encoding,omitempty"`
+}
+
+func (x *ReadJobOutputResponse) Reset() {
+	*x = ReadJobOutputResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadJobOutputResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadJobOutputResponse) ProtoMessage() {}
+
+func (x *ReadJobOutputResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadJobOutputResponse.ProtoReflect.Descriptor instead.
+func (*ReadJobOutputResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReadJobOutputResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ReadJobOutputResponse) GetContentEncoding() string {
+	if x != nil {
+		return x.ContentEncoding
+	}
+	return ""
+}
+
 type ListJobsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -464,12 +749,13 @@ type ListJobsRequest struct {
 	BeforeId int64    `protobuf:"varint,2,opt,name=before_id,json=beforeId,proto3" json:"before_id,omitempty"`
 	State    JobState `protobuf:"varint,3,opt,name=state,proto3,enum=flex.JobState" json:"state,omitempty"`
 	Label    string   `protobuf:"bytes,4,opt,name=label,proto3" json:"label,omitempty"`
+	ArrayId  int64    `protobuf:"varint,5,opt,name=array_id,json=arrayId,proto3" json:"array_id,omitempty"`
 }
 
 func (x *ListJobsRequest) Reset() {
 	*x = ListJobsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[8]
+		mi := &file_flex_service_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -482,7 +768,7 @@ func (x *ListJobsRequest) String() string {
 func (*ListJobsRequest) ProtoMessage() {}
 
 func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[8]
+	mi := &file_flex_service_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -495,7 +781,7 @@ func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
 func (*ListJobsRequest) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{8}
+	return file_flex_service_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ListJobsRequest) GetLimit() int64 {
@@ -526,6 +812,13 @@ func (x *ListJobsRequest) GetLabel() string {
 	return ""
 }
 
+func (x *ListJobsRequest) GetArrayId() int64 {
+	if x != nil {
+		return x.ArrayId
+	}
+	return 0
+}
+
 type ListJobsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -537,7 +830,7 @@ type ListJobsResponse struct {
 func (x *ListJobsResponse) Reset() {
 	*x = ListJobsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[9]
+		mi := &file_flex_service_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -550,7 +843,7 @@ func (x *ListJobsResponse) String() string {
 func (*ListJobsResponse) ProtoMessage() {}
 
 func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[9]
+	mi := &file_flex_service_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -563,7 +856,7 @@ func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
 func (*ListJobsResponse) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{9}
+	return file_flex_service_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListJobsResponse) GetJobs() []*JobStatus {
@@ -586,7 +879,7 @@ type UpdateJobLabelsRequest struct {
 func (x *UpdateJobLabelsRequest) Reset() {
 	*x = UpdateJobLabelsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[10]
+		mi := &file_flex_service_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -599,7 +892,7 @@ func (x *UpdateJobLabelsRequest) String() string {
 func (*UpdateJobLabelsRequest) ProtoMessage() {}
 
 func (x *UpdateJobLabelsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[10]
+	mi := &file_flex_service_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -612,7 +905,7 @@ func (x *UpdateJobLabelsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateJobLabelsRequest.ProtoReflect.Descriptor instead.
 func (*UpdateJobLabelsRequest) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{10}
+	return file_flex_service_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *UpdateJobLabelsRequest) GetId() int64 {
@@ -645,7 +938,7 @@ type UpdateJobLabelsResponse struct {
 func (x *UpdateJobLabelsResponse) Reset() {
 	*x = UpdateJobLabelsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[11]
+		mi := &file_flex_service_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -658,7 +951,7 @@ func (x *UpdateJobLabelsResponse) String() string {
 func (*UpdateJobLabelsResponse) ProtoMessage() {}
 
 func (x *UpdateJobLabelsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[11]
+	mi := &file_flex_service_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -671,37 +964,34 @@ func (x *UpdateJobLabelsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateJobLabelsResponse.ProtoReflect.Descriptor instead.
 func (*UpdateJobLabelsResponse) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{11}
+	return file_flex_service_proto_rawDescGZIP(), []int{15}
 }
 
-type InsertPackageRequest struct {
+type GetJobArrayRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Type:
-	//	*InsertPackageRequest_Spec
-	//	*InsertPackageRequest_Data
-	Type isInsertPackageRequest_Type `protobuf_oneof:"type"`
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *InsertPackageRequest) Reset() {
-	*x = InsertPackageRequest{}
+func (x *GetJobArrayRequest) Reset() {
+	*x = GetJobArrayRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[12]
+		mi := &file_flex_service_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *InsertPackageRequest) String() string {
+func (x *GetJobArrayRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InsertPackageRequest) ProtoMessage() {}
+func (*GetJobArrayRequest) ProtoMessage() {}
 
-func (x *InsertPackageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[12]
+func (x *GetJobArrayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -712,73 +1002,43 @@ func (x *InsertPackageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InsertPackageRequest.ProtoReflect.Descriptor instead.
-func (*InsertPackageRequest) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{12}
-}
-
-func (m *InsertPackageRequest) GetType() isInsertPackageRequest_Type {
-	if m != nil {
-		return m.Type
-	}
-	return nil
-}
-
-func (x *InsertPackageRequest) GetSpec() *PackageSpec {
-	if x, ok := x.GetType().(*InsertPackageRequest_Spec); ok {
-		return x.Spec
-	}
-	return nil
+// Deprecated: Use GetJobArrayRequest.ProtoReflect.Descriptor instead.
+func (*GetJobArrayRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *InsertPackageRequest) GetData() []byte {
-	if x, ok := x.GetType().(*InsertPackageRequest_Data); ok {
-		return x.Data
+func (x *GetJobArrayRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
 	}
-	return nil
-}
-
-type isInsertPackageRequest_Type interface {
-	isInsertPackageRequest_Type()
-}
-
-type InsertPackageRequest_Spec struct {
-	Spec *PackageSpec `protobuf:"bytes,1,opt,name=spec,proto3,oneof"`
-}
-
-type InsertPackageRequest_Data struct {
-	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+	return 0
 }
 
-func (*InsertPackageRequest_Spec) isInsertPackageRequest_Type() {}
-
-func (*InsertPackageRequest_Data) isInsertPackageRequest_Type() {}
-
-type InsertPackageResponse struct {
+type GetJobArrayResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Array *JobArrayStatus `protobuf:"bytes,1,opt,name=array,proto3" json:"array,omitempty"`
 }
 
-func (x *InsertPackageResponse) Reset() {
-	*x = InsertPackageResponse{}
+func (x *GetJobArrayResponse) Reset() {
+	*x = GetJobArrayResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[13]
+		mi := &file_flex_service_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *InsertPackageResponse) String() string {
+func (x *GetJobArrayResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InsertPackageResponse) ProtoMessage() {}
+func (*GetJobArrayResponse) ProtoMessage() {}
 
-func (x *InsertPackageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[13]
+func (x *GetJobArrayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -789,46 +1049,44 @@ func (x *InsertPackageResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InsertPackageResponse.ProtoReflect.Descriptor instead.
-func (*InsertPackageResponse) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use GetJobArrayResponse.ProtoReflect.Descriptor instead.
+func (*GetJobArrayResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *InsertPackageResponse) GetHash() string {
+func (x *GetJobArrayResponse) GetArray() *JobArrayStatus {
 	if x != nil {
-		return x.Hash
+		return x.Array
 	}
-	return ""
+	return nil
 }
 
-type GetPackageRequest struct {
+type WatchJobsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Type:
-	//	*GetPackageRequest_Hash
-	//	*GetPackageRequest_Tag
-	Type isGetPackageRequest_Type `protobuf_oneof:"type"`
+	Ids    []int64    `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	Filter *JobFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
 }
 
-func (x *GetPackageRequest) Reset() {
-	*x = GetPackageRequest{}
+func (x *WatchJobsRequest) Reset() {
+	*x = WatchJobsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[14]
+		mi := &file_flex_service_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetPackageRequest) String() string {
+func (x *WatchJobsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPackageRequest) ProtoMessage() {}
+func (*WatchJobsRequest) ProtoMessage() {}
 
-func (x *GetPackageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[14]
+func (x *WatchJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -839,73 +1097,50 @@ func (x *GetPackageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPackageRequest.ProtoReflect.Descriptor instead.
-func (*GetPackageRequest) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use WatchJobsRequest.ProtoReflect.Descriptor instead.
+func (*WatchJobsRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{18}
 }
 
-func (m *GetPackageRequest) GetType() isGetPackageRequest_Type {
-	if m != nil {
-		return m.Type
+func (x *WatchJobsRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
 	}
 	return nil
 }
 
-func (x *GetPackageRequest) GetHash() string {
-	if x, ok := x.GetType().(*GetPackageRequest_Hash); ok {
-		return x.Hash
-	}
-	return ""
-}
-
-func (x *GetPackageRequest) GetTag() string {
-	if x, ok := x.GetType().(*GetPackageRequest_Tag); ok {
-		return x.Tag
+func (x *WatchJobsRequest) GetFilter() *JobFilter {
+	if x != nil {
+		return x.Filter
 	}
-	return ""
-}
-
-type isGetPackageRequest_Type interface {
-	isGetPackageRequest_Type()
-}
-
-type GetPackageRequest_Hash struct {
-	Hash string `protobuf:"bytes,1,opt,name=hash,proto3,oneof"`
-}
-
-type GetPackageRequest_Tag struct {
-	Tag string `protobuf:"bytes,2,opt,name=tag,proto3,oneof"`
+	return nil
 }
 
-func (*GetPackageRequest_Hash) isGetPackageRequest_Type() {}
-
-func (*GetPackageRequest_Tag) isGetPackageRequest_Type() {}
-
-type GetPackageResponse struct {
+type WatchJobsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Package *Package `protobuf:"bytes,1,opt,name=package,proto3" json:"package,omitempty"`
+	Job *JobStatus `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
 }
 
-func (x *GetPackageResponse) Reset() {
-	*x = GetPackageResponse{}
+func (x *WatchJobsResponse) Reset() {
+	*x = WatchJobsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[15]
+		mi := &file_flex_service_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetPackageResponse) String() string {
+func (x *WatchJobsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPackageResponse) ProtoMessage() {}
+func (*WatchJobsResponse) ProtoMessage() {}
 
-func (x *GetPackageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[15]
+func (x *WatchJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -916,46 +1151,45 @@ func (x *GetPackageResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPackageResponse.ProtoReflect.Descriptor instead.
-func (*GetPackageResponse) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use WatchJobsResponse.ProtoReflect.Descriptor instead.
+func (*WatchJobsResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *GetPackageResponse) GetPackage() *Package {
+func (x *WatchJobsResponse) GetJob() *JobStatus {
 	if x != nil {
-		return x.Package
+		return x.Job
 	}
 	return nil
 }
 
-type FetchPackageRequest struct {
+type BulkUpdateJobLabelsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Type:
-	//	*FetchPackageRequest_Hash
-	//	*FetchPackageRequest_Tag
-	Type isFetchPackageRequest_Type `protobuf_oneof:"type"`
+	Filter *JobFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Adds   []string   `protobuf:"bytes,2,rep,name=adds,proto3" json:"adds,omitempty"`
+	Dels   []string   `protobuf:"bytes,3,rep,name=dels,proto3" json:"dels,omitempty"`
 }
 
-func (x *FetchPackageRequest) Reset() {
-	*x = FetchPackageRequest{}
+func (x *BulkUpdateJobLabelsRequest) Reset() {
+	*x = BulkUpdateJobLabelsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[16]
+		mi := &file_flex_service_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FetchPackageRequest) String() string {
+func (x *BulkUpdateJobLabelsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FetchPackageRequest) ProtoMessage() {}
+func (*BulkUpdateJobLabelsRequest) ProtoMessage() {}
 
-func (x *FetchPackageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[16]
+func (x *BulkUpdateJobLabelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -966,73 +1200,57 @@ func (x *FetchPackageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FetchPackageRequest.ProtoReflect.Descriptor instead.
-func (*FetchPackageRequest) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use BulkUpdateJobLabelsRequest.ProtoReflect.Descriptor instead.
+func (*BulkUpdateJobLabelsRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{20}
 }
 
-func (m *FetchPackageRequest) GetType() isFetchPackageRequest_Type {
-	if m != nil {
-		return m.Type
+func (x *BulkUpdateJobLabelsRequest) GetFilter() *JobFilter {
+	if x != nil {
+		return x.Filter
 	}
 	return nil
 }
 
-func (x *FetchPackageRequest) GetHash() string {
-	if x, ok := x.GetType().(*FetchPackageRequest_Hash); ok {
-		return x.Hash
+func (x *BulkUpdateJobLabelsRequest) GetAdds() []string {
+	if x != nil {
+		return x.Adds
 	}
-	return ""
+	return nil
 }
 
-func (x *FetchPackageRequest) GetTag() string {
-	if x, ok := x.GetType().(*FetchPackageRequest_Tag); ok {
-		return x.Tag
+func (x *BulkUpdateJobLabelsRequest) GetDels() []string {
+	if x != nil {
+		return x.Dels
 	}
-	return ""
-}
-
-type isFetchPackageRequest_Type interface {
-	isFetchPackageRequest_Type()
-}
-
-type FetchPackageRequest_Hash struct {
-	Hash string `protobuf:"bytes,1,opt,name=hash,proto3,oneof"`
-}
-
-type FetchPackageRequest_Tag struct {
-	Tag string `protobuf:"bytes,2,opt,name=tag,proto3,oneof"`
+	return nil
 }
 
-func (*FetchPackageRequest_Hash) isFetchPackageRequest_Type() {}
-
-func (*FetchPackageRequest_Tag) isFetchPackageRequest_Type() {}
-
-type FetchPackageResponse struct {
+type BulkUpdateJobLabelsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Location *FileLocation `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Results []*BulkJobResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-func (x *FetchPackageResponse) Reset() {
-	*x = FetchPackageResponse{}
+func (x *BulkUpdateJobLabelsResponse) Reset() {
+	*x = BulkUpdateJobLabelsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[17]
+		mi := &file_flex_service_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FetchPackageResponse) String() string {
+func (x *BulkUpdateJobLabelsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FetchPackageResponse) ProtoMessage() {}
+func (*BulkUpdateJobLabelsResponse) ProtoMessage() {}
 
-func (x *FetchPackageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[17]
+func (x *BulkUpdateJobLabelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1043,43 +1261,43 @@ func (x *FetchPackageResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FetchPackageResponse.ProtoReflect.Descriptor instead.
-func (*FetchPackageResponse) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use BulkUpdateJobLabelsResponse.ProtoReflect.Descriptor instead.
+func (*BulkUpdateJobLabelsResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *FetchPackageResponse) GetLocation() *FileLocation {
+func (x *BulkUpdateJobLabelsResponse) GetResults() []*BulkJobResult {
 	if x != nil {
-		return x.Location
+		return x.Results
 	}
 	return nil
 }
 
-type UpdateTagRequest struct {
+type BulkCancelJobsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tag *Tag `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Filter *JobFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
 }
 
-func (x *UpdateTagRequest) Reset() {
-	*x = UpdateTagRequest{}
+func (x *BulkCancelJobsRequest) Reset() {
+	*x = BulkCancelJobsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[18]
+		mi := &file_flex_service_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UpdateTagRequest) String() string {
+func (x *BulkCancelJobsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTagRequest) ProtoMessage() {}
+func (*BulkCancelJobsRequest) ProtoMessage() {}
 
-func (x *UpdateTagRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[18]
+func (x *BulkCancelJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1090,41 +1308,43 @@ func (x *UpdateTagRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTagRequest.ProtoReflect.Descriptor instead.
-func (*UpdateTagRequest) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use BulkCancelJobsRequest.ProtoReflect.Descriptor instead.
+func (*BulkCancelJobsRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *UpdateTagRequest) GetTag() *Tag {
+func (x *BulkCancelJobsRequest) GetFilter() *JobFilter {
 	if x != nil {
-		return x.Tag
+		return x.Filter
 	}
 	return nil
 }
 
-type UpdateTagResponse struct {
+type BulkCancelJobsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Results []*BulkJobResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-func (x *UpdateTagResponse) Reset() {
-	*x = UpdateTagResponse{}
+func (x *BulkCancelJobsResponse) Reset() {
+	*x = BulkCancelJobsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[19]
+		mi := &file_flex_service_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UpdateTagResponse) String() string {
+func (x *BulkCancelJobsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTagResponse) ProtoMessage() {}
+func (*BulkCancelJobsResponse) ProtoMessage() {}
 
-func (x *UpdateTagResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[19]
+func (x *BulkCancelJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1135,34 +1355,43 @@ func (x *UpdateTagResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTagResponse.ProtoReflect.Descriptor instead.
-func (*UpdateTagResponse) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use BulkCancelJobsResponse.ProtoReflect.Descriptor instead.
+func (*BulkCancelJobsResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{23}
 }
 
-type ListTagsRequest struct {
+func (x *BulkCancelJobsResponse) GetResults() []*BulkJobResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type BulkRetryJobsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Filter *JobFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
 }
 
-func (x *ListTagsRequest) Reset() {
-	*x = ListTagsRequest{}
+func (x *BulkRetryJobsRequest) Reset() {
+	*x = BulkRetryJobsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[20]
+		mi := &file_flex_service_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListTagsRequest) String() string {
+func (x *BulkRetryJobsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTagsRequest) ProtoMessage() {}
+func (*BulkRetryJobsRequest) ProtoMessage() {}
 
-func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[20]
+func (x *BulkRetryJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1173,36 +1402,43 @@ func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
-func (*ListTagsRequest) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use BulkRetryJobsRequest.ProtoReflect.Descriptor instead.
+func (*BulkRetryJobsRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{24}
 }
 
-type ListTagsResponse struct {
+func (x *BulkRetryJobsRequest) GetFilter() *JobFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type BulkRetryJobsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tags []*Tag `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	Results []*BulkJobResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-func (x *ListTagsResponse) Reset() {
-	*x = ListTagsResponse{}
+func (x *BulkRetryJobsResponse) Reset() {
+	*x = BulkRetryJobsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[21]
+		mi := &file_flex_service_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListTagsResponse) String() string {
+func (x *BulkRetryJobsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTagsResponse) ProtoMessage() {}
+func (*BulkRetryJobsResponse) ProtoMessage() {}
 
-func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[21]
+func (x *BulkRetryJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1213,41 +1449,46 @@ func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
-func (*ListTagsResponse) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use BulkRetryJobsResponse.ProtoReflect.Descriptor instead.
+func (*BulkRetryJobsResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *ListTagsResponse) GetTags() []*Tag {
+func (x *BulkRetryJobsResponse) GetResults() []*BulkJobResult {
 	if x != nil {
-		return x.Tags
+		return x.Results
 	}
 	return nil
 }
 
-type ListFlexletsRequest struct {
+type InsertPackageRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Type:
+	//	*InsertPackageRequest_Spec
+	//	*InsertPackageRequest_Data
+	Type isInsertPackageRequest_Type `protobuf_oneof:"type"`
 }
 
-func (x *ListFlexletsRequest) Reset() {
-	*x = ListFlexletsRequest{}
+func (x *InsertPackageRequest) Reset() {
+	*x = InsertPackageRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[22]
+		mi := &file_flex_service_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListFlexletsRequest) String() string {
+func (x *InsertPackageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFlexletsRequest) ProtoMessage() {}
+func (*InsertPackageRequest) ProtoMessage() {}
 
-func (x *ListFlexletsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[22]
+func (x *InsertPackageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1258,36 +1499,73 @@ func (x *ListFlexletsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFlexletsRequest.ProtoReflect.Descriptor instead.
-func (*ListFlexletsRequest) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use InsertPackageRequest.ProtoReflect.Descriptor instead.
+func (*InsertPackageRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{26}
 }
 
-type ListFlexletsResponse struct {
+func (m *InsertPackageRequest) GetType() isInsertPackageRequest_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (x *InsertPackageRequest) GetSpec() *PackageSpec {
+	if x, ok := x.GetType().(*InsertPackageRequest_Spec); ok {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *InsertPackageRequest) GetData() []byte {
+	if x, ok := x.GetType().(*InsertPackageRequest_Data); ok {
+		return x.Data
+	}
+	return nil
+}
+
+type isInsertPackageRequest_Type interface {
+	isInsertPackageRequest_Type()
+}
+
+type InsertPackageRequest_Spec struct {
+	Spec *PackageSpec `protobuf:"bytes,1,opt,name=spec,proto3,oneof"`
+}
+
+type InsertPackageRequest_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*InsertPackageRequest_Spec) isInsertPackageRequest_Type() {}
+
+func (*InsertPackageRequest_Data) isInsertPackageRequest_Type() {}
+
+type InsertPackageResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Flexlets []*FlexletStatus `protobuf:"bytes,1,rep,name=flexlets,proto3" json:"flexlets,omitempty"`
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 }
 
-func (x *ListFlexletsResponse) Reset() {
-	*x = ListFlexletsResponse{}
+func (x *InsertPackageResponse) Reset() {
+	*x = InsertPackageResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[23]
+		mi := &file_flex_service_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListFlexletsResponse) String() string {
+func (x *InsertPackageResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListFlexletsResponse) ProtoMessage() {}
+func (*InsertPackageResponse) ProtoMessage() {}
 
-func (x *ListFlexletsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[23]
+func (x *InsertPackageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1298,41 +1576,123 @@ func (x *ListFlexletsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListFlexletsResponse.ProtoReflect.Descriptor instead.
-func (*ListFlexletsResponse) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use InsertPackageResponse.ProtoReflect.Descriptor instead.
+func (*InsertPackageResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *ListFlexletsResponse) GetFlexlets() []*FlexletStatus {
+func (x *InsertPackageResponse) GetHash() string {
 	if x != nil {
-		return x.Flexlets
+		return x.Hash
+	}
+	return ""
+}
+
+type GetPackageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Type:
+	//	*GetPackageRequest_Hash
+	//	*GetPackageRequest_Tag
+	Type isGetPackageRequest_Type `protobuf_oneof:"type"`
+}
+
+func (x *GetPackageRequest) Reset() {
+	*x = GetPackageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPackageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPackageRequest) ProtoMessage() {}
+
+func (x *GetPackageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPackageRequest.ProtoReflect.Descriptor instead.
+func (*GetPackageRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (m *GetPackageRequest) GetType() isGetPackageRequest_Type {
+	if m != nil {
+		return m.Type
 	}
 	return nil
 }
 
-type GetStatsRequest struct {
+func (x *GetPackageRequest) GetHash() string {
+	if x, ok := x.GetType().(*GetPackageRequest_Hash); ok {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *GetPackageRequest) GetTag() string {
+	if x, ok := x.GetType().(*GetPackageRequest_Tag); ok {
+		return x.Tag
+	}
+	return ""
+}
+
+type isGetPackageRequest_Type interface {
+	isGetPackageRequest_Type()
+}
+
+type GetPackageRequest_Hash struct {
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3,oneof"`
+}
+
+type GetPackageRequest_Tag struct {
+	Tag string `protobuf:"bytes,2,opt,name=tag,proto3,oneof"`
+}
+
+func (*GetPackageRequest_Hash) isGetPackageRequest_Type() {}
+
+func (*GetPackageRequest_Tag) isGetPackageRequest_Type() {}
+
+type GetPackageResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Package *Package `protobuf:"bytes,1,opt,name=package,proto3" json:"package,omitempty"`
 }
 
-func (x *GetStatsRequest) Reset() {
-	*x = GetStatsRequest{}
+func (x *GetPackageResponse) Reset() {
+	*x = GetPackageResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[24]
+		mi := &file_flex_service_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetStatsRequest) String() string {
+func (x *GetPackageResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetStatsRequest) ProtoMessage() {}
+func (*GetPackageResponse) ProtoMessage() {}
 
-func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[24]
+func (x *GetPackageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1343,324 +1703,1729 @@ func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
-func (*GetStatsRequest) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use GetPackageResponse.ProtoReflect.Descriptor instead.
+func (*GetPackageResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{29}
 }
 
-type GetStatsResponse struct {
+func (x *GetPackageResponse) GetPackage() *Package {
+	if x != nil {
+		return x.Package
+	}
+	return nil
+}
+
+type FetchPackageRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Stats *Stats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	// Types that are assignable to Type:
+	//	*FetchPackageRequest_Hash
+	//	*FetchPackageRequest_Tag
+	Type isFetchPackageRequest_Type `protobuf_oneof:"type"`
 }
 
-func (x *GetStatsResponse) Reset() {
-	*x = GetStatsResponse{}
+func (x *FetchPackageRequest) Reset() {
+	*x = FetchPackageRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flex_service_proto_msgTypes[25]
+		mi := &file_flex_service_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetStatsResponse) String() string {
+func (x *FetchPackageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetStatsResponse) ProtoMessage() {}
+func (*FetchPackageRequest) ProtoMessage() {}
 
-func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_flex_service_proto_msgTypes[25]
+func (x *FetchPackageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
-func (*GetStatsResponse) Descriptor() ([]byte, []int) {
-	return file_flex_service_proto_rawDescGZIP(), []int{25}
-}
-
-func (x *GetStatsResponse) GetStats() *Stats {
-	if x != nil {
-		return x.Stats
-	}
-	return nil
-}
-
-var File_flex_service_proto protoreflect.FileDescriptor
-
-var file_flex_service_proto_rawDesc = []byte{
-	0x0a, 0x12, 0x66, 0x6c, 0x65, 0x78, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x66, 0x6c, 0x65, 0x78, 0x1a, 0x0a, 0x66, 0x6c, 0x65, 0x78,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x35, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74,
-	0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x04, 0x73, 0x70,
-	0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e,
-	0x4a, 0x6f, 0x62, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x22, 0x23, 0x0a,
-	0x11, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02,
-	0x69, 0x64, 0x22, 0x22, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x13, 0x0a, 0x11, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
-	0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x0a, 0x0d, 0x47,
-	0x65, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x33, 0x0a, 0x0e,
-	0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21,
-	0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x66, 0x6c,
-	0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x03, 0x6a, 0x6f,
-	0x62, 0x22, 0x8b, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x4f, 0x75, 0x74, 0x70,
-	0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3b, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x47,
-	0x65, 0x74, 0x4a, 0x6f, 0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x2e, 0x4a, 0x6f, 0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x27, 0x0a, 0x0d, 0x4a, 0x6f, 0x62, 0x4f, 0x75, 0x74,
-	0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x44, 0x4f, 0x55,
-	0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x44, 0x45, 0x52, 0x52, 0x10, 0x01, 0x22,
-	0x46, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x6c, 0x65, 0x78,
-	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c,
-	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x80, 0x01, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74,
-	0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c,
-	0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
-	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x49, 0x64, 0x12, 0x24,
-	0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e,
-	0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73,
-	0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x22, 0x37, 0x0a, 0x10, 0x4c, 0x69,
-	0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23,
-	0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x66,
-	0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x04, 0x6a,
-	0x6f, 0x62, 0x73, 0x22, 0x50, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62,
-	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a,
-	0x04, 0x61, 0x64, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64, 0x64,
-	0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x04, 0x64, 0x65, 0x6c, 0x73, 0x22, 0x19, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4a,
-	0x6f, 0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x5d, 0x0a, 0x14, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x50, 0x61,
-	0x63, 0x6b, 0x61, 0x67, 0x65, 0x53, 0x70, 0x65, 0x63, 0x48, 0x00, 0x52, 0x04, 0x73, 0x70, 0x65,
-	0x63, 0x12, 0x14, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48,
-	0x00, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22,
-	0x2b, 0x0a, 0x15, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x45, 0x0a, 0x11,
-	0x47, 0x65, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x14, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48,
-	0x00, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x74, 0x61, 0x67, 0x42, 0x06, 0x0a, 0x04, 0x74,
-	0x79, 0x70, 0x65, 0x22, 0x3d, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x07, 0x70, 0x61, 0x63,
-	0x6b, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x66, 0x6c, 0x65,
-	0x78, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x61,
-	0x67, 0x65, 0x22, 0x47, 0x0a, 0x13, 0x46, 0x65, 0x74, 0x63, 0x68, 0x50, 0x61, 0x63, 0x6b, 0x61,
-	0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x04, 0x68, 0x61, 0x73,
-	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12,
-	0x12, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03,
-	0x74, 0x61, 0x67, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x46, 0x0a, 0x14, 0x46,
-	0x65, 0x74, 0x63, 0x68, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x46, 0x69, 0x6c,
-	0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x22, 0x2f, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x54, 0x61, 0x67, 0x52,
-	0x03, 0x74, 0x61, 0x67, 0x22, 0x13, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61,
-	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x11, 0x0a, 0x0f, 0x4c, 0x69, 0x73,
-	0x74, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x31, 0x0a, 0x10,
-	0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x1d, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09,
-	0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22,
-	0x15, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x47, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x6c,
-	0x65, 0x78, 0x6c, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f,
-	0x0a, 0x08, 0x66, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x13, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x08, 0x66, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x73, 0x22,
-	0x11, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x22, 0x35, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x53, 0x74, 0x61,
-	0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x32, 0xf9, 0x06, 0x0a, 0x0b, 0x46, 0x6c,
-	0x65, 0x78, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3e, 0x0a, 0x09, 0x53, 0x75, 0x62,
-	0x6d, 0x69, 0x74, 0x4a, 0x6f, 0x62, 0x12, 0x16, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x53, 0x75,
-	0x62, 0x6d, 0x69, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
-	0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x4a, 0x6f, 0x62, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x09, 0x43, 0x61, 0x6e,
-	0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x12, 0x16, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x43, 0x61,
-	0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
-	0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x06, 0x47, 0x65, 0x74,
-	0x4a, 0x6f, 0x62, 0x12, 0x13, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f,
-	0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e,
-	0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x47, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
-	0x12, 0x19, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x4f, 0x75,
-	0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x66, 0x6c,
-	0x65, 0x78, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x08, 0x4c, 0x69, 0x73,
-	0x74, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x15, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x66,
-	0x6c, 0x65, 0x78, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x0f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x4a, 0x6f, 0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x1c, 0x2e, 0x66, 0x6c, 0x65, 0x78,
-	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0d, 0x49, 0x6e, 0x73, 0x65,
-	0x72, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x2e, 0x66, 0x6c, 0x65, 0x78,
-	0x2e, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x49, 0x6e, 0x73,
-	0x65, 0x72, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x41, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x50, 0x61, 0x63,
-	0x6b, 0x61, 0x67, 0x65, 0x12, 0x17, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x47, 0x65, 0x74, 0x50,
-	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
-	0x66, 0x6c, 0x65, 0x78, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0c, 0x46, 0x65, 0x74,
-	0x63, 0x68, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x19, 0x2e, 0x66, 0x6c, 0x65, 0x78,
-	0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x46, 0x65, 0x74, 0x63,
-	0x68, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x3e, 0x0a, 0x09, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x12,
-	0x16, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x3b, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x73, 0x12, 0x15,
-	0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x47, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x73, 0x12,
-	0x19, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x6c, 0x65, 0x78, 0x6c,
-	0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x66, 0x6c, 0x65,
-	0x78, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53,
-	0x74, 0x61, 0x74, 0x73, 0x12, 0x15, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x47, 0x65, 0x74, 0x53,
-	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x66, 0x6c,
-	0x65, 0x78, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x18, 0x5a, 0x16, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x79, 0x61, 0x33, 0x6a, 0x70, 0x2f, 0x66, 0x6c, 0x65, 0x78, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_flex_service_proto_rawDescOnce sync.Once
-	file_flex_service_proto_rawDescData = file_flex_service_proto_rawDesc
-)
-
-func file_flex_service_proto_rawDescGZIP() []byte {
-	file_flex_service_proto_rawDescOnce.Do(func() {
-		file_flex_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_flex_service_proto_rawDescData)
-	})
-	return file_flex_service_proto_rawDescData
-}
-
-var file_flex_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_flex_service_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
-var file_flex_service_proto_goTypes = []interface{}{
-	(GetJobOutputRequest_JobOutputType)(0), // 0: flex.GetJobOutputRequest.JobOutputType
-	(*SubmitJobRequest)(nil),               // 1: flex.SubmitJobRequest
-	(*SubmitJobResponse)(nil),              // 2: flex.SubmitJobResponse
-	(*CancelJobRequest)(nil),               // 3: flex.CancelJobRequest
-	(*CancelJobResponse)(nil),              // 4: flex.CancelJobResponse
-	(*GetJobRequest)(nil),                  // 5: flex.GetJobRequest
-	(*GetJobResponse)(nil),                 // 6: flex.GetJobResponse
-	(*GetJobOutputRequest)(nil),            // 7: flex.GetJobOutputRequest
-	(*GetJobOutputResponse)(nil),           // 8: flex.GetJobOutputResponse
-	(*ListJobsRequest)(nil),                // 9: flex.ListJobsRequest
-	(*ListJobsResponse)(nil),               // 10: flex.ListJobsResponse
-	(*UpdateJobLabelsRequest)(nil),         // 11: flex.UpdateJobLabelsRequest
-	(*UpdateJobLabelsResponse)(nil),        // 12: flex.UpdateJobLabelsResponse
-	(*InsertPackageRequest)(nil),           // 13: flex.InsertPackageRequest
-	(*InsertPackageResponse)(nil),          // 14: flex.InsertPackageResponse
-	(*GetPackageRequest)(nil),              // 15: flex.GetPackageRequest
-	(*GetPackageResponse)(nil),             // 16: flex.GetPackageResponse
-	(*FetchPackageRequest)(nil),            // 17: flex.FetchPackageRequest
-	(*FetchPackageResponse)(nil),           // 18: flex.FetchPackageResponse
-	(*UpdateTagRequest)(nil),               // 19: flex.UpdateTagRequest
-	(*UpdateTagResponse)(nil),              // 20: flex.UpdateTagResponse
-	(*ListTagsRequest)(nil),                // 21: flex.ListTagsRequest
-	(*ListTagsResponse)(nil),               // 22: flex.ListTagsResponse
-	(*ListFlexletsRequest)(nil),            // 23: flex.ListFlexletsRequest
-	(*ListFlexletsResponse)(nil),           // 24: flex.ListFlexletsResponse
-	(*GetStatsRequest)(nil),                // 25: flex.GetStatsRequest
-	(*GetStatsResponse)(nil),               // 26: flex.GetStatsResponse
-	(*JobSpec)(nil),                        // 27: flex.JobSpec
-	(*JobStatus)(nil),                      // 28: flex.JobStatus
-	(*FileLocation)(nil),                   // 29: flex.FileLocation
-	(JobState)(0),                          // 30: flex.JobState
-	(*PackageSpec)(nil),                    // 31: flex.PackageSpec
-	(*Package)(nil),                        // 32: flex.Package
-	(*Tag)(nil),                            // 33: flex.Tag
-	(*FlexletStatus)(nil),                  // 34: flex.FlexletStatus
-	(*Stats)(nil),                          // 35: flex.Stats
-}
-var file_flex_service_proto_depIdxs = []int32{
-	27, // 0: flex.SubmitJobRequest.spec:type_name -> flex.JobSpec
-	28, // 1: flex.GetJobResponse.job:type_name -> flex.JobStatus
-	0,  // 2: flex.GetJobOutputRequest.type:type_name -> flex.GetJobOutputRequest.JobOutputType
-	29, // 3: flex.GetJobOutputResponse.location:type_name -> flex.FileLocation
-	30, // 4: flex.ListJobsRequest.state:type_name -> flex.JobState
-	28, // 5: flex.ListJobsResponse.jobs:type_name -> flex.JobStatus
-	31, // 6: flex.InsertPackageRequest.spec:type_name -> flex.PackageSpec
-	32, // 7: flex.GetPackageResponse.package:type_name -> flex.Package
-	29, // 8: flex.FetchPackageResponse.location:type_name -> flex.FileLocation
-	33, // 9: flex.UpdateTagRequest.tag:type_name -> flex.Tag
-	33, // 10: flex.ListTagsResponse.tags:type_name -> flex.Tag
-	34, // 11: flex.ListFlexletsResponse.flexlets:type_name -> flex.FlexletStatus
-	35, // 12: flex.GetStatsResponse.stats:type_name -> flex.Stats
-	1,  // 13: flex.FlexService.SubmitJob:input_type -> flex.SubmitJobRequest
-	3,  // 14: flex.FlexService.CancelJob:input_type -> flex.CancelJobRequest
-	5,  // 15: flex.FlexService.GetJob:input_type -> flex.GetJobRequest
-	7,  // 16: flex.FlexService.GetJobOutput:input_type -> flex.GetJobOutputRequest
-	9,  // 17: flex.FlexService.ListJobs:input_type -> flex.ListJobsRequest
-	11, // 18: flex.FlexService.UpdateJobLabels:input_type -> flex.UpdateJobLabelsRequest
-	13, // 19: flex.FlexService.InsertPackage:input_type -> flex.InsertPackageRequest
-	15, // 20: flex.FlexService.GetPackage:input_type -> flex.GetPackageRequest
-	17, // 21: flex.FlexService.FetchPackage:input_type -> flex.FetchPackageRequest
-	19, // 22: flex.FlexService.UpdateTag:input_type -> flex.UpdateTagRequest
-	21, // 23: flex.FlexService.ListTags:input_type -> flex.ListTagsRequest
-	23, // 24: flex.FlexService.ListFlexlets:input_type -> flex.ListFlexletsRequest
-	25, // 25: flex.FlexService.GetStats:input_type -> flex.GetStatsRequest
-	2,  // 26: flex.FlexService.SubmitJob:output_type -> flex.SubmitJobResponse
-	4,  // 27: flex.FlexService.CancelJob:output_type -> flex.CancelJobResponse
-	6,  // 28: flex.FlexService.GetJob:output_type -> flex.GetJobResponse
-	8,  // 29: flex.FlexService.GetJobOutput:output_type -> flex.GetJobOutputResponse
-	10, // 30: flex.FlexService.ListJobs:output_type -> flex.ListJobsResponse
-	12, // 31: flex.FlexService.UpdateJobLabels:output_type -> flex.UpdateJobLabelsResponse
-	14, // 32: flex.FlexService.InsertPackage:output_type -> flex.InsertPackageResponse
-	16, // 33: flex.FlexService.GetPackage:output_type -> flex.GetPackageResponse
-	18, // 34: flex.FlexService.FetchPackage:output_type -> flex.FetchPackageResponse
-	20, // 35: flex.FlexService.UpdateTag:output_type -> flex.UpdateTagResponse
-	22, // 36: flex.FlexService.ListTags:output_type -> flex.ListTagsResponse
-	24, // 37: flex.FlexService.ListFlexlets:output_type -> flex.ListFlexletsResponse
-	26, // 38: flex.FlexService.GetStats:output_type -> flex.GetStatsResponse
-	26, // [26:39] is the sub-list for method output_type
-	13, // [13:26] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
-}
-
-func init() { file_flex_service_proto_init() }
-func file_flex_service_proto_init() {
-	if File_flex_service_proto != nil {
-		return
-	}
-	file_flex_proto_init()
-	if !protoimpl.UnsafeEnabled {
-		file_flex_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubmitJobRequest); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchPackageRequest.ProtoReflect.Descriptor instead.
+func (*FetchPackageRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (m *FetchPackageRequest) GetType() isFetchPackageRequest_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (x *FetchPackageRequest) GetHash() string {
+	if x, ok := x.GetType().(*FetchPackageRequest_Hash); ok {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *FetchPackageRequest) GetTag() string {
+	if x, ok := x.GetType().(*FetchPackageRequest_Tag); ok {
+		return x.Tag
+	}
+	return ""
+}
+
+type isFetchPackageRequest_Type interface {
+	isFetchPackageRequest_Type()
+}
+
+type FetchPackageRequest_Hash struct {
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3,oneof"`
+}
+
+type FetchPackageRequest_Tag struct {
+	Tag string `protobuf:"bytes,2,opt,name=tag,proto3,oneof"`
+}
+
+func (*FetchPackageRequest_Hash) isFetchPackageRequest_Type() {}
+
+func (*FetchPackageRequest_Tag) isFetchPackageRequest_Type() {}
+
+type FetchPackageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Location *FileLocation `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+}
+
+func (x *FetchPackageResponse) Reset() {
+	*x = FetchPackageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FetchPackageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchPackageResponse) ProtoMessage() {}
+
+func (x *FetchPackageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchPackageResponse.ProtoReflect.Descriptor instead.
+func (*FetchPackageResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *FetchPackageResponse) GetLocation() *FileLocation {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+type UpdateTagRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag *Tag `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+}
+
+func (x *UpdateTagRequest) Reset() {
+	*x = UpdateTagRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTagRequest) ProtoMessage() {}
+
+func (x *UpdateTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTagRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTagRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UpdateTagRequest) GetTag() *Tag {
+	if x != nil {
+		return x.Tag
+	}
+	return nil
+}
+
+type UpdateTagResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UpdateTagResponse) Reset() {
+	*x = UpdateTagResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateTagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTagResponse) ProtoMessage() {}
+
+func (x *UpdateTagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTagResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTagResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{33}
+}
+
+type ListTagsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsRequest) ProtoMessage() {}
+
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{34}
+}
+
+type ListTagsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tags []*Tag `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListTagsResponse) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type ListFlexletsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListFlexletsRequest) Reset() {
+	*x = ListFlexletsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListFlexletsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFlexletsRequest) ProtoMessage() {}
+
+func (x *ListFlexletsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFlexletsRequest.ProtoReflect.Descriptor instead.
+func (*ListFlexletsRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{36}
+}
+
+type ListFlexletsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Flexlets []*FlexletStatus `protobuf:"bytes,1,rep,name=flexlets,proto3" json:"flexlets,omitempty"`
+}
+
+func (x *ListFlexletsResponse) Reset() {
+	*x = ListFlexletsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListFlexletsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFlexletsResponse) ProtoMessage() {}
+
+func (x *ListFlexletsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFlexletsResponse.ProtoReflect.Descriptor instead.
+func (*ListFlexletsResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListFlexletsResponse) GetFlexlets() []*FlexletStatus {
+	if x != nil {
+		return x.Flexlets
+	}
+	return nil
+}
+
+type GetFlexletRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GetFlexletRequest) Reset() {
+	*x = GetFlexletRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFlexletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFlexletRequest) ProtoMessage() {}
+
+func (x *GetFlexletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFlexletRequest.ProtoReflect.Descriptor instead.
+func (*GetFlexletRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetFlexletRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetFlexletResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Flexlet     *FlexletStatus    `protobuf:"bytes,1,opt,name=flexlet,proto3" json:"flexlet,omitempty"`
+	RecentTasks []*FlexletTask    `protobuf:"bytes,2,rep,name=recent_tasks,json=recentTasks,proto3" json:"recent_tasks,omitempty"`
+	TaskStats   *FlexletTaskStats `protobuf:"bytes,3,opt,name=task_stats,json=taskStats,proto3" json:"task_stats,omitempty"`
+}
+
+func (x *GetFlexletResponse) Reset() {
+	*x = GetFlexletResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFlexletResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFlexletResponse) ProtoMessage() {}
+
+func (x *GetFlexletResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFlexletResponse.ProtoReflect.Descriptor instead.
+func (*GetFlexletResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetFlexletResponse) GetFlexlet() *FlexletStatus {
+	if x != nil {
+		return x.Flexlet
+	}
+	return nil
+}
+
+func (x *GetFlexletResponse) GetRecentTasks() []*FlexletTask {
+	if x != nil {
+		return x.RecentTasks
+	}
+	return nil
+}
+
+func (x *GetFlexletResponse) GetTaskStats() *FlexletTaskStats {
+	if x != nil {
+		return x.TaskStats
+	}
+	return nil
+}
+
+type DrainFlexletRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *DrainFlexletRequest) Reset() {
+	*x = DrainFlexletRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DrainFlexletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainFlexletRequest) ProtoMessage() {}
+
+func (x *DrainFlexletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainFlexletRequest.ProtoReflect.Descriptor instead.
+func (*DrainFlexletRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DrainFlexletRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DrainFlexletResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DrainFlexletResponse) Reset() {
+	*x = DrainFlexletResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DrainFlexletResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainFlexletResponse) ProtoMessage() {}
+
+func (x *DrainFlexletResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainFlexletResponse.ProtoReflect.Descriptor instead.
+func (*DrainFlexletResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{41}
+}
+
+type CordonFlexletRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *CordonFlexletRequest) Reset() {
+	*x = CordonFlexletRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CordonFlexletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CordonFlexletRequest) ProtoMessage() {}
+
+func (x *CordonFlexletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CordonFlexletRequest.ProtoReflect.Descriptor instead.
+func (*CordonFlexletRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CordonFlexletRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CordonFlexletResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CordonFlexletResponse) Reset() {
+	*x = CordonFlexletResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CordonFlexletResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CordonFlexletResponse) ProtoMessage() {}
+
+func (x *CordonFlexletResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CordonFlexletResponse.ProtoReflect.Descriptor instead.
+func (*CordonFlexletResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{43}
+}
+
+type UncordonFlexletRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *UncordonFlexletRequest) Reset() {
+	*x = UncordonFlexletRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UncordonFlexletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UncordonFlexletRequest) ProtoMessage() {}
+
+func (x *UncordonFlexletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UncordonFlexletRequest.ProtoReflect.Descriptor instead.
+func (*UncordonFlexletRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *UncordonFlexletRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UncordonFlexletResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UncordonFlexletResponse) Reset() {
+	*x = UncordonFlexletResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UncordonFlexletResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UncordonFlexletResponse) ProtoMessage() {}
+
+func (x *UncordonFlexletResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UncordonFlexletResponse.ProtoReflect.Descriptor instead.
+func (*UncordonFlexletResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{45}
+}
+
+type DeleteFlexletRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *DeleteFlexletRequest) Reset() {
+	*x = DeleteFlexletRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteFlexletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFlexletRequest) ProtoMessage() {}
+
+func (x *DeleteFlexletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFlexletRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFlexletRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DeleteFlexletRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteFlexletResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteFlexletResponse) Reset() {
+	*x = DeleteFlexletResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteFlexletResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFlexletResponse) ProtoMessage() {}
+
+func (x *DeleteFlexletResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFlexletResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFlexletResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{47}
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{48}
+}
+
+type GetStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stats *Stats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetStatsResponse) GetStats() *Stats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type ListWebhookDeliveriesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Limit    int64 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	BeforeId int64 `protobuf:"varint,2,opt,name=before_id,json=beforeId,proto3" json:"before_id,omitempty"`
+	JobId    int64 `protobuf:"varint,3,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListWebhookDeliveriesRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesRequest) GetBeforeId() int64 {
+	if x != nil {
+		return x.BeforeId
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesRequest) GetJobId() int64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+type ListWebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deliveries []*WebhookDelivery `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+}
+
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flex_service_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flex_service_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_flex_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+var File_flex_service_proto protoreflect.FileDescriptor
+
+var file_flex_service_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x66, 0x6c, 0x65, 0x78, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x66, 0x6c, 0x65, 0x78, 0x1a, 0x0a, 0x66, 0x6c, 0x65, 0x78,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x5b, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74,
+	0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x04, 0x73, 0x70,
+	0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e,
+	0x4a, 0x6f, 0x62, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x12, 0x24, 0x0a,
+	0x05, 0x61, 0x72, 0x72, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x66,
+	0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x41, 0x72, 0x72, 0x61, 0x79, 0x52, 0x05, 0x61, 0x72,
+	0x72, 0x61, 0x79, 0x22, 0x3e, 0x0a, 0x11, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x4a, 0x6f, 0x62,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x72, 0x72, 0x61,
+	0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x61, 0x72, 0x72, 0x61,
+	0x79, 0x49, 0x64, 0x22, 0x22, 0x0a, 0x10, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x13, 0x0a, 0x11, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x7a, 0x0a, 0x0f,
+	0x43, 0x6c, 0x6f, 0x6e, 0x65, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x34, 0x0a, 0x09, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x70, 0x65,
+	0x63, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x52, 0x09, 0x6f, 0x76, 0x65, 0x72,
+	0x72, 0x69, 0x64, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65,
+	0x5f, 0x74, 0x61, 0x67, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x72, 0x65, 0x73,
+	0x6f, 0x6c, 0x76, 0x65, 0x54, 0x61, 0x67, 0x73, 0x22, 0x22, 0x0a, 0x10, 0x43, 0x6c, 0x6f, 0x6e,
+	0x65, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x1f, 0x0a, 0x0d,
+	0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x33, 0x0a,
+	0x0e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x21, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x66,
+	0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x03, 0x6a,
+	0x6f, 0x62, 0x22, 0x94, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x4f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3b, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e,
+	0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x4a, 0x6f, 0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x30, 0x0a, 0x0d, 0x4a, 0x6f, 0x62, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x44, 0x4f,
+	0x55, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x44, 0x45, 0x52, 0x52, 0x10, 0x01,
+	0x12, 0x07, 0x0a, 0x03, 0x4c, 0x4f, 0x47, 0x10, 0x02, 0x22, 0x71, 0x0a, 0x14, 0x47, 0x65, 0x74,
+	0x4a, 0x6f, 0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2e, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x65, 0x6e, 0x63,
+	0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x22, 0xbe, 0x01, 0x0a,
+	0x14, 0x52, 0x65, 0x61, 0x64, 0x4a, 0x6f, 0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3b, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f,
+	0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4a,
+	0x6f, 0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67,
+	0x74, 0x68, 0x12, 0x29, 0x0a, 0x10, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x5f, 0x65, 0x6e, 0x63,
+	0x6f, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x61, 0x63,
+	0x63, 0x65, 0x70, 0x74, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x56, 0x0a,
+	0x15, 0x52, 0x65, 0x61, 0x64, 0x4a, 0x6f, 0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x63,
+	0x6f, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x9b, 0x01, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f,
+	0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x66, 0x6c,
+	0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x72, 0x72, 0x61,
+	0x79, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x61, 0x72, 0x72, 0x61,
+	0x79, 0x49, 0x64, 0x22, 0x37, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x22, 0x50, 0x0a, 0x16,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x64, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65,
+	0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x64, 0x65, 0x6c, 0x73, 0x22, 0x19,
+	0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x24, 0x0a, 0x12, 0x47, 0x65, 0x74,
+	0x4a, 0x6f, 0x62, 0x41, 0x72, 0x72, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0x41, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x41, 0x72, 0x72, 0x61, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x05, 0x61, 0x72, 0x72, 0x61, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62,
+	0x41, 0x72, 0x72, 0x61, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x61, 0x72, 0x72,
+	0x61, 0x79, 0x22, 0x4d, 0x0a, 0x10, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x03, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x27, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e,
+	0x4a, 0x6f, 0x62, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x22, 0x36, 0x0a, 0x11, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x22, 0x6d, 0x0a, 0x1a, 0x42, 0x75, 0x6c,
+	0x6b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a,
+	0x6f, 0x62, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04,
+	0x61, 0x64, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x04, 0x64, 0x65, 0x6c, 0x73, 0x22, 0x4c, 0x0a, 0x1b, 0x42, 0x75, 0x6c, 0x6b,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e,
+	0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x40, 0x0a, 0x15, 0x42, 0x75, 0x6c, 0x6b, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x27, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x47, 0x0a, 0x16, 0x42, 0x75, 0x6c, 0x6b,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x4a,
+	0x6f, 0x62, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x22, 0x3f, 0x0a, 0x14, 0x42, 0x75, 0x6c, 0x6b, 0x52, 0x65, 0x74, 0x72, 0x79, 0x4a, 0x6f,
+	0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x06, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x66, 0x6c, 0x65, 0x78,
+	0x2e, 0x4a, 0x6f, 0x62, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x22, 0x46, 0x0a, 0x15, 0x42, 0x75, 0x6c, 0x6b, 0x52, 0x65, 0x74, 0x72, 0x79, 0x4a,
+	0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x66,
+	0x6c, 0x65, 0x78, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x5d, 0x0a, 0x14, 0x49, 0x6e,
+	0x73, 0x65, 0x72, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x27, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x53,
+	0x70, 0x65, 0x63, 0x48, 0x00, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x12, 0x14, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x2b, 0x0a, 0x15, 0x49, 0x6e, 0x73,
+	0x65, 0x72, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x45, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x68, 0x61, 0x73,
+	0x68, 0x12, 0x12, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x03, 0x74, 0x61, 0x67, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x3d, 0x0a,
+	0x12, 0x47, 0x65, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x52, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x22, 0x47, 0x0a, 0x13,
+	0x46, 0x65, 0x74, 0x63, 0x68, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x03, 0x74, 0x61, 0x67,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x74, 0x61, 0x67, 0x42, 0x06, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x46, 0x0a, 0x14, 0x46, 0x65, 0x74, 0x63, 0x68, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a,
+	0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2f, 0x0a,
+	0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1b, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09,
+	0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x03, 0x74, 0x61, 0x67, 0x22, 0x13,
+	0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x11, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x31, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61,
+	0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x04, 0x74, 0x61,
+	0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e,
+	0x54, 0x61, 0x67, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0x15, 0x0a, 0x13, 0x4c, 0x69, 0x73,
+	0x74, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x47, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x66, 0x6c, 0x65, 0x78,
+	0x6c, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x66, 0x6c, 0x65,
+	0x78, 0x2e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x08, 0x66, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x73, 0x22, 0x27, 0x0a, 0x11, 0x47, 0x65, 0x74,
+	0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0xb0, 0x01, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x66, 0x6c, 0x65,
+	0x78, 0x6c, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x66, 0x6c, 0x65,
+	0x78, 0x2e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x07, 0x66, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x12, 0x34, 0x0a, 0x0c, 0x72, 0x65, 0x63, 0x65,
+	0x6e, 0x74, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x54, 0x61, 0x73,
+	0x6b, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x35,
+	0x0a, 0x0a, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65,
+	0x74, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x09, 0x74, 0x61, 0x73, 0x6b,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x22, 0x29, 0x0a, 0x13, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x46, 0x6c,
+	0x65, 0x78, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x22, 0x16, 0x0a, 0x14, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2a, 0x0a, 0x14, 0x43, 0x6f, 0x72, 0x64,
+	0x6f, 0x6e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x22, 0x17, 0x0a, 0x15, 0x43, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x46, 0x6c,
+	0x65, 0x78, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2c, 0x0a,
+	0x16, 0x55, 0x6e, 0x63, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x19, 0x0a, 0x17, 0x55,
+	0x6e, 0x63, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2a, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0x17, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x6c, 0x65, 0x78,
+	0x6c, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x11, 0x0a, 0x0f, 0x47,
+	0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x35,
+	0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0x68, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x65, 0x62,
+	0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x62,
+	0x65, 0x66, 0x6f, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x22,
+	0x56, 0x0a, 0x1d, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x35, 0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x57, 0x65, 0x62, 0x68,
+	0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x52, 0x0a, 0x64, 0x65, 0x6c,
+	0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x32, 0xdf, 0x0e, 0x0a, 0x0b, 0x46, 0x6c, 0x65, 0x78,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3e, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x6d, 0x69,
+	0x74, 0x4a, 0x6f, 0x62, 0x12, 0x16, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x53, 0x75, 0x62, 0x6d,
+	0x69, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x66,
+	0x6c, 0x65, 0x78, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x09, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x4a, 0x6f, 0x62, 0x12, 0x16, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x43, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x66,
+	0x6c, 0x65, 0x78, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x08, 0x43, 0x6c, 0x6f, 0x6e, 0x65,
+	0x4a, 0x6f, 0x62, 0x12, 0x15, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x43, 0x6c, 0x6f, 0x6e, 0x65,
+	0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x66, 0x6c, 0x65,
+	0x78, 0x2e, 0x43, 0x6c, 0x6f, 0x6e, 0x65, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x12, 0x13,
+	0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f,
+	0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0c, 0x47,
+	0x65, 0x74, 0x4a, 0x6f, 0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x19, 0x2e, 0x66, 0x6c,
+	0x65, 0x78, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x47, 0x65,
+	0x74, 0x4a, 0x6f, 0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0d, 0x52, 0x65, 0x61, 0x64, 0x4a, 0x6f, 0x62, 0x4f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x1a, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x52, 0x65, 0x61,
+	0x64, 0x4a, 0x6f, 0x62, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1b, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x4a, 0x6f, 0x62,
+	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x30, 0x01, 0x12, 0x3b, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x15,
+	0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x50, 0x0a, 0x0f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x12, 0x1c, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x4a, 0x6f, 0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1d, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4a, 0x6f,
+	0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x44, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x41, 0x72, 0x72, 0x61, 0x79,
+	0x12, 0x18, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x41, 0x72,
+	0x72, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x66, 0x6c, 0x65,
+	0x78, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x41, 0x72, 0x72, 0x61, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x09, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x4a, 0x6f, 0x62, 0x73, 0x12, 0x16, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x66,
+	0x6c, 0x65, 0x78, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x5c, 0x0a, 0x13, 0x42, 0x75, 0x6c,
+	0x6b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x12, 0x20, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x4a, 0x6f, 0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x21, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4d, 0x0a, 0x0e, 0x42, 0x75, 0x6c, 0x6b, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x1b, 0x2e, 0x66, 0x6c, 0x65, 0x78,
+	0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x42, 0x75,
+	0x6c, 0x6b, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0d, 0x42, 0x75, 0x6c, 0x6b, 0x52, 0x65,
+	0x74, 0x72, 0x79, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x1a, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x42,
+	0x75, 0x6c, 0x6b, 0x52, 0x65, 0x74, 0x72, 0x79, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x52,
+	0x65, 0x74, 0x72, 0x79, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0d, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x12, 0x1a, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x49, 0x6e, 0x73, 0x65, 0x72,
+	0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1b, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x50, 0x61, 0x63,
+	0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01,
+	0x12, 0x41, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x17,
+	0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x47,
+	0x65, 0x74, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0c, 0x46, 0x65, 0x74, 0x63, 0x68, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x12, 0x19, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68,
+	0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a,
+	0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x50, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x09,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x12, 0x16, 0x2e, 0x66, 0x6c, 0x65, 0x78,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x17, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54,
+	0x61, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x08,
+	0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x73, 0x12, 0x15, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x16, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x67, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0c, 0x4c, 0x69, 0x73,
+	0x74, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x73, 0x12, 0x19, 0x2e, 0x66, 0x6c, 0x65, 0x78,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x41, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74,
+	0x12, 0x17, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x6c, 0x65, 0x78, 0x6c,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x66, 0x6c, 0x65, 0x78,
+	0x2e, 0x47, 0x65, 0x74, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0c, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x46, 0x6c,
+	0x65, 0x78, 0x6c, 0x65, 0x74, 0x12, 0x19, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x44, 0x72, 0x61,
+	0x69, 0x6e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1a, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x46, 0x6c, 0x65,
+	0x78, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4a,
+	0x0a, 0x0d, 0x43, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x12,
+	0x1a, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x43, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x46, 0x6c, 0x65,
+	0x78, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x66, 0x6c,
+	0x65, 0x78, 0x2e, 0x43, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x0f, 0x55, 0x6e,
+	0x63, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x12, 0x1c, 0x2e,
+	0x66, 0x6c, 0x65, 0x78, 0x2e, 0x55, 0x6e, 0x63, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x46, 0x6c, 0x65,
+	0x78, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x66, 0x6c,
+	0x65, 0x78, 0x2e, 0x55, 0x6e, 0x63, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x46, 0x6c, 0x65, 0x78, 0x6c,
+	0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0d,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x12, 0x1a, 0x2e,
+	0x66, 0x6c, 0x65, 0x78, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x6c, 0x65, 0x78, 0x6c,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x66, 0x6c, 0x65, 0x78,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x6c, 0x65, 0x78, 0x6c, 0x65, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x15, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x66, 0x6c,
+	0x65, 0x78, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x62, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x65, 0x62,
+	0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x22,
+	0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f,
+	0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x23, 0x2e, 0x66, 0x6c, 0x65, 0x78, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x65,
+	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x18, 0x5a, 0x16, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x79, 0x61, 0x33, 0x6a, 0x70, 0x2f, 0x66,
+	0x6c, 0x65, 0x78, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_flex_service_proto_rawDescOnce sync.Once
+	file_flex_service_proto_rawDescData = file_flex_service_proto_rawDesc
+)
+
+func file_flex_service_proto_rawDescGZIP() []byte {
+	file_flex_service_proto_rawDescOnce.Do(func() {
+		file_flex_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_flex_service_proto_rawDescData)
+	})
+	return file_flex_service_proto_rawDescData
+}
+
+var file_flex_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_flex_service_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
+var file_flex_service_proto_goTypes = []interface{}{
+	(GetJobOutputRequest_JobOutputType)(0), // 0: flex.GetJobOutputRequest.JobOutputType
+	(*SubmitJobRequest)(nil),               // 1: flex.SubmitJobRequest
+	(*SubmitJobResponse)(nil),              // 2: flex.SubmitJobResponse
+	(*CancelJobRequest)(nil),               // 3: flex.CancelJobRequest
+	(*CancelJobResponse)(nil),              // 4: flex.CancelJobResponse
+	(*CloneJobRequest)(nil),                // 5: flex.CloneJobRequest
+	(*CloneJobResponse)(nil),               // 6: flex.CloneJobResponse
+	(*GetJobRequest)(nil),                  // 7: flex.GetJobRequest
+	(*GetJobResponse)(nil),                 // 8: flex.GetJobResponse
+	(*GetJobOutputRequest)(nil),            // 9: flex.GetJobOutputRequest
+	(*GetJobOutputResponse)(nil),           // 10: flex.GetJobOutputResponse
+	(*ReadJobOutputRequest)(nil),           // 11: flex.ReadJobOutputRequest
+	(*ReadJobOutputResponse)(nil),          // 12: flex.ReadJobOutputResponse
+	(*ListJobsRequest)(nil),                // 13: flex.ListJobsRequest
+	(*ListJobsResponse)(nil),               // 14: flex.ListJobsResponse
+	(*UpdateJobLabelsRequest)(nil),         // 15: flex.UpdateJobLabelsRequest
+	(*UpdateJobLabelsResponse)(nil),        // 16: flex.UpdateJobLabelsResponse
+	(*GetJobArrayRequest)(nil),             // 17: flex.GetJobArrayRequest
+	(*GetJobArrayResponse)(nil),            // 18: flex.GetJobArrayResponse
+	(*WatchJobsRequest)(nil),               // 19: flex.WatchJobsRequest
+	(*WatchJobsResponse)(nil),              // 20: flex.WatchJobsResponse
+	(*BulkUpdateJobLabelsRequest)(nil),     // 21: flex.BulkUpdateJobLabelsRequest
+	(*BulkUpdateJobLabelsResponse)(nil),    // 22: flex.BulkUpdateJobLabelsResponse
+	(*BulkCancelJobsRequest)(nil),          // 23: flex.BulkCancelJobsRequest
+	(*BulkCancelJobsResponse)(nil),         // 24: flex.BulkCancelJobsResponse
+	(*BulkRetryJobsRequest)(nil),           // 25: flex.BulkRetryJobsRequest
+	(*BulkRetryJobsResponse)(nil),          // 26: flex.BulkRetryJobsResponse
+	(*InsertPackageRequest)(nil),           // 27: flex.InsertPackageRequest
+	(*InsertPackageResponse)(nil),          // 28: flex.InsertPackageResponse
+	(*GetPackageRequest)(nil),              // 29: flex.GetPackageRequest
+	(*GetPackageResponse)(nil),             // 30: flex.GetPackageResponse
+	(*FetchPackageRequest)(nil),            // 31: flex.FetchPackageRequest
+	(*FetchPackageResponse)(nil),           // 32: flex.FetchPackageResponse
+	(*UpdateTagRequest)(nil),               // 33: flex.UpdateTagRequest
+	(*UpdateTagResponse)(nil),              // 34: flex.UpdateTagResponse
+	(*ListTagsRequest)(nil),                // 35: flex.ListTagsRequest
+	(*ListTagsResponse)(nil),               // 36: flex.ListTagsResponse
+	(*ListFlexletsRequest)(nil),            // 37: flex.ListFlexletsRequest
+	(*ListFlexletsResponse)(nil),           // 38: flex.ListFlexletsResponse
+	(*GetFlexletRequest)(nil),              // 39: flex.GetFlexletRequest
+	(*GetFlexletResponse)(nil),             // 40: flex.GetFlexletResponse
+	(*DrainFlexletRequest)(nil),            // 41: flex.DrainFlexletRequest
+	(*DrainFlexletResponse)(nil),           // 42: flex.DrainFlexletResponse
+	(*CordonFlexletRequest)(nil),           // 43: flex.CordonFlexletRequest
+	(*CordonFlexletResponse)(nil),          // 44: flex.CordonFlexletResponse
+	(*UncordonFlexletRequest)(nil),         // 45: flex.UncordonFlexletRequest
+	(*UncordonFlexletResponse)(nil),        // 46: flex.UncordonFlexletResponse
+	(*DeleteFlexletRequest)(nil),           // 47: flex.DeleteFlexletRequest
+	(*DeleteFlexletResponse)(nil),          // 48: flex.DeleteFlexletResponse
+	(*GetStatsRequest)(nil),                // 49: flex.GetStatsRequest
+	(*GetStatsResponse)(nil),               // 50: flex.GetStatsResponse
+	(*ListWebhookDeliveriesRequest)(nil),   // 51: flex.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil),  // 52: flex.ListWebhookDeliveriesResponse
+	(*JobSpec)(nil),                        // 53: flex.JobSpec
+	(*JobArray)(nil),                       // 54: flex.JobArray
+	(*JobSpecOverrides)(nil),               // 55: flex.JobSpecOverrides
+	(*JobStatus)(nil),                      // 56: flex.JobStatus
+	(*FileLocation)(nil),                   // 57: flex.FileLocation
+	(JobState)(0),                          // 58: flex.JobState
+	(*JobArrayStatus)(nil),                 // 59: flex.JobArrayStatus
+	(*JobFilter)(nil),                      // 60: flex.JobFilter
+	(*BulkJobResult)(nil),                  // 61: flex.BulkJobResult
+	(*PackageSpec)(nil),                    // 62: flex.PackageSpec
+	(*Package)(nil),                        // 63: flex.Package
+	(*Tag)(nil),                            // 64: flex.Tag
+	(*FlexletStatus)(nil),                  // 65: flex.FlexletStatus
+	(*FlexletTask)(nil),                    // 66: flex.FlexletTask
+	(*FlexletTaskStats)(nil),               // 67: flex.FlexletTaskStats
+	(*Stats)(nil),                          // 68: flex.Stats
+	(*WebhookDelivery)(nil),                // 69: flex.WebhookDelivery
+}
+var file_flex_service_proto_depIdxs = []int32{
+	53, // 0: flex.SubmitJobRequest.spec:type_name -> flex.JobSpec
+	54, // 1: flex.SubmitJobRequest.array:type_name -> flex.JobArray
+	55, // 2: flex.CloneJobRequest.overrides:type_name -> flex.JobSpecOverrides
+	56, // 3: flex.GetJobResponse.job:type_name -> flex.JobStatus
+	0,  // 4: flex.GetJobOutputRequest.type:type_name -> flex.GetJobOutputRequest.JobOutputType
+	57, // 5: flex.GetJobOutputResponse.location:type_name -> flex.FileLocation
+	0,  // 6: flex.ReadJobOutputRequest.type:type_name -> flex.GetJobOutputRequest.JobOutputType
+	58, // 7: flex.ListJobsRequest.state:type_name -> flex.JobState
+	56, // 8: flex.ListJobsResponse.jobs:type_name -> flex.JobStatus
+	59, // 9: flex.GetJobArrayResponse.array:type_name -> flex.JobArrayStatus
+	60, // 10: flex.WatchJobsRequest.filter:type_name -> flex.JobFilter
+	56, // 11: flex.WatchJobsResponse.job:type_name -> flex.JobStatus
+	60, // 12: flex.BulkUpdateJobLabelsRequest.filter:type_name -> flex.JobFilter
+	61, // 13: flex.BulkUpdateJobLabelsResponse.results:type_name -> flex.BulkJobResult
+	60, // 14: flex.BulkCancelJobsRequest.filter:type_name -> flex.JobFilter
+	61, // 15: flex.BulkCancelJobsResponse.results:type_name -> flex.BulkJobResult
+	60, // 16: flex.BulkRetryJobsRequest.filter:type_name -> flex.JobFilter
+	61, // 17: flex.BulkRetryJobsResponse.results:type_name -> flex.BulkJobResult
+	62, // 18: flex.InsertPackageRequest.spec:type_name -> flex.PackageSpec
+	63, // 19: flex.GetPackageResponse.package:type_name -> flex.Package
+	57, // 20: flex.FetchPackageResponse.location:type_name -> flex.FileLocation
+	64, // 21: flex.UpdateTagRequest.tag:type_name -> flex.Tag
+	64, // 22: flex.ListTagsResponse.tags:type_name -> flex.Tag
+	65, // 23: flex.ListFlexletsResponse.flexlets:type_name -> flex.FlexletStatus
+	65, // 24: flex.GetFlexletResponse.flexlet:type_name -> flex.FlexletStatus
+	66, // 25: flex.GetFlexletResponse.recent_tasks:type_name -> flex.FlexletTask
+	67, // 26: flex.GetFlexletResponse.task_stats:type_name -> flex.FlexletTaskStats
+	68, // 27: flex.GetStatsResponse.stats:type_name -> flex.Stats
+	69, // 28: flex.ListWebhookDeliveriesResponse.deliveries:type_name -> flex.WebhookDelivery
+	1,  // 29: flex.FlexService.SubmitJob:input_type -> flex.SubmitJobRequest
+	3,  // 30: flex.FlexService.CancelJob:input_type -> flex.CancelJobRequest
+	5,  // 31: flex.FlexService.CloneJob:input_type -> flex.CloneJobRequest
+	7,  // 32: flex.FlexService.GetJob:input_type -> flex.GetJobRequest
+	9,  // 33: flex.FlexService.GetJobOutput:input_type -> flex.GetJobOutputRequest
+	11, // 34: flex.FlexService.ReadJobOutput:input_type -> flex.ReadJobOutputRequest
+	13, // 35: flex.FlexService.ListJobs:input_type -> flex.ListJobsRequest
+	15, // 36: flex.FlexService.UpdateJobLabels:input_type -> flex.UpdateJobLabelsRequest
+	17, // 37: flex.FlexService.GetJobArray:input_type -> flex.GetJobArrayRequest
+	19, // 38: flex.FlexService.WatchJobs:input_type -> flex.WatchJobsRequest
+	21, // 39: flex.FlexService.BulkUpdateJobLabels:input_type -> flex.BulkUpdateJobLabelsRequest
+	23, // 40: flex.FlexService.BulkCancelJobs:input_type -> flex.BulkCancelJobsRequest
+	25, // 41: flex.FlexService.BulkRetryJobs:input_type -> flex.BulkRetryJobsRequest
+	27, // 42: flex.FlexService.InsertPackage:input_type -> flex.InsertPackageRequest
+	29, // 43: flex.FlexService.GetPackage:input_type -> flex.GetPackageRequest
+	31, // 44: flex.FlexService.FetchPackage:input_type -> flex.FetchPackageRequest
+	33, // 45: flex.FlexService.UpdateTag:input_type -> flex.UpdateTagRequest
+	35, // 46: flex.FlexService.ListTags:input_type -> flex.ListTagsRequest
+	37, // 47: flex.FlexService.ListFlexlets:input_type -> flex.ListFlexletsRequest
+	39, // 48: flex.FlexService.GetFlexlet:input_type -> flex.GetFlexletRequest
+	41, // 49: flex.FlexService.DrainFlexlet:input_type -> flex.DrainFlexletRequest
+	43, // 50: flex.FlexService.CordonFlexlet:input_type -> flex.CordonFlexletRequest
+	45, // 51: flex.FlexService.UncordonFlexlet:input_type -> flex.UncordonFlexletRequest
+	47, // 52: flex.FlexService.DeleteFlexlet:input_type -> flex.DeleteFlexletRequest
+	49, // 53: flex.FlexService.GetStats:input_type -> flex.GetStatsRequest
+	51, // 54: flex.FlexService.ListWebhookDeliveries:input_type -> flex.ListWebhookDeliveriesRequest
+	2,  // 55: flex.FlexService.SubmitJob:output_type -> flex.SubmitJobResponse
+	4,  // 56: flex.FlexService.CancelJob:output_type -> flex.CancelJobResponse
+	6,  // 57: flex.FlexService.CloneJob:output_type -> flex.CloneJobResponse
+	8,  // 58: flex.FlexService.GetJob:output_type -> flex.GetJobResponse
+	10, // 59: flex.FlexService.GetJobOutput:output_type -> flex.GetJobOutputResponse
+	12, // 60: flex.FlexService.ReadJobOutput:output_type -> flex.ReadJobOutputResponse
+	14, // 61: flex.FlexService.ListJobs:output_type -> flex.ListJobsResponse
+	16, // 62: flex.FlexService.UpdateJobLabels:output_type -> flex.UpdateJobLabelsResponse
+	18, // 63: flex.FlexService.GetJobArray:output_type -> flex.GetJobArrayResponse
+	20, // 64: flex.FlexService.WatchJobs:output_type -> flex.WatchJobsResponse
+	22, // 65: flex.FlexService.BulkUpdateJobLabels:output_type -> flex.BulkUpdateJobLabelsResponse
+	24, // 66: flex.FlexService.BulkCancelJobs:output_type -> flex.BulkCancelJobsResponse
+	26, // 67: flex.FlexService.BulkRetryJobs:output_type -> flex.BulkRetryJobsResponse
+	28, // 68: flex.FlexService.InsertPackage:output_type -> flex.InsertPackageResponse
+	30, // 69: flex.FlexService.GetPackage:output_type -> flex.GetPackageResponse
+	32, // 70: flex.FlexService.FetchPackage:output_type -> flex.FetchPackageResponse
+	34, // 71: flex.FlexService.UpdateTag:output_type -> flex.UpdateTagResponse
+	36, // 72: flex.FlexService.ListTags:output_type -> flex.ListTagsResponse
+	38, // 73: flex.FlexService.ListFlexlets:output_type -> flex.ListFlexletsResponse
+	40, // 74: flex.FlexService.GetFlexlet:output_type -> flex.GetFlexletResponse
+	42, // 75: flex.FlexService.DrainFlexlet:output_type -> flex.DrainFlexletResponse
+	44, // 76: flex.FlexService.CordonFlexlet:output_type -> flex.CordonFlexletResponse
+	46, // 77: flex.FlexService.UncordonFlexlet:output_type -> flex.UncordonFlexletResponse
+	48, // 78: flex.FlexService.DeleteFlexlet:output_type -> flex.DeleteFlexletResponse
+	50, // 79: flex.FlexService.GetStats:output_type -> flex.GetStatsResponse
+	52, // 80: flex.FlexService.ListWebhookDeliveries:output_type -> flex.ListWebhookDeliveriesResponse
+	55, // [55:81] is the sub-list for method output_type
+	29, // [29:55] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
+}
+
+func init() { file_flex_service_proto_init() }
+func file_flex_service_proto_init() {
+	if File_flex_service_proto != nil {
+		return
+	}
+	file_flex_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_flex_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubmitJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubmitJobResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelJobResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloneJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloneJobResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobOutputRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobOutputResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadJobOutputRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReadJobOutputResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListJobsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListJobsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1671,8 +3436,8 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubmitJobResponse); i {
+		file_flex_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateJobLabelsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1683,8 +3448,8 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CancelJobRequest); i {
+		file_flex_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateJobLabelsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1695,8 +3460,8 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CancelJobResponse); i {
+		file_flex_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobArrayRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1707,8 +3472,8 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetJobRequest); i {
+		file_flex_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobArrayResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1719,8 +3484,8 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetJobResponse); i {
+		file_flex_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchJobsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1731,8 +3496,8 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetJobOutputRequest); i {
+		file_flex_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchJobsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1743,8 +3508,8 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetJobOutputResponse); i {
+		file_flex_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkUpdateJobLabelsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1755,8 +3520,8 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListJobsRequest); i {
+		file_flex_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkUpdateJobLabelsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1767,8 +3532,8 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListJobsResponse); i {
+		file_flex_service_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkCancelJobsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1779,8 +3544,8 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateJobLabelsRequest); i {
+		file_flex_service_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkCancelJobsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1791,8 +3556,8 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateJobLabelsResponse); i {
+		file_flex_service_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkRetryJobsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1803,7 +3568,19 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_flex_service_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BulkRetryJobsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*InsertPackageRequest); i {
 			case 0:
 				return &v.state
@@ -1815,7 +3592,7 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_flex_service_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*InsertPackageResponse); i {
 			case 0:
 				return &v.state
@@ -1827,7 +3604,7 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_flex_service_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetPackageRequest); i {
 			case 0:
 				return &v.state
@@ -1839,7 +3616,7 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_flex_service_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetPackageResponse); i {
 			case 0:
 				return &v.state
@@ -1851,7 +3628,7 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_flex_service_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FetchPackageRequest); i {
 			case 0:
 				return &v.state
@@ -1863,7 +3640,7 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_flex_service_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FetchPackageResponse); i {
 			case 0:
 				return &v.state
@@ -1875,7 +3652,7 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_flex_service_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateTagRequest); i {
 			case 0:
 				return &v.state
@@ -1887,7 +3664,7 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_flex_service_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateTagResponse); i {
 			case 0:
 				return &v.state
@@ -1899,7 +3676,7 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_flex_service_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListTagsRequest); i {
 			case 0:
 				return &v.state
@@ -1911,7 +3688,7 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_flex_service_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListTagsResponse); i {
 			case 0:
 				return &v.state
@@ -1923,7 +3700,7 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_flex_service_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListFlexletsRequest); i {
 			case 0:
 				return &v.state
@@ -1935,7 +3712,7 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+		file_flex_service_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListFlexletsResponse); i {
 			case 0:
 				return &v.state
@@ -1947,7 +3724,127 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+		file_flex_service_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFlexletRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFlexletResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DrainFlexletRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DrainFlexletResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CordonFlexletRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CordonFlexletResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UncordonFlexletRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UncordonFlexletResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteFlexletRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteFlexletResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetStatsRequest); i {
 			case 0:
 				return &v.state
@@ -1959,7 +3856,7 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
-		file_flex_service_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_flex_service_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetStatsResponse); i {
 			case 0:
 				return &v.state
@@ -1971,16 +3868,40 @@ func file_flex_service_proto_init() {
 				return nil
 			}
 		}
+		file_flex_service_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListWebhookDeliveriesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flex_service_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListWebhookDeliveriesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_flex_service_proto_msgTypes[12].OneofWrappers = []interface{}{
+	file_flex_service_proto_msgTypes[26].OneofWrappers = []interface{}{
 		(*InsertPackageRequest_Spec)(nil),
 		(*InsertPackageRequest_Data)(nil),
 	}
-	file_flex_service_proto_msgTypes[14].OneofWrappers = []interface{}{
+	file_flex_service_proto_msgTypes[28].OneofWrappers = []interface{}{
 		(*GetPackageRequest_Hash)(nil),
 		(*GetPackageRequest_Tag)(nil),
 	}
-	file_flex_service_proto_msgTypes[16].OneofWrappers = []interface{}{
+	file_flex_service_proto_msgTypes[30].OneofWrappers = []interface{}{
 		(*FetchPackageRequest_Hash)(nil),
 		(*FetchPackageRequest_Tag)(nil),
 	}
@@ -1990,7 +3911,7 @@ func file_flex_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_flex_service_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   26,
+			NumMessages:   52,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
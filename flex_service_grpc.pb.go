@@ -24,17 +24,30 @@ const _ = grpc.SupportPackageIsVersion7
 type FlexServiceClient interface {
 	SubmitJob(ctx context.Context, in *SubmitJobRequest, opts ...grpc.CallOption) (*SubmitJobResponse, error)
 	CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*CancelJobResponse, error)
+	CloneJob(ctx context.Context, in *CloneJobRequest, opts ...grpc.CallOption) (*CloneJobResponse, error)
 	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error)
 	GetJobOutput(ctx context.Context, in *GetJobOutputRequest, opts ...grpc.CallOption) (*GetJobOutputResponse, error)
+	ReadJobOutput(ctx context.Context, in *ReadJobOutputRequest, opts ...grpc.CallOption) (FlexService_ReadJobOutputClient, error)
 	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
 	UpdateJobLabels(ctx context.Context, in *UpdateJobLabelsRequest, opts ...grpc.CallOption) (*UpdateJobLabelsResponse, error)
+	GetJobArray(ctx context.Context, in *GetJobArrayRequest, opts ...grpc.CallOption) (*GetJobArrayResponse, error)
+	WatchJobs(ctx context.Context, in *WatchJobsRequest, opts ...grpc.CallOption) (FlexService_WatchJobsClient, error)
+	BulkUpdateJobLabels(ctx context.Context, in *BulkUpdateJobLabelsRequest, opts ...grpc.CallOption) (*BulkUpdateJobLabelsResponse, error)
+	BulkCancelJobs(ctx context.Context, in *BulkCancelJobsRequest, opts ...grpc.CallOption) (*BulkCancelJobsResponse, error)
+	BulkRetryJobs(ctx context.Context, in *BulkRetryJobsRequest, opts ...grpc.CallOption) (*BulkRetryJobsResponse, error)
 	InsertPackage(ctx context.Context, opts ...grpc.CallOption) (FlexService_InsertPackageClient, error)
 	GetPackage(ctx context.Context, in *GetPackageRequest, opts ...grpc.CallOption) (*GetPackageResponse, error)
 	FetchPackage(ctx context.Context, in *FetchPackageRequest, opts ...grpc.CallOption) (*FetchPackageResponse, error)
 	UpdateTag(ctx context.Context, in *UpdateTagRequest, opts ...grpc.CallOption) (*UpdateTagResponse, error)
 	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
 	ListFlexlets(ctx context.Context, in *ListFlexletsRequest, opts ...grpc.CallOption) (*ListFlexletsResponse, error)
+	GetFlexlet(ctx context.Context, in *GetFlexletRequest, opts ...grpc.CallOption) (*GetFlexletResponse, error)
+	DrainFlexlet(ctx context.Context, in *DrainFlexletRequest, opts ...grpc.CallOption) (*DrainFlexletResponse, error)
+	CordonFlexlet(ctx context.Context, in *CordonFlexletRequest, opts ...grpc.CallOption) (*CordonFlexletResponse, error)
+	UncordonFlexlet(ctx context.Context, in *UncordonFlexletRequest, opts ...grpc.CallOption) (*UncordonFlexletResponse, error)
+	DeleteFlexlet(ctx context.Context, in *DeleteFlexletRequest, opts ...grpc.CallOption) (*DeleteFlexletResponse, error)
 	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
 }
 
 type flexServiceClient struct {
@@ -63,6 +76,15 @@ func (c *flexServiceClient) CancelJob(ctx context.Context, in *CancelJobRequest,
 	return out, nil
 }
 
+func (c *flexServiceClient) CloneJob(ctx context.Context, in *CloneJobRequest, opts ...grpc.CallOption) (*CloneJobResponse, error) {
+	out := new(CloneJobResponse)
+	err := c.cc.Invoke(ctx, "/flex.FlexService/CloneJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *flexServiceClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error) {
 	out := new(GetJobResponse)
 	err := c.cc.Invoke(ctx, "/flex.FlexService/GetJob", in, out, opts...)
@@ -81,6 +103,38 @@ func (c *flexServiceClient) GetJobOutput(ctx context.Context, in *GetJobOutputRe
 	return out, nil
 }
 
+func (c *flexServiceClient) ReadJobOutput(ctx context.Context, in *ReadJobOutputRequest, opts ...grpc.CallOption) (FlexService_ReadJobOutputClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FlexService_ServiceDesc.Streams[0], "/flex.FlexService/ReadJobOutput", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &flexServiceReadJobOutputClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FlexService_ReadJobOutputClient interface {
+	Recv() (*ReadJobOutputResponse, error)
+	grpc.ClientStream
+}
+
+type flexServiceReadJobOutputClient struct {
+	grpc.ClientStream
+}
+
+func (x *flexServiceReadJobOutputClient) Recv() (*ReadJobOutputResponse, error) {
+	m := new(ReadJobOutputResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *flexServiceClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
 	out := new(ListJobsResponse)
 	err := c.cc.Invoke(ctx, "/flex.FlexService/ListJobs", in, out, opts...)
@@ -99,8 +153,76 @@ func (c *flexServiceClient) UpdateJobLabels(ctx context.Context, in *UpdateJobLa
 	return out, nil
 }
 
+func (c *flexServiceClient) GetJobArray(ctx context.Context, in *GetJobArrayRequest, opts ...grpc.CallOption) (*GetJobArrayResponse, error) {
+	out := new(GetJobArrayResponse)
+	err := c.cc.Invoke(ctx, "/flex.FlexService/GetJobArray", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flexServiceClient) WatchJobs(ctx context.Context, in *WatchJobsRequest, opts ...grpc.CallOption) (FlexService_WatchJobsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FlexService_ServiceDesc.Streams[1], "/flex.FlexService/WatchJobs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &flexServiceWatchJobsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FlexService_WatchJobsClient interface {
+	Recv() (*WatchJobsResponse, error)
+	grpc.ClientStream
+}
+
+type flexServiceWatchJobsClient struct {
+	grpc.ClientStream
+}
+
+func (x *flexServiceWatchJobsClient) Recv() (*WatchJobsResponse, error) {
+	m := new(WatchJobsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *flexServiceClient) BulkUpdateJobLabels(ctx context.Context, in *BulkUpdateJobLabelsRequest, opts ...grpc.CallOption) (*BulkUpdateJobLabelsResponse, error) {
+	out := new(BulkUpdateJobLabelsResponse)
+	err := c.cc.Invoke(ctx, "/flex.FlexService/BulkUpdateJobLabels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flexServiceClient) BulkCancelJobs(ctx context.Context, in *BulkCancelJobsRequest, opts ...grpc.CallOption) (*BulkCancelJobsResponse, error) {
+	out := new(BulkCancelJobsResponse)
+	err := c.cc.Invoke(ctx, "/flex.FlexService/BulkCancelJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flexServiceClient) BulkRetryJobs(ctx context.Context, in *BulkRetryJobsRequest, opts ...grpc.CallOption) (*BulkRetryJobsResponse, error) {
+	out := new(BulkRetryJobsResponse)
+	err := c.cc.Invoke(ctx, "/flex.FlexService/BulkRetryJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *flexServiceClient) InsertPackage(ctx context.Context, opts ...grpc.CallOption) (FlexService_InsertPackageClient, error) {
-	stream, err := c.cc.NewStream(ctx, &FlexService_ServiceDesc.Streams[0], "/flex.FlexService/InsertPackage", opts...)
+	stream, err := c.cc.NewStream(ctx, &FlexService_ServiceDesc.Streams[2], "/flex.FlexService/InsertPackage", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -178,6 +300,51 @@ func (c *flexServiceClient) ListFlexlets(ctx context.Context, in *ListFlexletsRe
 	return out, nil
 }
 
+func (c *flexServiceClient) GetFlexlet(ctx context.Context, in *GetFlexletRequest, opts ...grpc.CallOption) (*GetFlexletResponse, error) {
+	out := new(GetFlexletResponse)
+	err := c.cc.Invoke(ctx, "/flex.FlexService/GetFlexlet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flexServiceClient) DrainFlexlet(ctx context.Context, in *DrainFlexletRequest, opts ...grpc.CallOption) (*DrainFlexletResponse, error) {
+	out := new(DrainFlexletResponse)
+	err := c.cc.Invoke(ctx, "/flex.FlexService/DrainFlexlet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flexServiceClient) CordonFlexlet(ctx context.Context, in *CordonFlexletRequest, opts ...grpc.CallOption) (*CordonFlexletResponse, error) {
+	out := new(CordonFlexletResponse)
+	err := c.cc.Invoke(ctx, "/flex.FlexService/CordonFlexlet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flexServiceClient) UncordonFlexlet(ctx context.Context, in *UncordonFlexletRequest, opts ...grpc.CallOption) (*UncordonFlexletResponse, error) {
+	out := new(UncordonFlexletResponse)
+	err := c.cc.Invoke(ctx, "/flex.FlexService/UncordonFlexlet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flexServiceClient) DeleteFlexlet(ctx context.Context, in *DeleteFlexletRequest, opts ...grpc.CallOption) (*DeleteFlexletResponse, error) {
+	out := new(DeleteFlexletResponse)
+	err := c.cc.Invoke(ctx, "/flex.FlexService/DeleteFlexlet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *flexServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
 	out := new(GetStatsResponse)
 	err := c.cc.Invoke(ctx, "/flex.FlexService/GetStats", in, out, opts...)
@@ -187,23 +354,45 @@ func (c *flexServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, o
 	return out, nil
 }
 
+func (c *flexServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, "/flex.FlexService/ListWebhookDeliveries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FlexServiceServer is the server API for FlexService service.
 // All implementations must embed UnimplementedFlexServiceServer
 // for forward compatibility
 type FlexServiceServer interface {
 	SubmitJob(context.Context, *SubmitJobRequest) (*SubmitJobResponse, error)
 	CancelJob(context.Context, *CancelJobRequest) (*CancelJobResponse, error)
+	CloneJob(context.Context, *CloneJobRequest) (*CloneJobResponse, error)
 	GetJob(context.Context, *GetJobRequest) (*GetJobResponse, error)
 	GetJobOutput(context.Context, *GetJobOutputRequest) (*GetJobOutputResponse, error)
+	ReadJobOutput(*ReadJobOutputRequest, FlexService_ReadJobOutputServer) error
 	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
 	UpdateJobLabels(context.Context, *UpdateJobLabelsRequest) (*UpdateJobLabelsResponse, error)
+	GetJobArray(context.Context, *GetJobArrayRequest) (*GetJobArrayResponse, error)
+	WatchJobs(*WatchJobsRequest, FlexService_WatchJobsServer) error
+	BulkUpdateJobLabels(context.Context, *BulkUpdateJobLabelsRequest) (*BulkUpdateJobLabelsResponse, error)
+	BulkCancelJobs(context.Context, *BulkCancelJobsRequest) (*BulkCancelJobsResponse, error)
+	BulkRetryJobs(context.Context, *BulkRetryJobsRequest) (*BulkRetryJobsResponse, error)
 	InsertPackage(FlexService_InsertPackageServer) error
 	GetPackage(context.Context, *GetPackageRequest) (*GetPackageResponse, error)
 	FetchPackage(context.Context, *FetchPackageRequest) (*FetchPackageResponse, error)
 	UpdateTag(context.Context, *UpdateTagRequest) (*UpdateTagResponse, error)
 	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
 	ListFlexlets(context.Context, *ListFlexletsRequest) (*ListFlexletsResponse, error)
+	GetFlexlet(context.Context, *GetFlexletRequest) (*GetFlexletResponse, error)
+	DrainFlexlet(context.Context, *DrainFlexletRequest) (*DrainFlexletResponse, error)
+	CordonFlexlet(context.Context, *CordonFlexletRequest) (*CordonFlexletResponse, error)
+	UncordonFlexlet(context.Context, *UncordonFlexletRequest) (*UncordonFlexletResponse, error)
+	DeleteFlexlet(context.Context, *DeleteFlexletRequest) (*DeleteFlexletResponse, error)
 	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
 	mustEmbedUnimplementedFlexServiceServer()
 }
 
@@ -217,18 +406,39 @@ func (UnimplementedFlexServiceServer) SubmitJob(context.Context, *SubmitJobReque
 func (UnimplementedFlexServiceServer) CancelJob(context.Context, *CancelJobRequest) (*CancelJobResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelJob not implemented")
 }
+func (UnimplementedFlexServiceServer) CloneJob(context.Context, *CloneJobRequest) (*CloneJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloneJob not implemented")
+}
 func (UnimplementedFlexServiceServer) GetJob(context.Context, *GetJobRequest) (*GetJobResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
 }
 func (UnimplementedFlexServiceServer) GetJobOutput(context.Context, *GetJobOutputRequest) (*GetJobOutputResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetJobOutput not implemented")
 }
+func (UnimplementedFlexServiceServer) ReadJobOutput(*ReadJobOutputRequest, FlexService_ReadJobOutputServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadJobOutput not implemented")
+}
 func (UnimplementedFlexServiceServer) ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
 }
 func (UnimplementedFlexServiceServer) UpdateJobLabels(context.Context, *UpdateJobLabelsRequest) (*UpdateJobLabelsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateJobLabels not implemented")
 }
+func (UnimplementedFlexServiceServer) GetJobArray(context.Context, *GetJobArrayRequest) (*GetJobArrayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobArray not implemented")
+}
+func (UnimplementedFlexServiceServer) WatchJobs(*WatchJobsRequest, FlexService_WatchJobsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchJobs not implemented")
+}
+func (UnimplementedFlexServiceServer) BulkUpdateJobLabels(context.Context, *BulkUpdateJobLabelsRequest) (*BulkUpdateJobLabelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkUpdateJobLabels not implemented")
+}
+func (UnimplementedFlexServiceServer) BulkCancelJobs(context.Context, *BulkCancelJobsRequest) (*BulkCancelJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkCancelJobs not implemented")
+}
+func (UnimplementedFlexServiceServer) BulkRetryJobs(context.Context, *BulkRetryJobsRequest) (*BulkRetryJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkRetryJobs not implemented")
+}
 func (UnimplementedFlexServiceServer) InsertPackage(FlexService_InsertPackageServer) error {
 	return status.Errorf(codes.Unimplemented, "method InsertPackage not implemented")
 }
@@ -247,9 +457,27 @@ func (UnimplementedFlexServiceServer) ListTags(context.Context, *ListTagsRequest
 func (UnimplementedFlexServiceServer) ListFlexlets(context.Context, *ListFlexletsRequest) (*ListFlexletsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListFlexlets not implemented")
 }
+func (UnimplementedFlexServiceServer) GetFlexlet(context.Context, *GetFlexletRequest) (*GetFlexletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFlexlet not implemented")
+}
+func (UnimplementedFlexServiceServer) DrainFlexlet(context.Context, *DrainFlexletRequest) (*DrainFlexletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrainFlexlet not implemented")
+}
+func (UnimplementedFlexServiceServer) CordonFlexlet(context.Context, *CordonFlexletRequest) (*CordonFlexletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CordonFlexlet not implemented")
+}
+func (UnimplementedFlexServiceServer) UncordonFlexlet(context.Context, *UncordonFlexletRequest) (*UncordonFlexletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UncordonFlexlet not implemented")
+}
+func (UnimplementedFlexServiceServer) DeleteFlexlet(context.Context, *DeleteFlexletRequest) (*DeleteFlexletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFlexlet not implemented")
+}
 func (UnimplementedFlexServiceServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
 }
+func (UnimplementedFlexServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
 func (UnimplementedFlexServiceServer) mustEmbedUnimplementedFlexServiceServer() {}
 
 // UnsafeFlexServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -299,6 +527,24 @@ func _FlexService_CancelJob_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FlexService_CloneJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlexServiceServer).CloneJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flex.FlexService/CloneJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlexServiceServer).CloneJob(ctx, req.(*CloneJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FlexService_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetJobRequest)
 	if err := dec(in); err != nil {
@@ -335,6 +581,27 @@ func _FlexService_GetJobOutput_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FlexService_ReadJobOutput_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadJobOutputRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FlexServiceServer).ReadJobOutput(m, &flexServiceReadJobOutputServer{stream})
+}
+
+type FlexService_ReadJobOutputServer interface {
+	Send(*ReadJobOutputResponse) error
+	grpc.ServerStream
+}
+
+type flexServiceReadJobOutputServer struct {
+	grpc.ServerStream
+}
+
+func (x *flexServiceReadJobOutputServer) Send(m *ReadJobOutputResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _FlexService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListJobsRequest)
 	if err := dec(in); err != nil {
@@ -371,6 +638,99 @@ func _FlexService_UpdateJobLabels_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FlexService_GetJobArray_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobArrayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlexServiceServer).GetJobArray(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flex.FlexService/GetJobArray",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlexServiceServer).GetJobArray(ctx, req.(*GetJobArrayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlexService_WatchJobs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchJobsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FlexServiceServer).WatchJobs(m, &flexServiceWatchJobsServer{stream})
+}
+
+type FlexService_WatchJobsServer interface {
+	Send(*WatchJobsResponse) error
+	grpc.ServerStream
+}
+
+type flexServiceWatchJobsServer struct {
+	grpc.ServerStream
+}
+
+func (x *flexServiceWatchJobsServer) Send(m *WatchJobsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FlexService_BulkUpdateJobLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkUpdateJobLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlexServiceServer).BulkUpdateJobLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flex.FlexService/BulkUpdateJobLabels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlexServiceServer).BulkUpdateJobLabels(ctx, req.(*BulkUpdateJobLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlexService_BulkCancelJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkCancelJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlexServiceServer).BulkCancelJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flex.FlexService/BulkCancelJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlexServiceServer).BulkCancelJobs(ctx, req.(*BulkCancelJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlexService_BulkRetryJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkRetryJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlexServiceServer).BulkRetryJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flex.FlexService/BulkRetryJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlexServiceServer).BulkRetryJobs(ctx, req.(*BulkRetryJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FlexService_InsertPackage_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(FlexServiceServer).InsertPackage(&flexServiceInsertPackageServer{stream})
 }
@@ -487,6 +847,96 @@ func _FlexService_ListFlexlets_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FlexService_GetFlexlet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFlexletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlexServiceServer).GetFlexlet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flex.FlexService/GetFlexlet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlexServiceServer).GetFlexlet(ctx, req.(*GetFlexletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlexService_DrainFlexlet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainFlexletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlexServiceServer).DrainFlexlet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flex.FlexService/DrainFlexlet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlexServiceServer).DrainFlexlet(ctx, req.(*DrainFlexletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlexService_CordonFlexlet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CordonFlexletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlexServiceServer).CordonFlexlet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flex.FlexService/CordonFlexlet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlexServiceServer).CordonFlexlet(ctx, req.(*CordonFlexletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlexService_UncordonFlexlet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UncordonFlexletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlexServiceServer).UncordonFlexlet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flex.FlexService/UncordonFlexlet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlexServiceServer).UncordonFlexlet(ctx, req.(*UncordonFlexletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlexService_DeleteFlexlet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFlexletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlexServiceServer).DeleteFlexlet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flex.FlexService/DeleteFlexlet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlexServiceServer).DeleteFlexlet(ctx, req.(*DeleteFlexletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FlexService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetStatsRequest)
 	if err := dec(in); err != nil {
@@ -505,6 +955,24 @@ func _FlexService_GetStats_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FlexService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlexServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/flex.FlexService/ListWebhookDeliveries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlexServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FlexService_ServiceDesc is the grpc.ServiceDesc for FlexService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -520,6 +988,10 @@ var FlexService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelJob",
 			Handler:    _FlexService_CancelJob_Handler,
 		},
+		{
+			MethodName: "CloneJob",
+			Handler:    _FlexService_CloneJob_Handler,
+		},
 		{
 			MethodName: "GetJob",
 			Handler:    _FlexService_GetJob_Handler,
@@ -536,6 +1008,22 @@ var FlexService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateJobLabels",
 			Handler:    _FlexService_UpdateJobLabels_Handler,
 		},
+		{
+			MethodName: "GetJobArray",
+			Handler:    _FlexService_GetJobArray_Handler,
+		},
+		{
+			MethodName: "BulkUpdateJobLabels",
+			Handler:    _FlexService_BulkUpdateJobLabels_Handler,
+		},
+		{
+			MethodName: "BulkCancelJobs",
+			Handler:    _FlexService_BulkCancelJobs_Handler,
+		},
+		{
+			MethodName: "BulkRetryJobs",
+			Handler:    _FlexService_BulkRetryJobs_Handler,
+		},
 		{
 			MethodName: "GetPackage",
 			Handler:    _FlexService_GetPackage_Handler,
@@ -556,12 +1044,46 @@ var FlexService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListFlexlets",
 			Handler:    _FlexService_ListFlexlets_Handler,
 		},
+		{
+			MethodName: "GetFlexlet",
+			Handler:    _FlexService_GetFlexlet_Handler,
+		},
+		{
+			MethodName: "DrainFlexlet",
+			Handler:    _FlexService_DrainFlexlet_Handler,
+		},
+		{
+			MethodName: "CordonFlexlet",
+			Handler:    _FlexService_CordonFlexlet_Handler,
+		},
+		{
+			MethodName: "UncordonFlexlet",
+			Handler:    _FlexService_UncordonFlexlet_Handler,
+		},
+		{
+			MethodName: "DeleteFlexlet",
+			Handler:    _FlexService_DeleteFlexlet_Handler,
+		},
 		{
 			MethodName: "GetStats",
 			Handler:    _FlexService_GetStats_Handler,
 		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _FlexService_ListWebhookDeliveries_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReadJobOutput",
+			Handler:       _FlexService_ReadJobOutput_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchJobs",
+			Handler:       _FlexService_WatchJobs_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "InsertPackage",
 			Handler:       _FlexService_InsertPackage_Handler,
@@ -8,6 +8,7 @@ require (
 	cloud.google.com/go/storage v1.16.0
 	github.com/alessio/shellescape v1.4.1
 	github.com/aws/aws-sdk-go-v2/config v1.5.0
+	github.com/aws/aws-sdk-go-v2/credentials v1.3.1
 	github.com/aws/aws-sdk-go-v2/service/s3 v1.11.1
 	github.com/aws/smithy-go v1.6.0
 	github.com/cpuguy83/go-md2man/v2 v2.0.0 // indirect
@@ -21,15 +21,22 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
+
+	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/internal/grpcutil"
 )
 
 func startCommand(name string, args ...string) (*exec.Cmd, error) {
@@ -45,6 +52,60 @@ func runCommand(name string, args ...string) (string, error) {
 	return string(out), err
 }
 
+// runFlex runs the flex command and returns the integer it prints, such as a
+// job ID.
+func runFlex(t *testing.T, args ...string) int64 {
+	t.Helper()
+	out, err := runCommand("flex", args...)
+	if err != nil {
+		t.Fatalf("flex %s: %v", strings.Join(args, " "), err)
+	}
+	id, err := strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+	if err != nil {
+		t.Fatalf("flex %s: unexpected output: %q", strings.Join(args, " "), out)
+	}
+	return id
+}
+
+// waitJobs waits for jobs to finish.
+func waitJobs(t *testing.T, ids ...int64) {
+	t.Helper()
+	for _, id := range ids {
+		if _, err := runCommand("flex", "job", "wait", strconv.FormatInt(id, 10)); err != nil {
+			t.Fatalf("flex job wait %d: %v", id, err)
+		}
+	}
+}
+
+// checkBulkResults checks per-job results of a bulk operation. want maps job
+// IDs to a substring of their expected errors, or "" for success.
+func checkBulkResults(t *testing.T, op string, results []*flex.BulkJobResult, want map[int64]string) {
+	t.Helper()
+	if len(results) != len(want) {
+		t.Errorf("%s: got %d results, want %d", op, len(results), len(want))
+	}
+	for _, r := range results {
+		wantErr, ok := want[r.GetId()]
+		switch {
+		case !ok:
+			t.Errorf("%s: got an unexpected result for job %d", op, r.GetId())
+		case wantErr == "" && !r.GetOk():
+			t.Errorf("%s: job %d failed: %s", op, r.GetId(), r.GetError())
+		case wantErr != "" && (r.GetOk() || !strings.Contains(r.GetError(), wantErr)):
+			t.Errorf("%s: job %d: got ok=%v error %q, want error %q", op, r.GetId(), r.GetOk(), r.GetError(), wantErr)
+		}
+	}
+}
+
+func newClient(ctx context.Context, t *testing.T) flex.FlexServiceClient {
+	cc, err := grpcutil.DialContext(ctx, "http://localhost:57111/", "foobar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	return flex.NewFlexServiceClient(cc)
+}
+
 func waitHTTP(t *testing.T, port int) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
@@ -62,10 +123,14 @@ func waitHTTP(t *testing.T, port int) {
 			res.Body.Close()
 			return
 		}
+
+		// Do not retry in a tight loop, which may end up in connecting to
+		// itself since the port is in the ephemeral port range.
+		time.Sleep(100 * time.Millisecond)
 	}
 }
 
-func setUp(t *testing.T) {
+func setUp(t *testing.T) (dbURL string) {
 	homeDir := t.TempDir()
 	binDir := filepath.Join(homeDir, "bin")
 	if err := os.MkdirAll(binDir, 0777); err != nil {
@@ -101,7 +166,7 @@ func setUp(t *testing.T) {
 
 	// Set up MySQL test database.
 	t.Log("Setting up MySQL test database...")
-	dbURL := fmt.Sprintf(
+	dbURL = fmt.Sprintf(
 		"%s:%s@tcp(%s)/%s?parseTime=true",
 		os.Getenv("FLEX_TEST_DB_USER"),
 		os.Getenv("FLEX_TEST_DB_PASS"),
@@ -114,7 +179,7 @@ func setUp(t *testing.T) {
 	}
 	t.Cleanup(func() { db.Close() })
 
-	for _, table := range []string{"labels", "flexlets", "tags", "tasks", "jobs"} {
+	for _, table := range []string{"labels", "flexlets", "tags", "tasks", "jobs", "job_arrays", "webhook_deliveries", "job_events", "leases", "object_keys"} {
 		if _, err := db.Exec("DROP TABLE IF EXISTS " + table); err != nil {
 			t.Fatalf("Failed to drop table %s: %v", table, err)
 		}
@@ -145,8 +210,17 @@ password = foobar
 	waitHTTP(t, 57180)
 
 	// Start Flexhub.
+	startHub(t, 57111, dbURL)
+
+	t.Log("Finished setup")
+	return dbURL
+}
+
+func startHub(t *testing.T, port int, dbURL string) {
 	t.Log("Starting Flexhub...")
-	hubCmd, err := startCommand("flexhub", "--port=57111", "--db="+dbURL, "--fs=http://localhost:57180/", "--password=foobar")
+	// Flexlets report running tasks every 10 seconds. A short task timeout
+	// makes lost tasks detected soon.
+	hubCmd, err := startCommand("flexhub", fmt.Sprintf("--port=%d", port), "--db="+dbURL, "--fs=http://localhost:57180/", "--password=foobar", "--task-timeout=20s")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -154,9 +228,44 @@ password = foobar
 		hubCmd.Process.Kill()
 		hubCmd.Process.Wait()
 	})
-	waitHTTP(t, 57111)
+	waitHTTP(t, port)
+}
 
-	t.Log("Finished setup")
+// webhookRecorder is a webhook receiver that fails requests until a deadline,
+// and records deliveries requested again after succeeding.
+type webhookRecorder struct {
+	failUntil time.Time
+
+	mu        sync.Mutex
+	succeeded map[string]bool
+	dups      []string
+}
+
+func newWebhookRecorder(failUntil time.Time) *webhookRecorder {
+	return &webhookRecorder{failUntil: failUntil, succeeded: make(map[string]bool)}
+}
+
+func (r *webhookRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	id := req.Header.Get("X-Flex-Delivery")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.succeeded[id] {
+		r.dups = append(r.dups, id)
+	}
+	if time.Now().Before(r.failUntil) {
+		http.Error(w, "not ready", http.StatusServiceUnavailable)
+		return
+	}
+	r.succeeded[id] = true
+}
+
+// Result returns the number of succeeded deliveries, and IDs of deliveries
+// requested again after succeeding.
+func (r *webhookRecorder) Result() (succeeded int, dups []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.succeeded), append([]string(nil), r.dups...)
 }
 
 type flexlet struct {
@@ -170,9 +279,9 @@ func (f *flexlet) Stop() {
 	_, _ = f.cmd.Process.Wait()
 }
 
-func startFlexlet(t *testing.T) *flexlet {
+func startFlexlet(t *testing.T, args ...string) *flexlet {
 	t.Log("Starting Flexlet...")
-	cmd, err := startCommand("flexlet", "--hub=http://localhost:57111/", "--password=foobar")
+	cmd, err := startCommand("flexlet", append([]string{"--hub=http://localhost:57111/", "--password=foobar"}, args...)...)
 	if err != nil {
 		t.Fatalf("Failed to start flexlet: %v", err)
 	}
@@ -180,7 +289,7 @@ func startFlexlet(t *testing.T) *flexlet {
 }
 
 func TestIntegration(t *testing.T) {
-	setUp(t)
+	dbURL := setUp(t)
 
 	func() {
 		t.Log("******** Simple run test")
@@ -204,6 +313,490 @@ func TestIntegration(t *testing.T) {
 		}
 	}()
 
+	func() {
+		t.Log("******** Watch jobs test")
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		cl := newClient(ctx, t)
+
+		// No flexlet is running, so the job stays pending until one starts.
+		id := runFlex(t, "job", "create", "true")
+		stream, err := cl.WatchJobs(ctx, &flex.WatchJobsRequest{Ids: []int64{id}})
+		if err != nil {
+			t.Fatalf("WatchJobs: %v", err)
+		}
+		res, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("WatchJobs: %v", err)
+		}
+		states := []flex.JobState{res.GetJob().GetState()}
+
+		f := startFlexlet(t)
+		defer f.Stop()
+
+		for states[len(states)-1] != flex.JobState_FINISHED {
+			res, err := stream.Recv()
+			if err != nil {
+				t.Fatalf("WatchJobs: %v", err)
+			}
+			if state := res.GetJob().GetState(); state != states[len(states)-1] {
+				states = append(states, state)
+			}
+		}
+		if got, want := fmt.Sprint(states), fmt.Sprint([]flex.JobState{flex.JobState_PENDING, flex.JobState_RUNNING, flex.JobState_FINISHED}); got != want {
+			t.Errorf("WatchJobs: got states %s, want %s", got, want)
+		}
+
+		// Watching an array filter sends current statuses of its jobs first,
+		// which "flex job array wait" relies on.
+		arrayID := runFlex(t, "job", "create", "--array=1-3", "true")
+		if _, err := runCommand("flex", "job", "array", "wait", strconv.FormatInt(arrayID, 10)); err != nil {
+			t.Fatalf("flex job array wait: %v", err)
+		}
+		stream, err = cl.WatchJobs(ctx, &flex.WatchJobsRequest{Filter: &flex.JobFilter{ArrayId: arrayID}})
+		if err != nil {
+			t.Fatalf("WatchJobs: %v", err)
+		}
+		seen := make(map[int64]bool)
+		for len(seen) < 3 {
+			res, err := stream.Recv()
+			if err != nil {
+				t.Fatalf("WatchJobs: %v", err)
+			}
+			job := res.GetJob()
+			if job.GetArrayId() != arrayID || job.GetState() != flex.JobState_FINISHED {
+				t.Errorf("WatchJobs: got job %d of array %d in %v, want a finished job of array %d", job.GetJob().GetId(), job.GetArrayId(), job.GetState(), arrayID)
+			}
+			seen[job.GetJob().GetId()] = true
+		}
+	}()
+
+	func() {
+		t.Log("******** Bulk operations test")
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		cl := newClient(ctx, t)
+
+		// Finish one job, and leave another pending.
+		f := startFlexlet(t)
+		finished := runFlex(t, "job", "create", "--label=bulk", "true")
+		waitJobs(t, finished)
+		f.Stop()
+		pending := runFlex(t, "job", "create", "--label=bulk", "true")
+
+		labelRes, err := cl.BulkUpdateJobLabels(ctx, &flex.BulkUpdateJobLabelsRequest{
+			Filter: &flex.JobFilter{Label: "bulk"},
+			Adds:   []string{"relabeled"},
+			Dels:   []string{"bulk"},
+		})
+		if err != nil {
+			t.Fatalf("BulkUpdateJobLabels: %v", err)
+		}
+		checkBulkResults(t, "BulkUpdateJobLabels", labelRes.GetResults(), map[int64]string{finished: "", pending: ""})
+		for label, want := range map[string]int{"bulk": 0, "relabeled": 2} {
+			res, err := cl.ListJobs(ctx, &flex.ListJobsRequest{Label: label, Limit: 10, BeforeId: math.MaxInt64})
+			if err != nil {
+				t.Fatalf("ListJobs: %v", err)
+			}
+			if got := len(res.GetJobs()); got != want {
+				t.Errorf("ListJobs: got %d jobs labeled %q, want %d", got, label, want)
+			}
+		}
+
+		cancelRes, err := cl.BulkCancelJobs(ctx, &flex.BulkCancelJobsRequest{Filter: &flex.JobFilter{Label: "relabeled"}})
+		if err != nil {
+			t.Fatalf("BulkCancelJobs: %v", err)
+		}
+		checkBulkResults(t, "BulkCancelJobs", cancelRes.GetResults(), map[int64]string{finished: "already finished", pending: ""})
+
+		retryRes, err := cl.BulkRetryJobs(ctx, &flex.BulkRetryJobsRequest{Filter: &flex.JobFilter{Label: "relabeled", State: flex.JobState_FINISHED}})
+		if err != nil {
+			t.Fatalf("BulkRetryJobs: %v", err)
+		}
+		checkBulkResults(t, "BulkRetryJobs", retryRes.GetResults(), map[int64]string{finished: "", pending: ""})
+
+		retryRes, err = cl.BulkRetryJobs(ctx, &flex.BulkRetryJobsRequest{Filter: &flex.JobFilter{Label: "relabeled"}})
+		if err != nil {
+			t.Fatalf("BulkRetryJobs: %v", err)
+		}
+		checkBulkResults(t, "BulkRetryJobs", retryRes.GetResults(), map[int64]string{finished: "not finished", pending: "not finished"})
+
+		// Retried jobs run again.
+		f = startFlexlet(t)
+		defer f.Stop()
+		waitJobs(t, finished, pending)
+	}()
+
+	func() {
+		t.Log("******** Clone job test")
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		cl := newClient(ctx, t)
+
+		f := startFlexlet(t)
+		defer f.Stop()
+
+		createPackage := func(content string) string {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "README.txt")
+			if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			out, err := runCommand("flex", "package", "create", "--tag=clone", path)
+			if err != nil {
+				t.Fatalf("flex package create: %v", err)
+			}
+			return strings.TrimSpace(out)
+		}
+
+		oldHash := createPackage("old")
+		orig := runFlex(t, "job", "create", "--package=clone", "--label=clone", "--max-output-bytes=1048576", "--kill-on-output-overflow", "cat", "README.txt")
+		waitJobs(t, orig)
+
+		// Clones pin the package hashes of the original job unless tags are
+		// resolved again.
+		newHash := createPackage("new")
+		pinned := runFlex(t, "job", "rerun", "--priority=7", "--time-limit=30s", strconv.FormatInt(orig, 10))
+		waitJobs(t, pinned)
+		resolved := runFlex(t, "job", "rerun", "--resolve-tags", strconv.FormatInt(orig, 10))
+		waitJobs(t, resolved)
+
+		origRes, err := cl.GetJob(ctx, &flex.GetJobRequest{Id: orig})
+		if err != nil {
+			t.Fatalf("GetJob: %v", err)
+		}
+		origLimit := origRes.GetJob().GetJob().GetSpec().GetLimits().GetTime().AsDuration()
+
+		for _, tc := range []struct {
+			id       int64
+			hash     string
+			priority int32
+			limit    time.Duration
+		}{
+			{pinned, oldHash, 7, 30 * time.Second},
+			{resolved, newHash, 0, origLimit},
+		} {
+			res, err := cl.GetJob(ctx, &flex.GetJobRequest{Id: tc.id})
+			if err != nil {
+				t.Fatalf("GetJob: %v", err)
+			}
+			job := res.GetJob()
+			spec := job.GetJob().GetSpec()
+			if got := job.GetClonedFrom(); got != orig {
+				t.Errorf("Job %d: got cloned_from %d, want %d", tc.id, got, orig)
+			}
+			if got := strings.Join(spec.GetCommand().GetArgs(), " "); got != "cat README.txt" {
+				t.Errorf("Job %d: got command %q, want %q", tc.id, got, "cat README.txt")
+			}
+			if got := strings.Join(spec.GetAnnotations().GetLabels(), ","); got != "clone" {
+				t.Errorf("Job %d: got labels %q, want %q", tc.id, got, "clone")
+			}
+			if pkgs := spec.GetInputs().GetPackages(); len(pkgs) != 1 || pkgs[0].GetHash() != tc.hash {
+				t.Errorf("Job %d: got packages %v, want hash %s", tc.id, pkgs, tc.hash)
+			}
+			if got := spec.GetConstraints().GetPriority(); got != tc.priority {
+				t.Errorf("Job %d: got priority %d, want %d", tc.id, got, tc.priority)
+			}
+			if got := spec.GetLimits().GetTime().AsDuration(); got != tc.limit {
+				t.Errorf("Job %d: got time limit %v, want %v", tc.id, got, tc.limit)
+			}
+			// Overriding the time limit keeps output limits.
+			if got := spec.GetLimits().GetMaxOutputBytes(); got != 1048576 {
+				t.Errorf("Job %d: got max output bytes %d, want %d", tc.id, got, 1048576)
+			}
+			if !spec.GetLimits().GetKillOnOutputOverflow() {
+				t.Errorf("Job %d: got kill_on_output_overflow false, want true", tc.id)
+			}
+			if code := job.GetResult().GetExitCode(); job.GetState() != flex.JobState_FINISHED || code != 0 {
+				t.Errorf("Job %d: got %v with exit code %d, want FINISHED with 0", tc.id, job.GetState(), code)
+			}
+		}
+	}()
+
+	func() {
+		t.Log("******** Job array test")
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		cl := newClient(ctx, t)
+
+		getArray := func(id int64) *flex.JobArrayStatus {
+			res, err := cl.GetJobArray(ctx, &flex.GetJobArrayRequest{Id: id})
+			if err != nil {
+				t.Fatalf("GetJobArray: %v", err)
+			}
+			return res.GetArray()
+		}
+
+		// No flexlet is running, so jobs of the array are cancelled while
+		// pending.
+		cancelled := runFlex(t, "job", "create", "--array=1-2", "true")
+		if _, err := runCommand("flex", "job", "array", "cancel", strconv.FormatInt(cancelled, 10)); err != nil {
+			t.Fatalf("flex job array cancel: %v", err)
+		}
+		if stats := getArray(cancelled).GetStats(); stats.GetFinishedJobs() != 2 || stats.GetPendingJobs() != 0 {
+			t.Errorf("Job array %d: got stats %v, want 2 finished jobs", cancelled, stats)
+		}
+
+		f := startFlexlet(t)
+		defer f.Stop()
+
+		// The range ends at the largest index.
+		waitArray := func(id int64) {
+			if _, err := runCommand("flex", "job", "array", "wait", strconv.FormatInt(id, 10)); err != nil {
+				t.Fatalf("flex job array wait: %v", err)
+			}
+		}
+		ranged := runFlex(t, "job", "create", "--array=2147483646-2147483647", "--shell", "echo $FLEX_ARRAY_INDEX")
+		waitArray(ranged)
+		params := runFlex(t, "job", "create", "--array-param=foo", "--array-param=bar", "--shell", "echo $FLEX_ARRAY_INDEX=$FLEX_ARRAY_PARAM")
+		waitArray(params)
+
+		for _, tc := range []struct {
+			id   int64
+			want []string
+		}{
+			{cancelled, []string{"", ""}},
+			{ranged, []string{"2147483646", "2147483647"}},
+			{params, []string{"0=foo", "1=bar"}},
+		} {
+			if stats := getArray(tc.id).GetStats(); stats.GetFinishedJobs() != int32(len(tc.want)) {
+				t.Errorf("Job array %d: got stats %v, want %d finished jobs", tc.id, stats, len(tc.want))
+			}
+
+			res, err := cl.ListJobs(ctx, &flex.ListJobsRequest{ArrayId: tc.id, Limit: 10, BeforeId: math.MaxInt64})
+			if err != nil {
+				t.Fatalf("ListJobs: %v", err)
+			}
+			var outs []string
+			for _, job := range res.GetJobs() {
+				if got := job.GetArrayId(); got != tc.id {
+					t.Errorf("ListJobs: got job %d of array %d, want array %d", job.GetJob().GetId(), got, tc.id)
+				}
+				out := ""
+				if tc.id != cancelled {
+					out, err = runCommand("flex", "job", "outputs", strconv.FormatInt(job.GetJob().GetId(), 10))
+					if err != nil {
+						t.Fatalf("flex job outputs: %v", err)
+					}
+				}
+				outs = append([]string{strings.TrimSpace(out)}, outs...) // jobs are listed in descending order
+			}
+			if got, want := strings.Join(outs, ","), strings.Join(tc.want, ","); got != want {
+				t.Errorf("Job array %d: got outputs %q, want %q", tc.id, got, want)
+			}
+		}
+	}()
+
+	func() {
+		t.Log("******** Flexlet administration test")
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		cl := newClient(ctx, t)
+
+		const name = "admin"
+		getFlexlet := func() (*flex.GetFlexletResponse, error) {
+			return cl.GetFlexlet(ctx, &flex.GetFlexletRequest{Name: name})
+		}
+		getJobState := func(id int64) flex.JobState {
+			res, err := cl.GetJob(ctx, &flex.GetJobRequest{Id: id})
+			if err != nil {
+				t.Fatalf("GetJob: %v", err)
+			}
+			return res.GetJob().GetState()
+		}
+
+		f := startFlexlet(t, "--name="+name)
+		defer f.Stop()
+		for {
+			if res, err := getFlexlet(); err == nil && res.GetFlexlet().GetState() == flex.FlexletState_ONLINE {
+				break
+			}
+			if ctx.Err() != nil {
+				t.Fatal("Flexlet did not come online")
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+
+		// A cordoned flexlet does not take tasks.
+		if _, err := runCommand("flex", "flexlet", "cordon", name); err != nil {
+			t.Fatalf("flex flexlet cordon: %v", err)
+		}
+		id := runFlex(t, "job", "create", "true")
+		time.Sleep(3 * time.Second)
+		if state := getJobState(id); state != flex.JobState_PENDING {
+			t.Errorf("Job %d on a cordoned flexlet: got %v, want %v", id, state, flex.JobState_PENDING)
+		}
+		if _, err := runCommand("flex", "flexlet", "uncordon", name); err != nil {
+			t.Fatalf("flex flexlet uncordon: %v", err)
+		}
+		waitJobs(t, id)
+
+		res, err := getFlexlet()
+		if err != nil {
+			t.Fatalf("GetFlexlet: %v", err)
+		}
+		if res.GetFlexlet().GetCordoned() {
+			t.Error("GetFlexlet: flexlet is still cordoned")
+		}
+		if res.GetFlexlet().GetLastUpdate() == nil {
+			t.Error("GetFlexlet: last update is missing")
+		}
+		if tasks := res.GetRecentTasks(); len(tasks) != 1 || tasks[0].GetJobId() != id {
+			t.Errorf("GetFlexlet: got recent tasks %v, want one for job %d", tasks, id)
+		}
+		if stats := res.GetTaskStats(); stats.GetFinishedTasks() != 1 || stats.GetFailedTasks() != 0 {
+			t.Errorf("GetFlexlet: got task stats %v, want 1 finished task", stats)
+		}
+
+		// Only offline flexlets can be removed. A flexlet goes offline when
+		// it exits gracefully.
+		if _, err := runCommand("flex", "flexlet", "rm", name); err == nil {
+			t.Error("flex flexlet rm: unexpectedly succeeded for an online flexlet")
+		}
+		if err := f.cmd.Process.Signal(os.Interrupt); err != nil {
+			t.Fatal(err)
+		}
+		f.cmd.Wait()
+		if _, err := runCommand("flex", "flexlet", "rm", name); err != nil {
+			t.Fatalf("flex flexlet rm: %v", err)
+		}
+		if _, err := getFlexlet(); err == nil {
+			t.Error("GetFlexlet: unexpectedly succeeded for a removed flexlet")
+		}
+	}()
+
+	func() {
+		t.Log("******** Lost task test")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		cl := newClient(ctx, t)
+
+		// The job hangs on its first run only.
+		marker := filepath.Join(t.TempDir(), "marker")
+		id := runFlex(t, "job", "create", "--shell", fmt.Sprintf("test -e %s || { touch %s; sleep 120; }", marker, marker))
+
+		const name = "lossy"
+		f := startFlexlet(t, "--name="+name)
+		for {
+			res, err := cl.GetJob(ctx, &flex.GetJobRequest{Id: id})
+			if err != nil {
+				t.Fatalf("GetJob: %v", err)
+			}
+			if res.GetJob().GetState() == flex.JobState_RUNNING {
+				break
+			}
+			if ctx.Err() != nil {
+				t.Fatal("Job did not start")
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+
+		// Kill the flexlet without letting it finish the task. The task is
+		// considered lost after the task timeout, and the job is requeued
+		// to another flexlet.
+		f.Stop()
+		f = startFlexlet(t)
+		defer f.Stop()
+		waitJobs(t, id)
+
+		res, err := cl.GetJob(ctx, &flex.GetJobRequest{Id: id})
+		if err != nil {
+			t.Fatalf("GetJob: %v", err)
+		}
+		if job := res.GetJob(); job.GetFlexletName() == name || job.GetResult().GetExitCode() != 0 {
+			t.Errorf("Job %d: got exit code %d on flexlet %q, want 0 on another flexlet", id, job.GetResult().GetExitCode(), job.GetFlexletName())
+		}
+
+		flexletRes, err := cl.GetFlexlet(ctx, &flex.GetFlexletRequest{Name: name})
+		if err != nil {
+			t.Fatalf("GetFlexlet: %v", err)
+		}
+		if got := flexletRes.GetFlexlet().GetLostTasks(); got != 1 {
+			t.Errorf("GetFlexlet: got %d lost tasks, want 1", got)
+		}
+		if tasks := flexletRes.GetRecentTasks(); len(tasks) != 1 || tasks[0].GetJobId() != id || !tasks[0].GetLost() {
+			t.Errorf("GetFlexlet: got recent tasks %v, want a lost task for job %d", tasks, id)
+		}
+	}()
+
+	func() {
+		t.Log("******** Dispatch wakeup test")
+
+		f := startFlexlet(t)
+		defer f.Stop()
+
+		// Let the flexlet start waiting for tasks.
+		waitJobs(t, runFlex(t, "job", "create", "true"))
+		time.Sleep(time.Second)
+
+		// Waiting flexlets are woken up as soon as jobs are submitted, rather
+		// than when the hub polls the database every 5 seconds.
+		const maxLatency = time.Second
+		for i := 0; i < 5; i++ {
+			start := time.Now()
+			id := runFlex(t, "job", "create", "true")
+			waitJobs(t, id)
+			if elapsed := time.Since(start); elapsed > maxLatency {
+				t.Errorf("Job %d took %v to finish, want within %v", id, elapsed, maxLatency)
+			}
+		}
+	}()
+
+	func() {
+		t.Log("******** Multi-replica test")
+
+		f := startFlexlet(t)
+		defer f.Stop()
+
+		// Leave webhook deliveries retrying on the first flexhub. The second
+		// flexhub started below must not deliver them again.
+		hooks := newWebhookRecorder(time.Now().Add(6 * time.Second))
+		hookServer := httptest.NewServer(hooks)
+		defer hookServer.Close()
+
+		// The job takes a while so that its RUNNING event is not merged into
+		// its FINISHED event.
+		out, err := runCommand("flex", "job", "create", "--webhook="+hookServer.URL, "sleep", "1")
+		if err != nil {
+			t.Fatalf("flex job create: %v", err)
+		}
+		if _, err := runCommand("flex", "job", "wait", strings.TrimSpace(out)); err != nil {
+			t.Fatalf("flex job wait: %v", err)
+		}
+
+		// Start another flexhub sharing the database. A job submitted to it
+		// should run on a flexlet connected to the first one, and its result
+		// should be relayed back. Its port is out of the ephemeral port range
+		// since outgoing connections made so far might be using it.
+		startHub(t, 17112, dbURL)
+
+		const msg = "Hello, replica!"
+		out, err = runCommand("flex", "--hub=http://localhost:17112/", "run", "echo", msg)
+		if err != nil {
+			t.Fatalf("flex job run: %v", err)
+		}
+		if !strings.Contains(out, msg) {
+			t.Fatalf("flex job run: unexpected output: got %q, want %q as a substring", out, msg)
+		}
+
+		// Deliveries succeed after failUntil. Wait for further retries of
+		// duplicated deliveries, if any.
+		time.Sleep(time.Until(hooks.failUntil.Add(8 * time.Second)))
+		succeeded, dups := hooks.Result()
+		if succeeded != 2 {
+			t.Errorf("Webhook deliveries succeeded: got %d, want 2", succeeded)
+		}
+		if len(dups) > 0 {
+			t.Errorf("Webhook deliveries delivered more than once: %v", dups)
+		}
+	}()
+
 	func() {
 		t.Log("******** Stress run test")
 
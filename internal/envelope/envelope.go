@@ -0,0 +1,282 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package envelope implements envelope encryption of stored files. Each file
+// is encrypted with its own data key, which is in turn wrapped by a master key
+// held only by flexhub.
+//
+// Files are encrypted in chunks with AES-256-GCM so that they can be
+// encrypted and decrypted as streams. Each chunk is authenticated together
+// with its position and whether it is the last one, which detects reordered
+// or truncated files.
+package envelope
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// KeySize is the size of master keys and data keys in bytes.
+const KeySize = 32
+
+const (
+	magic      = "FLEXENC1"
+	prefixSize = 7
+	chunkSize  = 64 * 1024
+)
+
+// ErrInvalid is returned when decryption fails because the data is corrupted
+// or the key is wrong.
+var ErrInvalid = errors.New("envelope: invalid ciphertext or key")
+
+// NewKey returns a new random key.
+func NewKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// WrapKey encrypts a data key with a master key. The same aad must be passed
+// to UnwrapKey, which binds the wrapped key to e.g. the path of its file.
+func WrapKey(master, key, aad []byte) ([]byte, error) {
+	aead, err := newAEAD(master)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, key, aad), nil
+}
+
+// UnwrapKey decrypts a data key wrapped by WrapKey.
+func UnwrapKey(master, wrapped, aad []byte) ([]byte, error) {
+	aead, err := newAEAD(master)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, ErrInvalid
+	}
+	nonce, sealed := wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():]
+	key, err := aead.Open(nil, nonce, sealed, aad)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	return key, nil
+}
+
+type writer struct {
+	w      io.Writer
+	aead   cipher.AEAD
+	nonce  []byte
+	count  uint32
+	buf    []byte
+	out    []byte
+	err    error
+	closed bool
+}
+
+// NewWriter returns a writer that encrypts data written to it with key and
+// writes the ciphertext to w. Close must be called to write the last chunk;
+// it does not close w.
+func NewWriter(w io.Writer, key []byte) (io.WriteCloser, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce[:prefixSize]); err != nil {
+		return nil, err
+	}
+
+	header := append([]byte(magic), nonce[:prefixSize]...)
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	return &writer{
+		w:     w,
+		aead:  aead,
+		nonce: nonce,
+		buf:   make([]byte, 0, chunkSize),
+		out:   make([]byte, 0, chunkSize+aead.Overhead()),
+	}, nil
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errors.New("envelope: write after close")
+	}
+	written := 0
+	for len(p) > 0 {
+		if w.err != nil {
+			return written, w.err
+		}
+		// Seal a full chunk only when more data follows so that the last
+		// chunk is always sealed by Close.
+		if len(w.buf) == chunkSize {
+			w.err = w.flush(false)
+			continue
+		}
+		n := copy(w.buf[len(w.buf):chunkSize], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+func (w *writer) Close() error {
+	if w.closed {
+		return w.err
+	}
+	w.closed = true
+	if w.err != nil {
+		return w.err
+	}
+	w.err = w.flush(true)
+	return w.err
+}
+
+func (w *writer) flush(last bool) error {
+	if w.count == ^uint32(0) {
+		return errors.New("envelope: file too large")
+	}
+	setNonce(w.nonce, w.count, last)
+	w.out = w.aead.Seal(w.out[:0], w.nonce, w.buf, nil)
+	if _, err := w.w.Write(w.out); err != nil {
+		return err
+	}
+	w.buf = w.buf[:0]
+	w.count++
+	return nil
+}
+
+type reader struct {
+	r     *bufio.Reader
+	aead  cipher.AEAD
+	nonce []byte
+	count uint32
+	in    []byte
+	buf   []byte
+	done  bool
+	err   error
+}
+
+// NewReader returns a reader that decrypts data written by a writer returned
+// by NewWriter. Read returns ErrInvalid if the data is corrupted or truncated,
+// or if key is wrong.
+func NewReader(r io.Reader, key []byte) (io.Reader, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, len(magic)+prefixSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, ErrInvalid
+		}
+		return nil, err
+	}
+	if string(header[:len(magic)]) != magic {
+		return nil, ErrInvalid
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	copy(nonce, header[len(magic):])
+
+	return &reader{
+		r:     bufio.NewReader(r),
+		aead:  aead,
+		nonce: nonce,
+		in:    make([]byte, chunkSize+aead.Overhead()),
+	}, nil
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.done {
+			return 0, io.EOF
+		}
+		r.err = r.fill()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *reader) fill() error {
+	n, err := io.ReadFull(r.r, r.in)
+	last := false
+	switch err {
+	case nil:
+		if _, err := r.r.Peek(1); err == io.EOF {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	case io.ErrUnexpectedEOF:
+		last = true
+	case io.EOF:
+		// The last chunk is missing.
+		return ErrInvalid
+	default:
+		return err
+	}
+
+	setNonce(r.nonce, r.count, last)
+	buf, err := r.aead.Open(r.in[:0], r.nonce, r.in[:n], nil)
+	if err != nil {
+		return ErrInvalid
+	}
+	r.buf = buf
+	r.count++
+	r.done = last
+	return nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, errors.New("envelope: invalid key size")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// setNonce sets the chunk counter and the last chunk flag to the nonce of a
+// chunk, following its random prefix.
+func setNonce(nonce []byte, count uint32, last bool) {
+	binary.BigEndian.PutUint32(nonce[prefixSize:], count)
+	nonce[prefixSize+4] = 0
+	if last {
+		nonce[prefixSize+4] = 1
+	}
+}
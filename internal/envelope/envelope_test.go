@@ -0,0 +1,140 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envelope_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+
+	"github.com/nya3jp/flex/internal/envelope"
+)
+
+func encrypt(t *testing.T, data, key []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := envelope.NewWriter(&buf, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func decrypt(data, key []byte) ([]byte, error) {
+	r, err := envelope.NewReader(bytes.NewReader(data), key)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(r)
+}
+
+func TestRoundTrip(t *testing.T) {
+	key, err := envelope.NewKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, size := range []int{0, 1, 1000, 65535, 65536, 65537, 200000} {
+		data := make([]byte, size)
+		rand.Read(data)
+
+		enc := encrypt(t, data, key)
+		if size > 0 && bytes.Contains(enc, data) {
+			t.Errorf("size=%d: ciphertext contains plaintext", size)
+		}
+		got, err := decrypt(enc, key)
+		if err != nil {
+			t.Errorf("size=%d: decrypt: %v", size, err)
+			continue
+		}
+		if !bytes.Equal(got, data) {
+			t.Errorf("size=%d: decrypt returned different data", size)
+		}
+	}
+}
+
+func TestNewReader_Invalid(t *testing.T) {
+	key, err := envelope.NewKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := envelope.NewKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := make([]byte, 200000)
+	rand.Read(data)
+	enc := encrypt(t, data, key)
+
+	tampered := append([]byte(nil), enc...)
+	tampered[len(tampered)/2] ^= 1
+
+	for _, tc := range []struct {
+		name string
+		data []byte
+		key  []byte
+	}{
+		{name: "wrong key", data: enc, key: otherKey},
+		{name: "tampered", data: tampered, key: key},
+		{name: "truncated at chunk", data: enc[:15+2*(65536+16)], key: key},
+		{name: "truncated in chunk", data: enc[:len(enc)-1], key: key},
+		{name: "header only", data: enc[:15], key: key},
+		{name: "plaintext", data: data, key: key},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := decrypt(tc.data, tc.key); !errors.Is(err, envelope.ErrInvalid) {
+				t.Errorf("decrypt() = %v; want %v", err, envelope.ErrInvalid)
+			}
+		})
+	}
+}
+
+func TestWrapKey(t *testing.T) {
+	master, err := envelope.NewKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := envelope.NewKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wrapped, err := envelope.WrapKey(master, key, []byte("packages/abc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(wrapped, key) {
+		t.Error("wrapped key contains the data key")
+	}
+
+	got, err := envelope.UnwrapKey(master, wrapped, []byte("packages/abc"))
+	if err != nil {
+		t.Fatalf("UnwrapKey: %v", err)
+	}
+	if !bytes.Equal(got, key) {
+		t.Error("UnwrapKey returned a different key")
+	}
+
+	if _, err := envelope.UnwrapKey(master, wrapped, []byte("packages/def")); !errors.Is(err, envelope.ErrInvalid) {
+		t.Errorf("UnwrapKey with wrong aad = %v; want %v", err, envelope.ErrInvalid)
+	}
+}
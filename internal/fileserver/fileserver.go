@@ -0,0 +1,228 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fileserver serves files in a local directory over HTTP, with
+// optional signed URL authorization.
+package fileserver
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nya3jp/flex/internal/signedurl"
+)
+
+// tempInfix is in names of temporary files created by WriteFileAtomic.
+const tempInfix = ".tmp."
+
+// Server serves GET, HEAD, PUT and DELETE requests to files in a directory.
+// GET and HEAD support range and conditional requests. PUT and DELETE
+// support If-Match and If-None-Match conditions.
+type Server struct {
+	dir      string
+	basePath string
+	secret   []byte
+}
+
+// New returns a Server serving files in dir under the URL path basePath. If
+// secret is non-empty, requests must be authorized by URLs signed with it.
+func New(dir, basePath string, secret []byte) *Server {
+	return &Server{dir: dir, basePath: basePath, secret: secret}
+}
+
+// LocalPath returns the local file path of name. It rejects names escaping
+// the directory.
+func (s *Server) LocalPath(name string) (string, error) {
+	clean := path.Clean("/" + name)
+	if clean == "/" || clean != "/"+name {
+		return "", fmt.Errorf("invalid path: %s", name)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(clean)), nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if len(s.secret) > 0 {
+		if err := signedurl.Verify(s.secret, r, time.Now()); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+	if !strings.HasPrefix(r.URL.Path, s.basePath) {
+		http.Error(w, "File not found", http.StatusNotFound)
+		return
+	}
+	localPath, err := s.LocalPath(strings.TrimPrefix(r.URL.Path, s.basePath))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		serveFile(w, r, localPath)
+	case http.MethodPut:
+		if !checkPreconditions(w, r, localPath) {
+			return
+		}
+		if err := WriteFileAtomic(localPath, r.Body); err != nil {
+			log.Printf("WARNING: Failed to store %s: %v", localPath, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if fi, err := os.Stat(localPath); err == nil {
+			w.Header().Set("ETag", etag(fi))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		if !checkPreconditions(w, r, localPath) {
+			return
+		}
+		if err := os.Remove(localPath); os.IsNotExist(err) {
+			http.Error(w, "File not found", http.StatusNotFound)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT, DELETE")
+		http.Error(w, "method not supported", http.StatusMethodNotAllowed)
+	}
+}
+
+func serveFile(w http.ResponseWriter, r *http.Request, localPath string) {
+	f, err := os.Open(localPath)
+	if os.IsNotExist(err) {
+		http.Error(w, "File not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if fi.IsDir() {
+		http.Error(w, "File not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("ETag", etag(fi))
+	// ServeContent handles range and conditional requests.
+	http.ServeContent(w, r, "", fi.ModTime(), f)
+}
+
+// checkPreconditions evaluates If-Match and If-None-Match headers of a request
+// modifying a file. It responds with 412 Precondition Failed and returns false
+// if they do not hold.
+func checkPreconditions(w http.ResponseWriter, r *http.Request, localPath string) bool {
+	ifMatch := r.Header.Get("If-Match")
+	ifNoneMatch := r.Header.Get("If-None-Match")
+	if ifMatch == "" && ifNoneMatch == "" {
+		return true
+	}
+
+	current := ""
+	if fi, err := os.Stat(localPath); err == nil {
+		current = etag(fi)
+	}
+
+	ok := true
+	if ifMatch != "" && !matchETag(ifMatch, current) {
+		ok = false
+	}
+	if ifNoneMatch != "" && matchETag(ifNoneMatch, current) {
+		ok = false
+	}
+	if !ok {
+		http.Error(w, "Precondition failed", http.StatusPreconditionFailed)
+	}
+	return ok
+}
+
+// matchETag reports whether a list of entity tags in a conditional header
+// matches current, which is empty if the file does not exist.
+func matchETag(header, current string) bool {
+	if current == "" {
+		return false
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == current {
+			return true
+		}
+	}
+	return false
+}
+
+func etag(fi os.FileInfo) string {
+	return `"` + strconv.FormatInt(fi.Size(), 16) + "-" + strconv.FormatInt(fi.ModTime().UnixNano(), 16) + `"`
+}
+
+// RemoveTempFiles removes temporary files left in dir by WriteFileAtomic
+// calls interrupted by crashes.
+func RemoveTempFiles(dir string) error {
+	return filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.IsDir() && strings.HasPrefix(fi.Name(), ".") && strings.Contains(fi.Name(), tempInfix) {
+			return os.Remove(path)
+		}
+		return nil
+	})
+}
+
+// WriteFileAtomic writes r to path via a temporary file so that readers never
+// see a partially written file, even if the writer crashes.
+func WriteFileAtomic(path string, r io.Reader) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(dir, "."+filepath.Base(path)+tempInfix)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, firstErr := io.Copy(f, r)
+	if err := f.Sync(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	if err := f.Close(); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,111 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileserver_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nya3jp/flex/internal/fileserver"
+	"github.com/nya3jp/flex/internal/signedurl"
+)
+
+func TestServer(t *testing.T) {
+	secret := []byte("secret")
+	server := httptest.NewServer(fileserver.New(t.TempDir(), "/", secret))
+	defer server.Close()
+
+	do := func(method, path string, body string, header http.Header, signed bool) (int, string, http.Header) {
+		t.Helper()
+		u, err := url.Parse(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if signed {
+			signMethod := method
+			if method == http.MethodHead {
+				signMethod = http.MethodGet
+			}
+			u = signedurl.Sign(secret, signMethod, u, time.Now().Add(time.Minute))
+		}
+		req, err := http.NewRequest(method, u.String(), strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		b, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.StatusCode, string(b), res.Header
+	}
+
+	if code, _, _ := do(http.MethodPut, "/a/b.txt", "hello", nil, false); code != http.StatusForbidden {
+		t.Errorf("Unsigned PUT: got %d; want %d", code, http.StatusForbidden)
+	}
+	if code, _, _ := do(http.MethodPut, "/a/b.txt", "hello", nil, true); code != http.StatusNoContent {
+		t.Fatalf("PUT: got %d; want %d", code, http.StatusNoContent)
+	}
+	if code, _, _ := do(http.MethodGet, "/a/b.txt", "", nil, false); code != http.StatusForbidden {
+		t.Errorf("Unsigned GET: got %d; want %d", code, http.StatusForbidden)
+	}
+
+	code, body, header := do(http.MethodGet, "/a/b.txt", "", nil, true)
+	if code != http.StatusOK || body != "hello" {
+		t.Fatalf("GET: got %d %q; want %d %q", code, body, http.StatusOK, "hello")
+	}
+	etag := header.Get("ETag")
+
+	if code, body, _ := do(http.MethodGet, "/a/b.txt", "", http.Header{"Range": {"bytes=1-3"}}, true); code != http.StatusPartialContent || body != "ell" {
+		t.Errorf("Range GET: got %d %q; want %d %q", code, body, http.StatusPartialContent, "ell")
+	}
+	if code, _, _ := do(http.MethodGet, "/a/b.txt", "", http.Header{"If-None-Match": {etag}}, true); code != http.StatusNotModified {
+		t.Errorf("Conditional GET: got %d; want %d", code, http.StatusNotModified)
+	}
+	if code, _, _ := do(http.MethodPut, "/a/b.txt", "world", http.Header{"If-None-Match": {"*"}}, true); code != http.StatusPreconditionFailed {
+		t.Errorf("PUT with If-None-Match: *: got %d; want %d", code, http.StatusPreconditionFailed)
+	}
+	if code, _, _ := do(http.MethodPut, "/a/b.txt", "world", http.Header{"If-Match": {`"stale"`}}, true); code != http.StatusPreconditionFailed {
+		t.Errorf("PUT with stale If-Match: got %d; want %d", code, http.StatusPreconditionFailed)
+	}
+	if code, _, _ := do(http.MethodPut, "/a/b.txt", "world", http.Header{"If-Match": {etag}}, true); code != http.StatusNoContent {
+		t.Errorf("PUT with If-Match: got %d; want %d", code, http.StatusNoContent)
+	}
+	if code, body, _ := do(http.MethodGet, "/a/b.txt", "", nil, true); code != http.StatusOK || body != "world" {
+		t.Errorf("GET after overwrite: got %d %q; want %d %q", code, body, http.StatusOK, "world")
+	}
+
+	if code, _, _ := do(http.MethodDelete, "/a/b.txt", "", nil, true); code != http.StatusNoContent {
+		t.Errorf("DELETE: got %d; want %d", code, http.StatusNoContent)
+	}
+	if code, _, _ := do(http.MethodHead, "/a/b.txt", "", nil, true); code != http.StatusNotFound {
+		t.Errorf("HEAD after DELETE: got %d; want %d", code, http.StatusNotFound)
+	}
+	if code, _, _ := do(http.MethodPut, "/a/../../escape", "x", nil, true); code != http.StatusBadRequest {
+		t.Errorf("PUT escaping the directory: got %d; want %d", code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package joblog implements combined logs of jobs, which record lines written
+// to stdout and stderr in the order they were written.
+//
+// A log consists of records, one per line, each of which has a timestamp, a
+// stream name and a line written to the stream:
+//
+//	2022-01-02T03:04:05.123456789Z stdout Hello, world!
+package joblog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Stream names.
+const (
+	Stdout = "stdout"
+	Stderr = "stderr"
+)
+
+// maxLineSize is the maximum size of a line in a record. Longer lines are
+// split into multiple records.
+const maxLineSize = 64 * 1024
+
+// Record is a line written to a stream.
+type Record struct {
+	Time   time.Time
+	Stream string
+	Line   string
+}
+
+// Parse parses a line of a log into a record.
+func Parse(s string) (*Record, error) {
+	fields := strings.SplitN(strings.TrimSuffix(s, "\n"), " ", 3)
+	if len(fields) < 2 {
+		return nil, errors.New("malformed log record")
+	}
+	t, err := time.Parse(time.RFC3339Nano, fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed log record: %w", err)
+	}
+	rec := &Record{Time: t, Stream: fields[1]}
+	if len(fields) == 3 {
+		rec.Line = fields[2]
+	}
+	return rec, nil
+}
+
+// Logger writes records of multiple streams to a log.
+type Logger struct {
+	now func() time.Time
+
+	mu  sync.Mutex
+	w   io.Writer
+	err error
+}
+
+// NewLogger returns a logger writing a log to w.
+func NewLogger(w io.Writer) *Logger {
+	return &Logger{now: time.Now, w: w}
+}
+
+// Stream returns a writer that records lines written to it as the named
+// stream. Close must be called to record the last line if it does not end
+// with a newline.
+func (l *Logger) Stream(name string) io.WriteCloser {
+	return &streamWriter{l: l, name: name}
+}
+
+func (l *Logger) write(stream string, line []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return l.err
+	}
+	ts := l.now().UTC().Format(time.RFC3339Nano)
+	_, l.err = fmt.Fprintf(l.w, "%s %s %s\n", ts, stream, line)
+	return l.err
+}
+
+type streamWriter struct {
+	l    *Logger
+	name string
+	buf  []byte
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			w.buf = append(w.buf, p...)
+			break
+		}
+		w.buf = append(w.buf, p[:i]...)
+		p = p[i+1:]
+		if err := w.flush(); err != nil {
+			return 0, err
+		}
+	}
+	for len(w.buf) >= maxLineSize {
+		if err := w.l.write(w.name, w.buf[:maxLineSize]); err != nil {
+			return 0, err
+		}
+		w.buf = append(w.buf[:0], w.buf[maxLineSize:]...)
+	}
+	return n, nil
+}
+
+func (w *streamWriter) Close() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	return w.flush()
+}
+
+func (w *streamWriter) flush() error {
+	for {
+		line := w.buf
+		if len(line) > maxLineSize {
+			line = line[:maxLineSize]
+		}
+		if err := w.l.write(w.name, line); err != nil {
+			return err
+		}
+		w.buf = w.buf[len(line):]
+		if len(w.buf) == 0 {
+			w.buf = nil
+			return nil
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package joblog_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/nya3jp/flex/internal/joblog"
+)
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := joblog.NewLogger(&buf)
+	stdout := logger.Stream(joblog.Stdout)
+	stderr := logger.Stream(joblog.Stderr)
+
+	long := strings.Repeat("x", 100*1024)
+
+	start := time.Now()
+	for _, step := range []struct {
+		s    string
+		text string
+	}{
+		{joblog.Stdout, "foo\nba"},
+		{joblog.Stderr, "hoge\n\n"},
+		{joblog.Stdout, "r\nbaz"},
+		{joblog.Stderr, long + "\n"},
+		{joblog.Stderr, "fuga"},
+	} {
+		w := stdout
+		if step.s == joblog.Stderr {
+			w = stderr
+		}
+		if _, err := w.Write([]byte(step.text)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	for _, w := range []interface{ Close() error }{stdout, stderr} {
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+	}
+	end := time.Now()
+
+	var got []joblog.Record
+	for _, line := range strings.SplitAfter(buf.String(), "\n") {
+		if line == "" {
+			continue
+		}
+		rec, err := joblog.Parse(line)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", line, err)
+		}
+		if rec.Time.Before(start) || rec.Time.After(end) {
+			t.Errorf("Record has unexpected time %v", rec.Time)
+		}
+		got = append(got, joblog.Record{Stream: rec.Stream, Line: rec.Line})
+	}
+
+	want := []joblog.Record{
+		{Stream: joblog.Stdout, Line: "foo"},
+		{Stream: joblog.Stderr, Line: "hoge"},
+		{Stream: joblog.Stderr, Line: ""},
+		{Stream: joblog.Stdout, Line: "bar"},
+		{Stream: joblog.Stderr, Line: long[:64*1024]},
+		{Stream: joblog.Stderr, Line: long[64*1024:]},
+		{Stream: joblog.Stdout, Line: "baz"},
+		{Stream: joblog.Stderr, Line: "fuga"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Records mismatch (-got +want):\n%s", diff)
+	}
+}
+
+func TestParse(t *testing.T) {
+	rec, err := joblog.Parse("2022-01-02T03:04:05.123456789Z stdout foo bar  \n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &joblog.Record{
+		Time:   time.Date(2022, 1, 2, 3, 4, 5, 123456789, time.UTC),
+		Stream: joblog.Stdout,
+		Line:   "foo bar  ",
+	}
+	if diff := cmp.Diff(rec, want); diff != "" {
+		t.Errorf("Parse mismatch (-got +want):\n%s", diff)
+	}
+
+	for _, s := range []string{
+		"",
+		"stdout foo",
+		"\n[flex: output truncated; 42 bytes omitted]\n",
+	} {
+		if _, err := joblog.Parse(s); err == nil {
+			t.Errorf("Parse(%q) succeeded unexpectedly", s)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/nya3jp/flex"
+)
+
+// Channel is an in-process Publisher and Subscriber backed by a Go channel.
+// It is mainly for tests.
+type Channel struct {
+	ch        chan *flex.JobEvent
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+var (
+	_ Publisher  = &Channel{}
+	_ Subscriber = &Channel{}
+)
+
+// NewChannel creates a Channel that buffers up to size events.
+func NewChannel(size int) *Channel {
+	return &Channel{
+		ch:   make(chan *flex.JobEvent, size),
+		done: make(chan struct{}),
+	}
+}
+
+func (c *Channel) Close() error {
+	c.closeOnce.Do(func() { close(c.done) })
+	return nil
+}
+
+func (c *Channel) Publish(ctx context.Context, event *flex.JobEvent) error {
+	// Check closure first since select picks a ready case at random.
+	select {
+	case <-c.done:
+		return errors.New("channel closed")
+	default:
+	}
+	select {
+	case c.ch <- event:
+		return nil
+	case <-c.done:
+		return errors.New("channel closed")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Receive calls f sequentially for each event until ctx is canceled or c is
+// closed.
+func (c *Channel) Receive(ctx context.Context, f func(ctx context.Context, event *flex.JobEvent)) error {
+	for {
+		select {
+		case event := <-c.ch:
+			f(ctx, event)
+		case <-c.done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package pubsub
+
+import (
+	"context"
+	"log"
+
+	"cloud.google.com/go/compute/metadata"
+	"cloud.google.com/go/pubsub"
+
+	"github.com/nya3jp/flex"
+)
+
+func newGCPClient(ctx context.Context, projectID string) (*pubsub.Client, error) {
+	if projectID == "" {
+		var err error
+		projectID, err = metadata.ProjectID()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pubsub.NewClient(ctx, projectID)
+}
+
+type gcpPublisher struct {
+	client *pubsub.Client
+	topic  *pubsub.Topic
+}
+
+func newGCPPublisher(ctx context.Context, projectID, topicID string) (*gcpPublisher, error) {
+	client, err := newGCPClient(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	topic := client.Topic(topicID)
+
+	return &gcpPublisher{
+		client: client,
+		topic:  topic,
+	}, nil
+}
+
+func (p *gcpPublisher) Close() error {
+	p.topic.Stop()
+	return p.client.Close()
+}
+
+func (p *gcpPublisher) Publish(ctx context.Context, event *flex.JobEvent) error {
+	data, err := MarshalEvent(event)
+	if err != nil {
+		return err
+	}
+
+	result := p.topic.Publish(ctx, &pubsub.Message{
+		Data: data,
+		// Allow subscriptions to filter events by state.
+		Attributes: map[string]string{"state": event.GetState().String()},
+	})
+	_, err = result.Get(ctx)
+	return err
+}
+
+type gcpSubscriber struct {
+	client       *pubsub.Client
+	subscription *pubsub.Subscription
+}
+
+func newGCPSubscriber(ctx context.Context, projectID, subscriptionID string, maxOutstanding int) (*gcpSubscriber, error) {
+	client, err := newGCPClient(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := client.Subscription(subscriptionID)
+	subscription.ReceiveSettings.MaxOutstandingMessages = maxOutstanding
+	subscription.ReceiveSettings.Synchronous = true
+
+	return &gcpSubscriber{
+		client:       client,
+		subscription: subscription,
+	}, nil
+}
+
+func (s *gcpSubscriber) Close() error {
+	return s.client.Close()
+}
+
+func (s *gcpSubscriber) Receive(ctx context.Context, f func(ctx context.Context, event *flex.JobEvent)) error {
+	return s.subscription.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
+		// Ack immediately to allow f to run beyond ack deadline.
+		msg.Ack()
+
+		event, err := UnmarshalEvent(msg.Data)
+		if err != nil {
+			log.Printf("WARNING: Dropped a malformed event: %v", err)
+			return
+		}
+		f(ctx, event)
+	})
+}
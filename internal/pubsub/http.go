@@ -0,0 +1,45 @@
+package pubsub
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/nya3jp/flex"
+)
+
+type httpPublisher struct {
+	url string
+}
+
+func newHTTPPublisher(url string) *httpPublisher {
+	return &httpPublisher{url: url}
+}
+
+func (p *httpPublisher) Close() error {
+	return nil
+}
+
+func (p *httpPublisher) Publish(ctx context.Context, event *flex.JobEvent) error {
+	data, err := MarshalEvent(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		return errors.New(res.Status)
+	}
+	return nil
+}
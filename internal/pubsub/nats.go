@@ -0,0 +1,288 @@
+package pubsub
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/internal/concurrent"
+)
+
+const defaultNATSPort = "4222"
+
+// natsConn is a minimal client of the NATS text protocol.
+// https://docs.nats.io/reference/reference-protocols/nats-protocol
+type natsConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func dialNATS(ctx context.Context, u *url.URL) (*natsConn, error) {
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), defaultNATSPort)
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bound the handshake by ctx as well as dialing.
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	c := &natsConn{conn: conn, r: bufio.NewReader(conn)}
+	if err := c.handshake(u.User); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("nats: %w", err)
+	}
+	conn.SetDeadline(time.Time{})
+	return c, nil
+}
+
+func (c *natsConn) handshake(user *url.Userinfo) error {
+	line, err := c.readLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return fmt.Errorf("unexpected greeting: %s", line)
+	}
+
+	opts := map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"lang":     "go",
+		"version":  "flex",
+	}
+	if user != nil {
+		if pass, ok := user.Password(); ok {
+			opts["user"] = user.Username()
+			opts["pass"] = pass
+		} else {
+			opts["auth_token"] = user.Username()
+		}
+	}
+	b, err := json.Marshal(opts)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(c.conn, "CONNECT %s\r\n", b); err != nil {
+		return err
+	}
+	return c.flush()
+}
+
+// flush sends PING and waits for PONG, which ensures that all preceding
+// commands have been processed by the server.
+func (c *natsConn) flush() error {
+	if _, err := io.WriteString(c.conn, "PING\r\n"); err != nil {
+		return err
+	}
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := io.WriteString(c.conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New(line)
+		}
+	}
+}
+
+func (c *natsConn) readLine() (string, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (c *natsConn) Close() error {
+	return c.conn.Close()
+}
+
+type natsPublisher struct {
+	url     *url.URL
+	subject string
+
+	mu   sync.Mutex
+	conn *natsConn // lazily (re)connected
+}
+
+func newNATSPublisher(u *url.URL) (*natsPublisher, error) {
+	subject := strings.TrimPrefix(u.Path, "/")
+	if subject == "" {
+		return nil, errors.New("nats: subject missing in URL")
+	}
+	return &natsPublisher{url: u, subject: subject}, nil
+}
+
+func (p *natsPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn = nil
+	return err
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, event *flex.JobEvent) error {
+	data, err := MarshalEvent(event)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		conn, err := dialNATS(ctx, p.url)
+		if err != nil {
+			return err
+		}
+		p.conn = conn
+	}
+
+	if err := func() error {
+		if deadline, ok := ctx.Deadline(); ok {
+			p.conn.conn.SetDeadline(deadline)
+			defer p.conn.conn.SetDeadline(time.Time{})
+		}
+		if _, err := fmt.Fprintf(p.conn.conn, "PUB %s %d\r\n%s\r\n", p.subject, len(data), data); err != nil {
+			return err
+		}
+		return p.conn.flush()
+	}(); err != nil {
+		p.conn.Close()
+		p.conn = nil
+		return fmt.Errorf("nats: %w", err)
+	}
+	return nil
+}
+
+type natsSubscriber struct {
+	url            *url.URL
+	subject        string
+	queue          string
+	maxOutstanding int
+}
+
+func newNATSSubscriber(u *url.URL, maxOutstanding int) (*natsSubscriber, error) {
+	subject := strings.TrimPrefix(u.Path, "/")
+	if subject == "" {
+		return nil, errors.New("nats: subject missing in URL")
+	}
+	return &natsSubscriber{
+		url:            u,
+		subject:        subject,
+		queue:          u.Query().Get("queue"),
+		maxOutstanding: maxOutstanding,
+	}, nil
+}
+
+func (s *natsSubscriber) Close() error {
+	return nil
+}
+
+func (s *natsSubscriber) Receive(ctx context.Context, f func(ctx context.Context, event *flex.JobEvent)) error {
+	d := newDispatcher(s.maxOutstanding)
+	defer d.Wait()
+
+	retry := concurrent.NewRetry(time.Second, time.Minute)
+	for {
+		err := s.receiveOnce(ctx, d, f, retry)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		log.Printf("WARNING: NATS subscription failed; reconnecting: %v", err)
+		if err := retry.Wait(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *natsSubscriber) receiveOnce(ctx context.Context, d *dispatcher, f func(ctx context.Context, event *flex.JobEvent), retry *concurrent.Retry) error {
+	conn, err := dialNATS(ctx, s.url)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Unblock reads on cancellation.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	sub := fmt.Sprintf("SUB %s 1\r\n", s.subject)
+	if s.queue != "" {
+		sub = fmt.Sprintf("SUB %s %s 1\r\n", s.subject, s.queue)
+	}
+	if _, err := io.WriteString(conn.conn, sub); err != nil {
+		return err
+	}
+	retry.Clear()
+
+	for {
+		line, err := conn.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PING":
+			if _, err := io.WriteString(conn.conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New(line)
+		case strings.HasPrefix(line, "MSG "):
+			// MSG <subject> <sid> [reply-to] <#bytes>
+			fields := strings.Fields(line)
+			size, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil {
+				return fmt.Errorf("malformed message: %s", line)
+			}
+			data := make([]byte, size+2)
+			if _, err := io.ReadFull(conn.r, data); err != nil {
+				return err
+			}
+
+			event, err := UnmarshalEvent(data[:size])
+			if err != nil {
+				log.Printf("WARNING: Dropped a malformed event: %v", err)
+				continue
+			}
+			if err := d.Dispatch(ctx, f, event); err != nil {
+				return err
+			}
+		}
+	}
+}
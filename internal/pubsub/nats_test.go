@@ -0,0 +1,186 @@
+package pubsub_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/internal/pubsub"
+)
+
+// expectNATS reads a line from conn and checks that it starts with prefix.
+func expectNATS(t *testing.T, conn *scriptedConn, prefix string) string {
+	t.Helper()
+	line, err := conn.r.ReadString('\n')
+	if err != nil {
+		t.Errorf("Reading %q: %v", prefix, err)
+		return ""
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if !strings.HasPrefix(line, prefix) {
+		t.Errorf("Got %q; want %q", line, prefix)
+	}
+	return line
+}
+
+// handshakeNATS plays the server side of a NATS handshake. It also pings the
+// client while the client waits for PONG.
+func handshakeNATS(t *testing.T, conn *scriptedConn) {
+	t.Helper()
+	io.WriteString(conn, "INFO {\"server_id\":\"test\"}\r\n")
+	expectNATS(t, conn, "CONNECT {")
+	expectNATS(t, conn, "PING")
+	io.WriteString(conn, "PING\r\n")
+	expectNATS(t, conn, "PONG")
+	io.WriteString(conn, "PONG\r\n")
+}
+
+// readNATSPub reads a PUB command and returns the job ID of its event.
+func readNATSPub(t *testing.T, conn *scriptedConn, subject string) int64 {
+	t.Helper()
+	fields := strings.Fields(expectNATS(t, conn, "PUB "+subject+" "))
+	if len(fields) != 3 {
+		t.Errorf("Malformed PUB: %v", fields)
+		return 0
+	}
+	size, err := strconv.Atoi(fields[2])
+	if err != nil {
+		t.Errorf("Malformed PUB: %v", fields)
+		return 0
+	}
+	data := make([]byte, size+2)
+	if _, err := io.ReadFull(conn.r, data); err != nil {
+		t.Errorf("Reading PUB payload: %v", err)
+		return 0
+	}
+	event, err := pubsub.UnmarshalEvent(data[:size])
+	if err != nil {
+		t.Errorf("UnmarshalEvent: %v", err)
+		return 0
+	}
+	return event.GetJobId()
+}
+
+func natsMsg(t *testing.T, subject, replyTo string, id int64) string {
+	t.Helper()
+	data, err := pubsub.MarshalEvent(&flex.JobEvent{JobId: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replyTo != "" {
+		return fmt.Sprintf("MSG %s 1 %s %d\r\n%s\r\n", subject, replyTo, len(data), data)
+	}
+	return fmt.Sprintf("MSG %s 1 %d\r\n%s\r\n", subject, len(data), data)
+}
+
+func TestNATSPublisher(t *testing.T) {
+	ctx := context.Background()
+
+	addr := scriptedServer(t,
+		func(conn *scriptedConn) {
+			handshakeNATS(t, conn)
+			if id := readNATSPub(t, conn, "events"); id != 1 {
+				t.Errorf("Published job %d; want 1", id)
+			}
+			expectNATS(t, conn, "PING")
+			io.WriteString(conn, "PONG\r\n")
+
+			readNATSPub(t, conn, "events")
+			expectNATS(t, conn, "PING")
+			io.WriteString(conn, "-ERR 'Permissions Violation'\r\n")
+		},
+		func(conn *scriptedConn) {
+			handshakeNATS(t, conn)
+			if id := readNATSPub(t, conn, "events"); id != 3 {
+				t.Errorf("Published job %d; want 3", id)
+			}
+			expectNATS(t, conn, "PING")
+			io.WriteString(conn, "PONG\r\n")
+		},
+	)
+
+	pub, err := pubsub.NewPublisher(ctx, "nats://"+addr+"/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pub.Close()
+
+	if err := pub.Publish(ctx, &flex.JobEvent{JobId: 1}); err != nil {
+		t.Errorf("Publish(1): %v", err)
+	}
+	if err := pub.Publish(ctx, &flex.JobEvent{JobId: 2}); err == nil || !strings.Contains(err.Error(), "Permissions Violation") {
+		t.Errorf("Publish(2): %v; want Permissions Violation", err)
+	}
+	// The publisher reconnects after an error.
+	if err := pub.Publish(ctx, &flex.JobEvent{JobId: 3}); err != nil {
+		t.Errorf("Publish(3): %v", err)
+	}
+}
+
+func TestNATSPublisher_Hung(t *testing.T) {
+	// The server accepts a connection but never greets.
+	addr := scriptedServer(t, func(conn *scriptedConn) {
+		io.Copy(io.Discard, conn)
+	})
+
+	pub, err := pubsub.NewPublisher(context.Background(), "nats://"+addr+"/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pub.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := pub.Publish(ctx, &flex.JobEvent{JobId: 1}); err == nil {
+		t.Error("Publish succeeded unexpectedly")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Publish took %v; want it to time out with ctx", elapsed)
+	}
+}
+
+func TestNATSSubscriber(t *testing.T) {
+	ctx := context.Background()
+
+	addr := scriptedServer(t,
+		func(conn *scriptedConn) {
+			handshakeNATS(t, conn)
+			if line := expectNATS(t, conn, "SUB "); line != "SUB events workers 1" {
+				t.Errorf("Got %q; want %q", line, "SUB events workers 1")
+			}
+			io.WriteString(conn, "PING\r\n")
+			expectNATS(t, conn, "PONG")
+			io.WriteString(conn, natsMsg(t, "events", "_INBOX.reply", 1))
+			io.WriteString(conn, "MSG events 1 7\r\nbroken!\r\n")
+			io.WriteString(conn, natsMsg(t, "events", "", 2))
+			// Disconnect to make the subscriber reconnect.
+		},
+		func(conn *scriptedConn) {
+			handshakeNATS(t, conn)
+			expectNATS(t, conn, "SUB ")
+			io.WriteString(conn, "-ERR 'Stale Connection'\r\n")
+		},
+		func(conn *scriptedConn) {
+			handshakeNATS(t, conn)
+			expectNATS(t, conn, "SUB ")
+			io.WriteString(conn, natsMsg(t, "events", "", 3))
+		},
+	)
+
+	sub, err := pubsub.NewSubscriber(ctx, "nats://"+addr+"/events?queue=workers", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	ids := receiveEvents(t, sub, 3)
+	if got, want := fmt.Sprint(ids), "[1 2 3]"; got != want {
+		t.Errorf("Received %s; want %s", got, want)
+	}
+}
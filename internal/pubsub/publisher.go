@@ -2,44 +2,71 @@ package pubsub
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"strings"
 
-	"cloud.google.com/go/compute/metadata"
-	"cloud.google.com/go/pubsub"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/nya3jp/flex"
 )
 
-type Publisher struct {
-	client *pubsub.Client
-	topic  *pubsub.Topic
+// Publisher publishes job events to a message broker.
+type Publisher interface {
+	Publish(ctx context.Context, event *flex.JobEvent) error
+	Close() error
 }
 
-func NewPublisher(ctx context.Context, topicID string) (*Publisher, error) {
-	projectID, err := metadata.ProjectID()
-	if err != nil {
-		return nil, err
+// NewPublisher creates a Publisher from a URL. Supported URLs are:
+//
+//	gcppubsub://PROJECT/TOPIC  Cloud Pub/Sub topic
+//	nats://HOST:PORT/SUBJECT   NATS subject
+//	redis://HOST:PORT/KEY      Redis stream (?maxlen=N to cap its length)
+//	http(s)://...              HTTP endpoint to POST events to
+//
+// A string without a scheme is taken as a Cloud Pub/Sub topic ID in the
+// project the program is running in.
+func NewPublisher(ctx context.Context, rawURL string) (Publisher, error) {
+	if !strings.Contains(rawURL, "://") {
+		return newGCPPublisher(ctx, "", rawURL)
 	}
 
-	client, err := pubsub.NewClient(ctx, projectID)
+	parsed, err := url.Parse(rawURL)
 	if err != nil {
 		return nil, err
 	}
+	switch parsed.Scheme {
+	case "gcppubsub":
+		return newGCPPublisher(ctx, parsed.Host, strings.TrimPrefix(parsed.Path, "/"))
+	case "nats":
+		return newNATSPublisher(parsed)
+	case "redis":
+		return newRedisPublisher(parsed)
+	case "http", "https":
+		return newHTTPPublisher(rawURL), nil
+	default:
+		return nil, fmt.Errorf("unknown publisher scheme: %s", parsed.Scheme)
+	}
+}
 
-	topic := client.Topic(topicID)
+var eventMarshalOptions = protojson.MarshalOptions{
+	EmitUnpopulated: true,
+}
 
-	return &Publisher{
-		client: client,
-		topic:  topic,
-	}, nil
+var eventUnmarshalOptions = protojson.UnmarshalOptions{
+	DiscardUnknown: true,
 }
 
-func (p *Publisher) Close() error {
-	p.topic.Stop()
-	return p.client.Close()
+// MarshalEvent encodes a job event in JSON.
+func MarshalEvent(event *flex.JobEvent) ([]byte, error) {
+	return eventMarshalOptions.Marshal(event)
 }
 
-func (p *Publisher) Send(ctx context.Context) error {
-	result := p.topic.Publish(ctx, &pubsub.Message{
-		Data: []byte("{}"),
-	})
-	_, err := result.Get(ctx)
-	return err
+// UnmarshalEvent decodes a job event encoded by MarshalEvent.
+func UnmarshalEvent(data []byte) (*flex.JobEvent, error) {
+	var event flex.JobEvent
+	if err := eventUnmarshalOptions.Unmarshal(data, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
 }
@@ -0,0 +1,133 @@
+package pubsub_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/internal/pubsub"
+)
+
+// scriptedConn is a connection accepted by a scripted server.
+type scriptedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+// scriptedServer starts a TCP server that calls scripts in order for each
+// accepted connection, and returns its address. Connections accepted after
+// all scripts are used are closed immediately. A connection is closed when its
+// script returns.
+func scriptedServer(t *testing.T, scripts ...func(conn *scriptedConn)) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	t.Cleanup(func() {
+		lis.Close()
+		wg.Wait()
+	})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			if i >= len(scripts) {
+				conn.Close()
+				continue
+			}
+			script := scripts[i]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer conn.Close()
+				conn.SetDeadline(time.Now().Add(10 * time.Second))
+				script(&scriptedConn{Conn: conn, r: bufio.NewReader(conn)})
+			}()
+		}
+	}()
+	return lis.Addr().String()
+}
+
+// receiveEvents calls sub.Receive until n events are received, and returns
+// the IDs of their jobs.
+func receiveEvents(t *testing.T, sub pubsub.Subscriber, n int) []int64 {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var mu sync.Mutex
+	var ids []int64
+	err := sub.Receive(ctx, func(ctx context.Context, event *flex.JobEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		ids = append(ids, event.GetJobId())
+		if len(ids) == n {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Receive: %v; want %v", err, context.Canceled)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	return ids
+}
+
+func TestChannel(t *testing.T) {
+	ctx := context.Background()
+	ch := pubsub.NewChannel(2)
+
+	for _, id := range []int64{1, 2} {
+		if err := ch.Publish(ctx, &flex.JobEvent{JobId: id}); err != nil {
+			t.Fatalf("Publish(%d): %v", id, err)
+		}
+	}
+
+	// The buffer is full, so Publish blocks until ctx expires.
+	shortCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := ch.Publish(shortCtx, &flex.JobEvent{JobId: 3}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Publish to full channel: %v; want %v", err, context.DeadlineExceeded)
+	}
+
+	if ids := receiveEvents(t, ch, 2); len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("Received %v; want [1 2]", ids)
+	}
+}
+
+func TestChannel_Close(t *testing.T) {
+	ctx := context.Background()
+	ch := pubsub.NewChannel(1)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- ch.Receive(ctx, func(ctx context.Context, event *flex.JobEvent) {})
+	}()
+
+	if err := ch.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := ch.Close(); err != nil {
+		t.Errorf("Second Close: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Receive after Close: %v; want nil", err)
+	}
+	if err := ch.Publish(ctx, &flex.JobEvent{JobId: 1}); err == nil {
+		t.Error("Publish after Close succeeded; want error")
+	}
+}
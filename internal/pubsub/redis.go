@@ -0,0 +1,320 @@
+package pubsub
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/internal/concurrent"
+)
+
+const (
+	defaultRedisPort = "6379"
+	redisEventField  = "event"
+	redisBlockTime   = 5 * time.Second
+)
+
+// redisConn is a minimal client of the Redis serialization protocol.
+// https://redis.io/docs/reference/protocol-spec/
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func dialRedis(ctx context.Context, u *url.URL) (*redisConn, error) {
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), defaultRedisPort)
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bound authentication by ctx as well as dialing.
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	c := &redisConn{conn: conn, r: bufio.NewReader(conn)}
+	if u.User != nil {
+		args := []string{"AUTH"}
+		if pass, ok := u.User.Password(); ok {
+			if name := u.User.Username(); name != "" {
+				args = append(args, name)
+			}
+			args = append(args, pass)
+		} else {
+			args = append(args, u.User.Username())
+		}
+		if _, err := c.Do(args...); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	conn.SetDeadline(time.Time{})
+	return c, nil
+}
+
+// Do sends a command and returns its reply. A reply is one of string, int64,
+// []interface{} and nil.
+func (c *redisConn) Do(args ...string) (interface{}, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(c.conn, b.String()); err != nil {
+		return nil, fmt.Errorf("redis: %w", err)
+	}
+	reply, err := c.readReply()
+	if err != nil {
+		return nil, fmt.Errorf("redis: %w", err)
+	}
+	return reply, nil
+}
+
+func (c *redisConn) readReply() (interface{}, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, errors.New(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, data); err != nil {
+			return nil, err
+		}
+		return string(data[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		values := make([]interface{}, n)
+		for i := range values {
+			values[i], err = c.readReply()
+			if err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("malformed reply: %s", line)
+	}
+}
+
+func (c *redisConn) Close() error {
+	return c.conn.Close()
+}
+
+type redisPublisher struct {
+	url    *url.URL
+	key    string
+	maxLen string
+
+	mu   sync.Mutex
+	conn *redisConn // lazily (re)connected
+}
+
+func newRedisPublisher(u *url.URL) (*redisPublisher, error) {
+	key := strings.TrimPrefix(u.Path, "/")
+	if key == "" {
+		return nil, errors.New("redis: stream key missing in URL")
+	}
+	maxLen := u.Query().Get("maxlen")
+	if maxLen != "" {
+		if _, err := strconv.ParseInt(maxLen, 10, 64); err != nil {
+			return nil, fmt.Errorf("redis: invalid maxlen: %s", maxLen)
+		}
+	}
+	return &redisPublisher{url: u, key: key, maxLen: maxLen}, nil
+}
+
+func (p *redisPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn = nil
+	return err
+}
+
+func (p *redisPublisher) Publish(ctx context.Context, event *flex.JobEvent) error {
+	data, err := MarshalEvent(event)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		conn, err := dialRedis(ctx, p.url)
+		if err != nil {
+			return err
+		}
+		p.conn = conn
+	}
+
+	args := []string{"XADD", p.key}
+	if p.maxLen != "" {
+		args = append(args, "MAXLEN", "~", p.maxLen)
+	}
+	args = append(args, "*", redisEventField, string(data))
+
+	if err := func() error {
+		if deadline, ok := ctx.Deadline(); ok {
+			p.conn.conn.SetDeadline(deadline)
+			defer p.conn.conn.SetDeadline(time.Time{})
+		}
+		_, err := p.conn.Do(args...)
+		return err
+	}(); err != nil {
+		p.conn.Close()
+		p.conn = nil
+		return err
+	}
+	return nil
+}
+
+type redisSubscriber struct {
+	url            *url.URL
+	key            string
+	maxOutstanding int
+}
+
+func newRedisSubscriber(u *url.URL, maxOutstanding int) (*redisSubscriber, error) {
+	key := strings.TrimPrefix(u.Path, "/")
+	if key == "" {
+		return nil, errors.New("redis: stream key missing in URL")
+	}
+	return &redisSubscriber{url: u, key: key, maxOutstanding: maxOutstanding}, nil
+}
+
+func (s *redisSubscriber) Close() error {
+	return nil
+}
+
+// Receive reads events appended to the stream after it is called.
+func (s *redisSubscriber) Receive(ctx context.Context, f func(ctx context.Context, event *flex.JobEvent)) error {
+	d := newDispatcher(s.maxOutstanding)
+	defer d.Wait()
+
+	lastID := "$"
+	retry := concurrent.NewRetry(time.Second, time.Minute)
+	for {
+		err := s.receiveOnce(ctx, d, f, &lastID, retry)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		log.Printf("WARNING: Redis subscription failed; reconnecting: %v", err)
+		if err := retry.Wait(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *redisSubscriber) receiveOnce(ctx context.Context, d *dispatcher, f func(ctx context.Context, event *flex.JobEvent), lastID *string, retry *concurrent.Retry) error {
+	conn, err := dialRedis(ctx, s.url)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// Unblock reads on cancellation.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	blockMillis := strconv.FormatInt(int64(redisBlockTime/time.Millisecond), 10)
+	for {
+		reply, err := conn.Do("XREAD", "COUNT", "100", "BLOCK", blockMillis, "STREAMS", s.key, *lastID)
+		if err != nil {
+			return err
+		}
+		retry.Clear()
+		if reply == nil {
+			continue // timed out
+		}
+
+		// Reply: [[key, [[id, [field, value, ...]], ...]]]
+		streams, ok := reply.([]interface{})
+		if !ok || len(streams) == 0 {
+			return fmt.Errorf("redis: unexpected XREAD reply: %v", reply)
+		}
+		stream, ok := streams[0].([]interface{})
+		if !ok || len(stream) != 2 {
+			return fmt.Errorf("redis: unexpected XREAD reply: %v", reply)
+		}
+		entries, ok := stream[1].([]interface{})
+		if !ok {
+			return fmt.Errorf("redis: unexpected XREAD reply: %v", reply)
+		}
+
+		for _, e := range entries {
+			entry, ok := e.([]interface{})
+			if !ok || len(entry) != 2 {
+				return fmt.Errorf("redis: unexpected stream entry: %v", e)
+			}
+			id, _ := entry[0].(string)
+			fields, _ := entry[1].([]interface{})
+			*lastID = id
+
+			for i := 0; i+1 < len(fields); i += 2 {
+				if name, _ := fields[i].(string); name != redisEventField {
+					continue
+				}
+				value, _ := fields[i+1].(string)
+				event, err := UnmarshalEvent([]byte(value))
+				if err != nil {
+					log.Printf("WARNING: Dropped a malformed event: %v", err)
+					continue
+				}
+				if err := d.Dispatch(ctx, f, event); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package pubsub_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/internal/pubsub"
+)
+
+// readRedisCommand reads a command sent by a client as an array of bulk
+// strings.
+func readRedisCommand(t *testing.T, conn *scriptedConn) []string {
+	t.Helper()
+	readLine := func(prefix byte) int {
+		line, err := conn.r.ReadString('\n')
+		if err != nil {
+			t.Errorf("Reading command: %v", err)
+			return -1
+		}
+		if line[0] != prefix {
+			t.Errorf("Got %q; want %q", line, prefix)
+			return -1
+		}
+		n, err := strconv.Atoi(strings.TrimRight(line[1:], "\r\n"))
+		if err != nil {
+			t.Errorf("Malformed line: %q", line)
+			return -1
+		}
+		return n
+	}
+
+	n := readLine('*')
+	var args []string
+	for i := 0; i < n; i++ {
+		size := readLine('$')
+		if size < 0 {
+			return nil
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(conn.r, data); err != nil {
+			t.Errorf("Reading command: %v", err)
+			return nil
+		}
+		args = append(args, string(data[:size]))
+	}
+	return args
+}
+
+// expectRedis reads a command and checks that it is want, where "?" in want
+// matches any argument.
+func expectRedis(t *testing.T, conn *scriptedConn, want ...string) []string {
+	t.Helper()
+	args := readRedisCommand(t, conn)
+	ok := len(args) == len(want)
+	for i := 0; ok && i < len(want); i++ {
+		ok = want[i] == "?" || want[i] == args[i]
+	}
+	if !ok {
+		t.Errorf("Got command %q; want %q", args, want)
+	}
+	return args
+}
+
+func redisBulk(s string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(s), s)
+}
+
+// redisXREADReply returns an XREAD reply containing an entry for each job ID,
+// which is also used as the entry ID.
+func redisXREADReply(t *testing.T, key string, ids ...int64) string {
+	t.Helper()
+	var b strings.Builder
+	fmt.Fprintf(&b, "*1\r\n*2\r\n%s*%d\r\n", redisBulk(key), len(ids))
+	for _, id := range ids {
+		data, err := pubsub.MarshalEvent(&flex.JobEvent{JobId: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(&b, "*2\r\n%s*4\r\n%s%s%s%s", redisBulk(fmt.Sprintf("%d-0", id)), redisBulk("other"), redisBulk("ignored"), redisBulk("event"), redisBulk(string(data)))
+	}
+	return b.String()
+}
+
+func TestRedisPublisher(t *testing.T) {
+	ctx := context.Background()
+
+	addr := scriptedServer(t,
+		func(conn *scriptedConn) {
+			expectRedis(t, conn, "AUTH", "alice", "secret")
+			io.WriteString(conn, "+OK\r\n")
+
+			args := expectRedis(t, conn, "XADD", "events", "MAXLEN", "~", "100", "*", "event", "?")
+			if len(args) > 0 {
+				event, err := pubsub.UnmarshalEvent([]byte(args[len(args)-1]))
+				if err != nil {
+					t.Errorf("UnmarshalEvent: %v", err)
+				} else if id := event.GetJobId(); id != 1 {
+					t.Errorf("Published job %d; want 1", id)
+				}
+			}
+			io.WriteString(conn, redisBulk("1-0"))
+
+			expectRedis(t, conn, "XADD", "events", "MAXLEN", "~", "100", "*", "event", "?")
+			io.WriteString(conn, "-ERR OOM command not allowed\r\n")
+		},
+		func(conn *scriptedConn) {
+			expectRedis(t, conn, "AUTH", "alice", "secret")
+			io.WriteString(conn, "+OK\r\n")
+			expectRedis(t, conn, "XADD", "events", "MAXLEN", "~", "100", "*", "event", "?")
+			io.WriteString(conn, redisBulk("3-0"))
+		},
+	)
+
+	pub, err := pubsub.NewPublisher(ctx, "redis://alice:secret@"+addr+"/events?maxlen=100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pub.Close()
+
+	if err := pub.Publish(ctx, &flex.JobEvent{JobId: 1}); err != nil {
+		t.Errorf("Publish(1): %v", err)
+	}
+	if err := pub.Publish(ctx, &flex.JobEvent{JobId: 2}); err == nil || !strings.Contains(err.Error(), "OOM") {
+		t.Errorf("Publish(2): %v; want OOM error", err)
+	}
+	// The publisher reconnects after an error.
+	if err := pub.Publish(ctx, &flex.JobEvent{JobId: 3}); err != nil {
+		t.Errorf("Publish(3): %v", err)
+	}
+}
+
+func TestRedisSubscriber(t *testing.T) {
+	ctx := context.Background()
+
+	addr := scriptedServer(t,
+		func(conn *scriptedConn) {
+			// BLOCK timed out.
+			expectRedis(t, conn, "XREAD", "COUNT", "100", "BLOCK", "?", "STREAMS", "events", "$")
+			io.WriteString(conn, "*-1\r\n")
+
+			expectRedis(t, conn, "XREAD", "COUNT", "100", "BLOCK", "?", "STREAMS", "events", "$")
+			io.WriteString(conn, redisXREADReply(t, "events", 1, 2))
+
+			// Disconnect to make the subscriber reconnect.
+			expectRedis(t, conn, "XREAD", "COUNT", "100", "BLOCK", "?", "STREAMS", "events", "2-0")
+		},
+		func(conn *scriptedConn) {
+			// The subscriber resumes after the last entry it has seen.
+			expectRedis(t, conn, "XREAD", "COUNT", "100", "BLOCK", "?", "STREAMS", "events", "2-0")
+			io.WriteString(conn, "-ERR LOADING Redis is loading the dataset in memory\r\n")
+		},
+		func(conn *scriptedConn) {
+			expectRedis(t, conn, "XREAD", "COUNT", "100", "BLOCK", "?", "STREAMS", "events", "2-0")
+			io.WriteString(conn, redisXREADReply(t, "events", 3))
+		},
+	)
+
+	sub, err := pubsub.NewSubscriber(ctx, "redis://"+addr+"/events", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Close()
+
+	ids := receiveEvents(t, sub, 3)
+	if got, want := fmt.Sprint(ids), "[1 2 3]"; got != want {
+		t.Errorf("Received %s; want %s", got, want)
+	}
+}
@@ -2,43 +2,80 @@ package pubsub
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
 
-	"cloud.google.com/go/compute/metadata"
-	"cloud.google.com/go/pubsub"
+	"github.com/nya3jp/flex"
+	"github.com/nya3jp/flex/internal/concurrent"
 )
 
-type Message = pubsub.Message
-
-type Subscriber struct {
-	client       *pubsub.Client
-	subscription *pubsub.Subscription
+// Subscriber receives job events from a message broker.
+type Subscriber interface {
+	// Receive calls f for each event received until ctx is canceled. f may be
+	// called concurrently up to the number of outstanding events specified on
+	// creation. Events are acknowledged before f is called.
+	Receive(ctx context.Context, f func(ctx context.Context, event *flex.JobEvent)) error
+	Close() error
 }
 
-func NewSubscriber(ctx context.Context, subscriptionID string, maxOutstandingMessages int) (*Subscriber, error) {
-	projectID, err := metadata.ProjectID()
-	if err != nil {
-		return nil, err
+// NewSubscriber creates a Subscriber from a URL. Supported URLs are:
+//
+//	gcppubsub://PROJECT/SUBSCRIPTION  Cloud Pub/Sub subscription
+//	nats://HOST:PORT/SUBJECT          NATS subject (?queue=GROUP to join a queue group)
+//	redis://HOST:PORT/KEY             Redis stream
+//
+// A string without a scheme is taken as a Cloud Pub/Sub subscription ID in the
+// project the program is running in.
+func NewSubscriber(ctx context.Context, rawURL string, maxOutstanding int) (Subscriber, error) {
+	if !strings.Contains(rawURL, "://") {
+		return newGCPSubscriber(ctx, "", rawURL, maxOutstanding)
 	}
 
-	client, err := pubsub.NewClient(ctx, projectID)
+	parsed, err := url.Parse(rawURL)
 	if err != nil {
 		return nil, err
 	}
+	switch parsed.Scheme {
+	case "gcppubsub":
+		return newGCPSubscriber(ctx, parsed.Host, strings.TrimPrefix(parsed.Path, "/"), maxOutstanding)
+	case "nats":
+		return newNATSSubscriber(parsed, maxOutstanding)
+	case "redis":
+		return newRedisSubscriber(parsed, maxOutstanding)
+	default:
+		return nil, fmt.Errorf("unknown subscriber scheme: %s", parsed.Scheme)
+	}
+}
 
-	subscription := client.Subscription(subscriptionID)
-	subscription.ReceiveSettings.MaxOutstandingMessages = maxOutstandingMessages
-	subscription.ReceiveSettings.Synchronous = true
+// dispatcher calls a callback in goroutines, up to a limit at a time.
+type dispatcher struct {
+	limiter *concurrent.Limiter
+	wg      sync.WaitGroup
+}
 
-	return &Subscriber{
-		client:       client,
-		subscription: subscription,
-	}, nil
+func newDispatcher(maxOutstanding int) *dispatcher {
+	if maxOutstanding <= 0 {
+		maxOutstanding = 1
+	}
+	return &dispatcher{limiter: concurrent.NewLimiter(maxOutstanding)}
 }
 
-func (s *Subscriber) Close() error {
-	return s.client.Close()
+func (d *dispatcher) Dispatch(ctx context.Context, f func(ctx context.Context, event *flex.JobEvent), event *flex.JobEvent) error {
+	if err := d.limiter.Take(ctx); err != nil {
+		return err
+	}
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		defer d.limiter.Done()
+		f(ctx, event)
+	}()
+	return nil
 }
 
-func (s *Subscriber) Receive(ctx context.Context, f func(ctx context.Context, msg *Message)) error {
-	return s.subscription.Receive(ctx, f)
+// Wait waits for all callbacks to return.
+func (d *dispatcher) Wait() {
+	d.wg.Wait()
 }
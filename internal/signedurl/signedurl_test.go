@@ -0,0 +1,67 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signedurl_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/nya3jp/flex/internal/signedurl"
+)
+
+func TestVerify(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Unix(1600000000, 0)
+	base, err := url.Parse("http://localhost/fs/packages/abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	get := signedurl.Sign(secret, http.MethodGet, base, now.Add(time.Minute))
+	put := signedurl.Sign(secret, http.MethodPut, base, now.Add(time.Minute))
+
+	other := *get
+	other.Path = "/fs/packages/def"
+
+	for _, tc := range []struct {
+		name   string
+		method string
+		u      *url.URL
+		secret []byte
+		now    time.Time
+		want   error
+	}{
+		{name: "get", method: http.MethodGet, u: get, secret: secret, now: now},
+		{name: "head", method: http.MethodHead, u: get, secret: secret, now: now},
+		{name: "put", method: http.MethodPut, u: put, secret: secret, now: now},
+		{name: "unsigned", method: http.MethodGet, u: base, secret: secret, now: now, want: signedurl.ErrUnsigned},
+		{name: "expired", method: http.MethodGet, u: get, secret: secret, now: now.Add(2 * time.Minute), want: signedurl.ErrExpired},
+		{name: "wrong method", method: http.MethodPut, u: get, secret: secret, now: now, want: signedurl.ErrInvalid},
+		{name: "wrong path", method: http.MethodGet, u: &other, secret: secret, now: now, want: signedurl.ErrInvalid},
+		{name: "wrong secret", method: http.MethodGet, u: get, secret: []byte("other"), now: now, want: signedurl.ErrInvalid},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, tc.u.String(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := signedurl.Verify(tc.secret, req, tc.now); !errors.Is(err, tc.want) {
+				t.Errorf("Verify() = %v; want %v", err, tc.want)
+			}
+		})
+	}
+}
@@ -57,6 +57,33 @@ export interface FlexletStatus {
   flexlet: Flexlet
   state: FlexletState
   currentJobs: Job[]
+  cordoned?: boolean
+  lostTasks?: number
+  lastUpdate?: string
+  host?: FlexletHostInfo
+  unhealthy?: boolean
+  healthError?: string
+}
+
+export interface FlexletHostInfo {
+  version: string
+  os: string
+  kernel: string
+  totalMemoryBytes: string
+  freeMemoryBytes: string
+  diskFreeBytes: string
+  cacheBytes: string
+  loadAverage: number[]
+  uptime: string
+  cache?: FlexletCacheStats
+}
+
+export interface FlexletCacheStats {
+  entries?: number
+  maxBytes?: string
+  hits?: string
+  misses?: string
+  evictions?: string
 }
 
 export interface Flexlet {
@@ -68,7 +95,7 @@ export interface FlexletSpec {
   cores: number
 }
 
-export type FlexletState = 'OFFLINE' | 'ONLINE';
+export type FlexletState = 'OFFLINE' | 'ONLINE' | 'DRAINING';
 
 export interface Stats {
   job: JobStats
@@ -69,7 +69,7 @@ export default function FlexletsPage() {
   useEffect(() => {
     (async () => {
       const all = await client.listFlexlets();
-      const onlines = all.filter((flexlet) => flexlet.state === 'ONLINE');
+      const onlines = all.filter((flexlet) => flexlet.state !== 'OFFLINE');
       setFlexlets(onlines);
     })();
   }, [client, setFlexlets]);